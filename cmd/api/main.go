@@ -2,37 +2,182 @@ package main
 
 import (
 	v1 "challenge-yuno/cmd/api/v1"
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/usecases/audit"
+	"challenge-yuno/internal/business/usecases/dining"
+	"challenge-yuno/internal/business/usecases/inventory"
+	"challenge-yuno/internal/business/usecases/notification"
 	"challenge-yuno/internal/business/usecases/order"
+	"challenge-yuno/internal/business/usecases/report"
+	"challenge-yuno/internal/business/usecases/reservation"
+	"challenge-yuno/internal/platform/broadcast"
+	"challenge-yuno/internal/platform/config"
+	"challenge-yuno/internal/platform/health"
+	"challenge-yuno/internal/platform/lifecycle"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/metrics"
+	"challenge-yuno/internal/platform/problem"
+	"challenge-yuno/internal/platform/ratelimit"
 	"challenge-yuno/internal/platform/repositories/kvstore"
 	"challenge-yuno/internal/platform/repositories/sql"
+	"challenge-yuno/internal/platform/tracing"
 	"challenge-yuno/internal/services"
+	"context"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"log/slog"
+	"os"
+	"time"
 )
 
 func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("error loading config", slog.Any("error", err))
+		panic(err)
+	}
+
+	logger := logging.New(os.Stdout, cfg.Logging)
+	slog.SetDefault(logger)
+
+	var spanExporter sdktrace.SpanExporter
+	if len(cfg.TracingEndpoint) > 0 {
+		spanExporter, err = otlptracehttp.New(context.Background())
+		if err != nil {
+			logger.Error("error creating span exporter", slog.Any("error", err))
+			panic(err)
+		}
+	}
+	tracerProvider := tracing.NewProvider(spanExporter)
+
 	dsn := "host=postgres user=user password=password dbname=postgres port=5432 sslmode=disable TimeZone=America/Argentina/Mendoza"
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
-		log.Errorf("error connecting to db %v", err)
+		logger.Error("error connecting to db", slog.Any("error", err))
 		panic(err)
 	}
 	db.Debug()
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Error("error getting db pool", slog.Any("error", err))
+		panic(err)
+	}
+
+	// a database from before branches only holds orders of the first one
+	if err = sql.Migrate(context.Background(), db, cfg.Branches[0].ID, logger); err != nil {
+		logger.Error("error migrating db", slog.Any("error", err))
+		panic(err)
+	}
 
-	kvsOrderRepo := kvstore.NewOrderRepository()
-	sqlOrderRepo := sql.NewOrderRepository(db)
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	appMetrics := metrics.New(registry)
+	if err = db.Use(appMetrics.GormPlugin()); err != nil {
+		logger.Error("error registering db metrics", slog.Any("error", err))
+		panic(err)
+	}
+
+	branchRepo := kvstore.NewBranchRepository(cfg.Branches)
+	kvsOrderRepo := kvstore.NewOrderRepository(logger)
+	dbTimeouts := sql.Timeouts{Default: cfg.DBTimeout, Operations: cfg.DBOperationTimeouts}
+	sqlOrderRepo := sql.NewOrderRepository(db, dbTimeouts, logger)
+	sqlAuditRepo := sql.NewAuditRepository(db, dbTimeouts, logger)
+	sqlOutboxRepo := sql.NewOutboxRepository(db, dbTimeouts, logger)
+	sqlDiningRepo := sql.NewDiningRepository(db, dbTimeouts, logger)
+	sqlReservationRepo := sql.NewReservationRepository(db, dbTimeouts, logger)
+	sqlInventoryRepo := sql.NewInventoryRepository(db, dbTimeouts, logger)
+	sqlReportRepo := sql.NewReportRepository(db, dbTimeouts, logger)
+	unitOfWork := sql.NewUnitOfWork(db, sqlOrderRepo, sqlInventoryRepo, sqlOutboxRepo, sqlDiningRepo, sqlReservationRepo, logger)
+	menuRepo := kvstore.NewMenuRepository(cfg.Menu)
+	metrics.RegisterQueueDepth(registry, sqlOrderRepo.CountActiveOrders)
+
+	appHealth := health.New()
+	appHealth.AddCheck("database", sql.PingCheck(db))
+	appHealth.AddCheck("migrations", sql.MigrationsCheck(db))
+	appHealth.SetBacklogSource(sqlOutboxRepo.Backlog)
 
-	notificationService := services.NewNotificationService("whatsapp")
-	orderUsecase := order.NewOrderUsecase(kvsOrderRepo, sqlOrderRepo, notificationService)
+	notificationService := appMetrics.InstrumentNotifications(services.NewNotificationService("whatsapp", cfg.NotificationWebhookURL, logger))
+	orderUsecase := order.NewOrderUsecase(kvsOrderRepo, sqlOrderRepo, sqlOutboxRepo, sqlInventoryRepo, unitOfWork,
+		broadcast.NewHub[[]model.Order](), menuRepo, cfg.PrepTimeEstimates, appMetrics, logger)
+	notificationUsecase := notification.NewNotificationUsecase(sqlOutboxRepo, notificationService, logger)
+	auditUsecase := audit.NewAuditUsecase(sqlAuditRepo)
+	diningUsecase := dining.NewDiningUsecase(sqlDiningRepo, sqlOrderRepo, menuRepo, logger)
+	inventoryUsecase := inventory.NewInventoryUsecase(sqlInventoryRepo, menuRepo, logger)
+	reportUsecase := report.NewReportUsecase(sqlReportRepo, branchRepo, menuRepo, logger)
+	reservationUsecase := reservation.NewReservationUsecase(sqlReservationRepo, sqlDiningRepo, unitOfWork, orderUsecase, cfg.ReservationDuration, logger)
+
+	var limiter ratelimit.Limiter = ratelimit.NewMemoryLimiter()
+	var workers []lifecycle.Component
+	if cfg.RateLimitStore == config.RateLimitStorePostgres {
+		rateLimitRepo := sql.NewRateLimitRepository(db, dbTimeouts, logger)
+		limiter = rateLimitRepo
+		workers = append(workers, lifecycle.Periodic("rate-limit-pruner", time.Hour, func(ctx context.Context) error {
+			return rateLimitRepo.Prune(ctx, time.Now().Add(-24*time.Hour))
+		}, logger))
+	}
+
+	spec, err := v1.LoadSpec()
+	if err != nil {
+		logger.Error("error loading openapi spec", slog.Any("error", err))
+		panic(err)
+	}
 
 	e := echo.New()
 
 	e.Debug = true
 	e.HideBanner = true
+	e.HTTPErrorHandler = problem.ErrorHandler(logger)
+	e.IPExtractor = v1.ClientIP(cfg.TrustedProxies)
+	e.Use(logging.Middleware(logger))
+	e.Use(appMetrics.Middleware())
+	e.Use(tracing.Middleware())
+	e.Use(v1.BranchAccess(cfg.APIKeyBranches))
+	e.Use(v1.RateLimit(limiter, cfg.RateLimits, cfg.RateLimitKey, cfg.APIKeys, branchRepo, logging.Named(logger, "http")))
+	e.Use(v1.ValidateRequests(spec))
 
-	v1.NewOrderHandler(e, orderUsecase)
+	v1.NewMetricsHandler(e, registry)
+	v1.NewHealthHandler(e, appHealth)
+	v1.NewOpenAPIHandler(e)
 
-	e.Logger.Fatal(e.Start(":8080"))
+	v1.NewOrderHandler(e, orderUsecase, auditUsecase, branchRepo, cfg.AdminAPIKey, logger)
+	v1.NewAuditHandler(e, auditUsecase, branchRepo)
+	v1.NewTableHandler(e, diningUsecase, branchRepo)
+	v1.NewReservationHandler(e, reservationUsecase, auditUsecase, branchRepo, logger)
+	v1.NewInventoryHandler(e, inventoryUsecase, branchRepo)
+	v1.NewReportHandler(e, reportUsecase, branchRepo)
+
+	// readiness fails first and the manager waits DrainDelay, so the load balancer has seen it and
+	// stopped sending traffic by the time the server stops accepting requests
+	manager := lifecycle.New(cfg.ShutdownTimeout, cfg.DrainDelay, logger)
+	manager.BeforeStop(appHealth.Drain)
+	manager.Add(
+		lifecycle.Closer("database", sqlDB.Close),
+		lifecycle.Closer("tracing", func() error { return tracerProvider.Shutdown(context.Background()) }),
+		lifecycle.Periodic("outbox-dispatcher", cfg.OutboxPollInterval, notificationUsecase.DispatchPending, logger),
+		lifecycle.Periodic("sla-checker", cfg.SLACheckInterval, func(ctx context.Context) error {
+			return orderUsecase.CheckSLA(ctx, cfg.OrderSLAs)
+		}, logger),
+		lifecycle.Periodic("day-closer", cfg.DayCloseInterval, func(ctx context.Context) error {
+			// the day is snapshotted first so its totals are kept whatever happens to the orders
+			if err := reportUsecase.CloseDay(ctx, time.Now()); err != nil {
+				return err
+			}
+			return orderUsecase.ArchiveClosed(ctx, cfg.ArchiveAfter)
+		}, logger),
+		lifecycle.Periodic("scheduled-promoter", cfg.SchedulerInterval, func(ctx context.Context) error {
+			return orderUsecase.PromoteScheduled(ctx, cfg.ScheduleLeadTime, cfg.PrepTimeEstimates)
+		}, logger),
+	)
+	manager.Add(workers...)
+	manager.Add(lifecycle.Server(e, ":8080"))
+
+	if err = manager.RunUntilSignal(); err != nil {
+		logger.Error("application stopped with errors", slog.Any("error", err))
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const HeaderAdminKey = "X-Admin-Key"
+
+// RequireAdminKey only lets through requests carrying the configured admin key. When no key is
+// configured the routes behind it are disabled.
+func RequireAdminKey(adminKey string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if len(adminKey) == 0 {
+				return echo.NewHTTPError(http.StatusForbidden, "admin endpoints are disabled")
+			}
+
+			key := c.Request().Header.Get(HeaderAdminKey)
+			if subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) != 1 {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid admin key")
+			}
+
+			return next(c)
+		}
+	}
+}
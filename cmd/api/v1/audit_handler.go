@@ -0,0 +1,148 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/audit"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderActor = "X-Actor"
+
+	anonymousActor = "anonymous"
+	mimeNDJSON     = "application/x-ndjson"
+)
+
+type AuditHandler struct {
+	AuditUsecase interfaces.AuditUsecase
+}
+
+func NewAuditHandler(e *echo.Echo, auditUsecase interfaces.AuditUsecase, branchRepository interfaces.BranchRepository) {
+	handler := &AuditHandler{
+		AuditUsecase: auditUsecase,
+	}
+
+	g := e.Group("/audit", BranchScope(branchRepository))
+	g.GET("", handler.ListEntries)
+	g.GET("/export", handler.ExportEntries)
+}
+
+func (h *AuditHandler) ListEntries(c echo.Context) error {
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.AuditUsecase.ListEntries(c.Request().Context(), branch, filter)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *AuditHandler) ExportEntries(c echo.Context) error {
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := auditFilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, mimeNDJSON)
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit.ndjson"`)
+
+	encoder := json.NewEncoder(res)
+	return h.AuditUsecase.ExportEntries(c.Request().Context(), branch, filter, func(entry model.Entry) error {
+		if !res.Committed {
+			res.WriteHeader(http.StatusOK)
+		}
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	})
+}
+
+func auditFilterFromQuery(c echo.Context) (model.Filter, error) {
+	filter := model.Filter{
+		Actor:    c.QueryParam("actor"),
+		Endpoint: c.QueryParam("endpoint"),
+		OrderID:  c.QueryParam("order_id"),
+	}
+
+	for param, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.QueryParam(param)
+		if len(value) == 0 {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return model.Filter{}, errs.Invalid("invalid_filter", param+" must be an RFC3339 timestamp")
+		}
+		*target = &t
+	}
+
+	if value := c.QueryParam("limit"); len(value) > 0 {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return model.Filter{}, errs.Invalid("invalid_filter", "limit must be a positive number")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// auditEntryFromContext collects who made the request and from where. The actor comes from the
+// X-Actor header until the API has proper authentication.
+func auditEntryFromContext(c echo.Context, orderID string) model.Entry {
+	actor := c.Request().Header.Get(HeaderActor)
+	if len(actor) == 0 {
+		actor = anonymousActor
+	}
+
+	requestID := logging.RequestID(c.Request().Context())
+	if len(requestID) == 0 {
+		requestID = c.Request().Header.Get(echo.HeaderXRequestID)
+	}
+
+	return model.Entry{
+		Actor:     actor,
+		Endpoint:  c.Request().Method + " " + c.Path(),
+		OrderID:   orderID,
+		ClientIP:  c.RealIP(),
+		RequestID: requestID,
+	}
+}
+
+// recordAudit stores who changed the order and how. The change is already persisted at this
+// point, so a failure to write the audit entry is logged instead of failing the request.
+func recordAudit(ctx context.Context, c echo.Context, auditUsecase interfaces.AuditUsecase, logger *slog.Logger,
+	branch tenant.Branch, before, after *order.Order) {
+	entry := auditEntryFromContext(c, after.ID)
+	if err := auditUsecase.Record(ctx, branch, entry, before, after); err != nil {
+		logger.ErrorContext(logging.WithOrderID(ctx, after.ID), "error recording audit entry", slog.Any("error", err))
+	}
+}
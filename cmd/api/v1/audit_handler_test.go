@@ -0,0 +1,178 @@
+package v1
+
+import (
+	"bufio"
+	"bytes"
+	"challenge-yuno/internal/business/domain/audit"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/mocks"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type AuditHandlerTestSuite struct {
+	suite.Suite
+	auditHandler *AuditHandler
+	auditUseCase *mocks.MockAuditUsecase
+}
+
+func (s *AuditHandlerTestSuite) SetupTest() {
+	s.auditUseCase = new(mocks.MockAuditUsecase)
+	s.auditHandler = &AuditHandler{s.auditUseCase}
+}
+
+func TestAuditHandler(t *testing.T) {
+	suite.Run(t, new(AuditHandlerTestSuite))
+}
+
+func (s *AuditHandlerTestSuite) TestListEntries() {
+	from := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name                 string
+		query                string
+		expectedFilter       audit.Filter
+		mockExpectedResponse []audit.Entry
+		mockExpectedError    error
+		expectedResponse     []audit.Entry
+		expectedError        error
+	}{
+		{
+			name:          "error_wrong_from",
+			query:         "from=yesterday",
+			expectedError: errs.Invalid("invalid_filter", "from must be an RFC3339 timestamp"),
+		},
+		{
+			name:          "error_wrong_limit",
+			query:         "limit=-1",
+			expectedError: errs.Invalid("invalid_filter", "limit must be a positive number"),
+		},
+		{
+			name:              "error_listing_entries",
+			query:             "actor=error",
+			expectedFilter:    audit.Filter{Actor: "error"},
+			mockExpectedError: fmt.Errorf("mock error"),
+			expectedError:     fmt.Errorf("mock error"),
+		},
+		{
+			name:                 "success",
+			query:                "actor=cook&order_id=123456&from=2025-02-01T00:00:00Z&limit=10",
+			expectedFilter:       audit.Filter{Actor: "cook", OrderID: "123456", From: &from, Limit: 10},
+			mockExpectedResponse: []audit.Entry{{ID: "1", Actor: "cook", OrderID: "123456"}},
+			expectedResponse:     []audit.Entry{{ID: "1", Actor: "cook", OrderID: "123456"}},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			req, err := http.NewRequest(http.MethodGet, "/audit?"+tt.query, nil)
+			s.Require().NoError(err)
+			recorder := httptest.NewRecorder()
+			e := echo.New()
+			ctx := e.NewContext(req, recorder)
+			ctx.Set(branchContextKey, testBranch)
+
+			s.auditUseCase.On("ListEntries", mock.Anything, testBranch, tt.expectedFilter).
+				Return(tt.mockExpectedResponse, tt.mockExpectedError)
+
+			err = s.auditHandler.ListEntries(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			var response []audit.Entry
+			err = json.Unmarshal(recorder.Body.Bytes(), &response)
+			s.Require().NoError(err)
+			s.Equal(tt.expectedResponse, response)
+		})
+	}
+}
+
+func (s *AuditHandlerTestSuite) TestExportEntries() {
+	entries := []audit.Entry{
+		{ID: "1", Actor: "cook", OrderID: "123456", Changes: []audit.Change{{Field: "status", After: "PENDING"}}},
+		{ID: "2", Actor: "cook", OrderID: "123456", Changes: []audit.Change{{Field: "status", Before: "PENDING", After: "CANCELED"}}},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/audit/export?order_id=123456", nil)
+	s.Require().NoError(err)
+	recorder := httptest.NewRecorder()
+	e := echo.New()
+	ctx := e.NewContext(req, recorder)
+	ctx.Set(branchContextKey, testBranch)
+
+	s.auditUseCase.On("ExportEntries", mock.Anything, testBranch, audit.Filter{OrderID: "123456"}, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(3).(func(audit.Entry) error)
+			for _, entry := range entries {
+				s.Require().NoError(fn(entry))
+			}
+		}).
+		Return(nil)
+
+	err = s.auditHandler.ExportEntries(ctx)
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal(mimeNDJSON, recorder.Header().Get(echo.HeaderContentType))
+
+	var lines []audit.Entry
+	scanner := bufio.NewScanner(bytes.NewReader(recorder.Body.Bytes()))
+	for scanner.Scan() {
+		var entry audit.Entry
+		s.Require().NoError(json.Unmarshal(scanner.Bytes(), &entry))
+		lines = append(lines, entry)
+	}
+	s.Require().Len(lines, 2)
+	s.Equal("2", lines[1].ID)
+	s.Equal("CANCELED", lines[1].Changes[0].After)
+}
+
+func (s *AuditHandlerTestSuite) TestRecordClientIP() {
+	_, proxy, _ := net.ParseCIDR("192.0.2.0/24")
+
+	var tests = []struct {
+		name           string
+		trustedProxies []*net.IPNet
+		forwardedFor   string
+		expectedIP     string
+	}{
+		{name: "direct_spoofed", forwardedFor: "203.0.113.1", expectedIP: "192.0.2.1"},
+		{name: "behind_proxy_spoofed", trustedProxies: []*net.IPNet{proxy}, forwardedFor: "203.0.113.1, 198.51.100.7", expectedIP: "198.51.100.7"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.auditUseCase.On("Record", mock.Anything, testBranch, mock.MatchedBy(func(entry audit.Entry) bool {
+				return entry.ClientIP == tt.expectedIP
+			}), mock.Anything, mock.Anything).Return(nil)
+
+			e := echo.New()
+			e.IPExtractor = ClientIP(tt.trustedProxies)
+			e.PUT("/order/:ID/cancel", func(c echo.Context) error {
+				changed := &order.Order{ID: "123456", Status: order.Canceled}
+				recordAudit(c.Request().Context(), c, s.auditUseCase, discardLogger, testBranch, &order.Order{ID: "123456"}, changed)
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/order/123456/cancel", nil)
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			s.auditUseCase.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"slices"
+)
+
+const (
+	HeaderBranchID = "X-Branch-ID"
+
+	branchContextKey       = "branch"
+	branchAccessContextKey = "branch_access"
+)
+
+// BranchAccess limits the branches each API key may act on, as listed in keyBranches. It only
+// records the branches of the request's X-API-Key; BranchScope checks them once it resolves the
+// branch, so routes without a branch stay open. With no keys configured any branch is allowed.
+func BranchAccess(keyBranches map[string][]string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if len(keyBranches) > 0 {
+				// a missing or unknown key gets no branches
+				c.Set(branchAccessContextKey, keyBranches[c.Request().Header.Get(HeaderAPIKey)])
+			}
+			return next(c)
+		}
+	}
+}
+
+// BranchScope resolves the branch of the request from the X-Branch-ID header. When the header is
+// missing the request is only accepted if the deployment serves a single branch. Behind
+// BranchAccess, a branch the API key doesn't have is refused with 403.
+func BranchScope(branchRepository interfaces.BranchRepository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			branchID := c.Request().Header.Get(HeaderBranchID)
+			if len(branchID) == 0 {
+				branches := branchRepository.ListBranches()
+				if len(branches) != 1 {
+					return errs.Invalid("branch_required", HeaderBranchID+" header is required")
+				}
+				branchID = branches[0].ID
+			}
+
+			branch, err := branchRepository.GetBranch(branchID)
+			if err != nil {
+				return err
+			}
+
+			if !branchAllowed(c, branch.ID) {
+				return echo.NewHTTPError(http.StatusForbidden, "API key can't access this branch")
+			}
+
+			c.Set(branchContextKey, *branch)
+			req := c.Request()
+			c.SetRequest(req.WithContext(logging.WithTenant(req.Context(), branch.ID)))
+			return next(c)
+		}
+	}
+}
+
+// branchAllowed tells whether the API key of the request may act on the branch, as recorded by
+// BranchAccess.
+func branchAllowed(c echo.Context, branchID string) bool {
+	allowed, limited := c.Get(branchAccessContextKey).([]string)
+	return !limited || slices.Contains(allowed, branchID)
+}
+
+func branchFromContext(c echo.Context) (tenant.Branch, error) {
+	branch, ok := c.Get(branchContextKey).(tenant.Branch)
+	if !ok {
+		return tenant.Branch{}, errors.New("request is not scoped to a branch")
+	}
+
+	return branch, nil
+}
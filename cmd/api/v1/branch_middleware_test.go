@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/platform/problem"
+	"challenge-yuno/internal/platform/repositories/kvstore"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type BranchScopeTestSuite struct {
+	suite.Suite
+}
+
+func TestBranchScope(t *testing.T) {
+	suite.Run(t, new(BranchScopeTestSuite))
+}
+
+func (s *BranchScopeTestSuite) newEcho(branches []tenant.Branch, keyBranches map[string][]string) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = problem.ErrorHandler(discardLogger)
+	e.Use(BranchAccess(keyBranches))
+	e.GET("/order/active", func(c echo.Context) error {
+		branch, err := branchFromContext(c)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, branch.ID)
+	}, BranchScope(kvstore.NewBranchRepository(branches)))
+	return e
+}
+
+func (s *BranchScopeTestSuite) TestKeyBranches() {
+	branches := []tenant.Branch{{ID: "centro"}, {ID: "norte"}}
+	keyBranches := map[string][]string{"a": {"centro"}, "b": {"centro", "norte"}}
+
+	var tests = []struct {
+		name           string
+		keyBranches    map[string][]string
+		branches       []tenant.Branch
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{name: "no_keys_configured", branches: branches, headers: map[string]string{HeaderBranchID: "norte"}, expectedStatus: http.StatusOK},
+		{name: "allowed", keyBranches: keyBranches, branches: branches, headers: map[string]string{HeaderAPIKey: "a", HeaderBranchID: "centro"}, expectedStatus: http.StatusOK},
+		{name: "allowed_one_of_many", keyBranches: keyBranches, branches: branches, headers: map[string]string{HeaderAPIKey: "b", HeaderBranchID: "norte"}, expectedStatus: http.StatusOK},
+		{name: "other_branch", keyBranches: keyBranches, branches: branches, headers: map[string]string{HeaderAPIKey: "a", HeaderBranchID: "norte"}, expectedStatus: http.StatusForbidden},
+		{name: "unknown_key", keyBranches: keyBranches, branches: branches, headers: map[string]string{HeaderAPIKey: "x", HeaderBranchID: "centro"}, expectedStatus: http.StatusForbidden},
+		{name: "missing_key", keyBranches: keyBranches, branches: branches, headers: map[string]string{HeaderBranchID: "centro"}, expectedStatus: http.StatusForbidden},
+		{name: "single_branch_without_header", keyBranches: map[string][]string{"a": {"centro"}}, branches: []tenant.Branch{{ID: "norte"}}, headers: map[string]string{HeaderAPIKey: "a"}, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			e := s.newEcho(tt.branches, tt.keyBranches)
+			req := httptest.NewRequest(http.MethodGet, "/order/active", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				s.Equal(tt.headers[HeaderBranchID], recorder.Body.String())
+			}
+		})
+	}
+}
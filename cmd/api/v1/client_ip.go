@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"net"
+)
+
+// ClientIP tells how to find the IP of the client behind c.RealIP(), which keys rate limits and
+// is recorded in the audit trail. Without trustedProxies the API faces clients directly and only
+// the address of the connection is used. Otherwise the client is the nearest address in
+// X-Forwarded-For not in trustedProxies; addresses outside them, private ones included, are never
+// trusted, as anyone can send the header.
+func ClientIP(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipRange := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"github.com/labstack/echo/v4"
+	"strconv"
+	"strings"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// setETag sends the version of the order as its entity tag, for the client to send back in
+// If-Match when it changes the order.
+func setETag(c echo.Context, order *model.Order) {
+	c.Response().Header().Set(HeaderETag, strconv.Quote(strconv.Itoa(order.Version)))
+}
+
+// ifMatch is the If-Match precondition of a change: the entity tags of the versions of the order
+// it may be applied to.
+type ifMatch struct {
+	// anyVersion is set when the header is missing or "*", so clients that don't send it keep the
+	// last-write-wins behavior.
+	anyVersion bool
+	versions   []int
+}
+
+// ifMatchFrom reads the If-Match header, a comma separated list of entity tags (RFC 9110). If-Match
+// uses the strong comparison, so weak tags (W/"3") and tags that aren't a version never match; only
+// tags that aren't quoted are rejected as malformed.
+func ifMatchFrom(c echo.Context) (ifMatch, error) {
+	value := strings.Join(c.Request().Header.Values(HeaderIfMatch), ",")
+	if len(strings.TrimSpace(value)) == 0 {
+		return ifMatch{anyVersion: true}, nil
+	}
+
+	var precondition ifMatch
+	for _, tag := range strings.Split(value, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) == 0 {
+			continue
+		}
+		if tag == "*" {
+			precondition.anyVersion = true
+			continue
+		}
+
+		weak := strings.HasPrefix(tag, "W/")
+		tag = strings.TrimPrefix(tag, "W/")
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			return ifMatch{}, errs.Invalid("invalid_if_match", `If-Match must be the ETag of the order, e.g. "3"`)
+		}
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil && !weak {
+			precondition.versions = append(precondition.versions, version)
+		}
+	}
+
+	return precondition, nil
+}
+
+// version is the version of order the change must be based on, for the repository to check again
+// as it writes. It fails with model.ErrOrderModified when order isn't at any of the versions.
+func (m ifMatch) version(order *model.Order) (*int, error) {
+	if m.anyVersion {
+		return nil, nil
+	}
+
+	for _, version := range m.versions {
+		if version == order.Version {
+			return &version, nil
+		}
+	}
+
+	return nil, model.ErrOrderModified
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/order"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *OrderHandlerTestSuite) TestETag() {
+	current := &order.Order{ID: "123456", Status: order.InPreparation, Version: 2}
+	updated := &order.Order{ID: "123456", Status: order.Finished, Version: 3}
+	read := 2
+
+	var tests = []struct {
+		name           string
+		method         string
+		path           string
+		ifMatch        string
+		version        *int
+		mockError      error
+		expectedStatus int
+		expectedETag   string
+	}{
+		{
+			name:           "get",
+			method:         http.MethodGet,
+			path:           "/order/123456",
+			expectedStatus: http.StatusOK,
+			expectedETag:   `"2"`,
+		},
+		{
+			name:           "error_invalid_if_match",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        "2",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error_stale",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "error_modified_meanwhile",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `"2"`,
+			version:        &read,
+			mockError:      order.ErrOrderModified,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "error_weak",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `W/"2"`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "error_not_a_version",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `"abc"`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "error_invalid_in_list",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `"2", 3`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "success_list",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `"1", W/"3", "2"`,
+			version:        &read,
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "success_any_version",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        "*",
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "success",
+			method:         http.MethodPut,
+			path:           "/order/123456/status",
+			ifMatch:        `"2"`,
+			version:        &read,
+			expectedStatus: http.StatusCreated,
+			expectedETag:   `"3"`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.orderUseCase.On("GetOrder", mock.Anything, testBranch, "123456").Return(current, nil)
+			update := order.StatusUpdate{OrderID: "123456", Status: order.Finished, Version: tt.version}
+			if tt.mockError != nil {
+				s.orderUseCase.On("UpdateOrder", mock.Anything, testBranch, update).Return(nil, tt.mockError)
+			} else {
+				s.orderUseCase.On("UpdateOrder", mock.Anything, testBranch, update).Return(updated, nil)
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(`{"status": "FINISHED"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if len(tt.ifMatch) > 0 {
+				req.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			s.Equal(tt.expectedETag, recorder.Header().Get(HeaderETag))
+		})
+	}
+}
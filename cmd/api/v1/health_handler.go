@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"challenge-yuno/internal/platform/health"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type HealthHandler struct {
+	Health *health.Health
+}
+
+func NewHealthHandler(e *echo.Echo, h *health.Health) {
+	handler := &HealthHandler{
+		Health: h,
+	}
+
+	e.GET("/healthz", handler.Liveness)
+	e.GET("/readyz", handler.Readiness)
+	e.GET("/version", handler.Version)
+}
+
+// Liveness only tells the process is serving requests; it never checks dependencies, so a
+// database outage doesn't get the container restarted.
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": health.StatusUp})
+}
+
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	report := h.Health.Ready(c.Request().Context())
+	if report.Status != health.StatusUp {
+		return c.JSON(http.StatusServiceUnavailable, report)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+func (h *HealthHandler) Version(c echo.Context) error {
+	return c.JSON(http.StatusOK, health.Build())
+}
@@ -0,0 +1,106 @@
+package v1
+
+import (
+	"challenge-yuno/internal/platform/health"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type HealthHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestHealthHandler(t *testing.T) {
+	suite.Run(t, new(HealthHandlerTestSuite))
+}
+
+func (s *HealthHandlerTestSuite) serve(h *health.Health, path string) (*httptest.ResponseRecorder, health.Report) {
+	e := echo.New()
+	NewHealthHandler(e, h)
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	report := health.Report{}
+	if path == "/readyz" {
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &report))
+	}
+	return recorder, report
+}
+
+func (s *HealthHandlerTestSuite) TestLiveness() {
+	h := health.New()
+	h.AddCheck("database", func(ctx context.Context) error { return fmt.Errorf("connection refused") })
+
+	recorder, _ := s.serve(h, "/healthz")
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *HealthHandlerTestSuite) TestReadiness() {
+	var tests = []struct {
+		name           string
+		checkError     error
+		drain          bool
+		expectedStatus int
+		expectedChecks map[string]health.CheckResult
+	}{
+		{
+			name:           "ready",
+			expectedStatus: http.StatusOK,
+			expectedChecks: map[string]health.CheckResult{"database": {Status: health.StatusUp}},
+		},
+		{
+			name:           "database_down",
+			checkError:     fmt.Errorf("connection refused"),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: map[string]health.CheckResult{"database": {Status: health.StatusDown, Error: "connection refused"}},
+		},
+		{
+			name:           "draining",
+			drain:          true,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: map[string]health.CheckResult{
+				"database": {Status: health.StatusUp},
+				"shutdown": {Status: health.StatusDown, Error: "draining"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			h := health.New()
+			h.AddCheck("database", func(ctx context.Context) error { return tt.checkError })
+			h.SetBacklogSource(func(ctx context.Context) (int, error) { return 3, nil })
+			if tt.drain {
+				h.Drain()
+			}
+
+			recorder, report := s.serve(h, "/readyz")
+			s.Equal(tt.expectedStatus, recorder.Code)
+			s.Equal(tt.expectedChecks, report.Checks)
+			s.Require().NotNil(report.NotificationBacklog)
+			s.Equal(3, *report.NotificationBacklog)
+		})
+	}
+}
+
+func (s *HealthHandlerTestSuite) TestVersion() {
+	e := echo.New()
+	NewHealthHandler(e, health.New())
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/version", nil))
+	s.Require().Equal(http.StatusOK, recorder.Code)
+
+	info := health.BuildInfo{}
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &info))
+	s.Equal(health.Version, info.Version)
+	s.NotEmpty(info.GoVersion)
+	s.NotEmpty(info.Commit)
+}
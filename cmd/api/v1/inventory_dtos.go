@@ -0,0 +1,34 @@
+package v1
+
+import model "challenge-yuno/internal/business/domain/inventory"
+
+type Ingredient struct {
+	Unit  string `json:"unit" validate:"required"`
+	Stock *int64 `json:"stock" validate:"required,gte=0"`
+}
+
+func (i *Ingredient) ToModel(name string) model.Ingredient {
+	return model.Ingredient{
+		Name:  name,
+		Unit:  i.Unit,
+		Stock: *i.Stock,
+	}
+}
+
+type Recipe struct {
+	Lines []RecipeLine `json:"lines" validate:"required,dive"`
+}
+
+type RecipeLine struct {
+	Ingredient string `json:"ingredient" validate:"required"`
+	Quantity   int64  `json:"quantity" validate:"required,gt=0"`
+}
+
+func (r *Recipe) ToModel(item string) model.Recipe {
+	recipe := model.Recipe{Item: item, Lines: make([]model.RecipeLine, 0, len(r.Lines))}
+	for _, line := range r.Lines {
+		recipe.Lines = append(recipe.Lines, model.RecipeLine{Ingredient: line.Ingredient, Quantity: line.Quantity})
+	}
+
+	return recipe
+}
@@ -0,0 +1,145 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/tracing"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"net/url"
+)
+
+type InventoryHandler struct {
+	InventoryUsecase interfaces.InventoryUsecase
+}
+
+func NewInventoryHandler(e *echo.Echo, inventoryUsecase interfaces.InventoryUsecase, branchRepository interfaces.BranchRepository) {
+	handler := &InventoryHandler{
+		InventoryUsecase: inventoryUsecase,
+	}
+
+	e.GET("/menu", handler.ListMenu, BranchScope(branchRepository))
+
+	g := e.Group("/inventory", BranchScope(branchRepository))
+	g.GET("/ingredients", handler.ListIngredients)
+	g.PUT("/ingredients/:name", handler.SetIngredient)
+	g.GET("/recipes", handler.ListRecipes)
+	g.PUT("/recipes/:item", handler.SetRecipe)
+}
+
+// ListMenu returns the menu with the items the branch can't prepare right now flagged as
+// unavailable.
+func (h *InventoryHandler) ListMenu(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "InventoryHandler.ListMenu")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.InventoryUsecase.ListMenu(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *InventoryHandler) ListIngredients(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "InventoryHandler.ListIngredients")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.InventoryUsecase.ListIngredients(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *InventoryHandler) SetIngredient(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "InventoryHandler.SetIngredient")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	name, err := nameParam(c, "name", "ingredient_name_required")
+	if err != nil {
+		return err
+	}
+
+	ingredient := Ingredient{}
+	if err := c.Bind(&ingredient); err != nil {
+		return errs.Invalid("invalid_body", "error binding ingredient body")
+	}
+
+	if err := order.Validate(ingredient); err != nil {
+		return err
+	}
+
+	response, err := h.InventoryUsecase.SetIngredient(ctx, branch, ingredient.ToModel(name))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *InventoryHandler) ListRecipes(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "InventoryHandler.ListRecipes")
+	defer func() { tracing.End(span, err) }()
+
+	response, err := h.InventoryUsecase.ListRecipes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *InventoryHandler) SetRecipe(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "InventoryHandler.SetRecipe")
+	defer func() { tracing.End(span, err) }()
+
+	item, err := nameParam(c, "item", "item_required")
+	if err != nil {
+		return err
+	}
+
+	recipe := Recipe{}
+	if err := c.Bind(&recipe); err != nil {
+		return errs.Invalid("invalid_body", "error binding recipe body")
+	}
+
+	if err := order.Validate(recipe); err != nil {
+		return err
+	}
+
+	response, err := h.InventoryUsecase.SetRecipe(ctx, recipe.ToModel(item))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// nameParam reads a path param holding a name, which may come escaped when it has spaces, e.g.
+// "pan%20rallado".
+func nameParam(c echo.Context, param, code string) (string, error) {
+	name, err := url.PathUnescape(c.Param(param))
+	if err != nil || len(name) == 0 {
+		return "", errs.Invalid(code, param+" param can't be empty")
+	}
+
+	return name, nil
+}
@@ -0,0 +1,116 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/inventory"
+	"challenge-yuno/internal/business/domain/menu"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/mocks"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type InventoryHandlerTestSuite struct {
+	suite.Suite
+	inventoryHandler *InventoryHandler
+	inventoryUseCase *mocks.MockInventoryUsecase
+}
+
+func (s *InventoryHandlerTestSuite) SetupTest() {
+	s.inventoryUseCase = new(mocks.MockInventoryUsecase)
+	s.inventoryHandler = &InventoryHandler{InventoryUsecase: s.inventoryUseCase}
+}
+
+func TestInventoryHandler(t *testing.T) {
+	suite.Run(t, new(InventoryHandlerTestSuite))
+}
+
+func (s *InventoryHandlerTestSuite) newContext(method, target, param, value string, payload []byte) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, bytes.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, recorder)
+	ctx.Set(branchContextKey, testBranch)
+	if len(param) > 0 {
+		ctx.SetParamNames(param)
+		ctx.SetParamValues(value)
+	}
+
+	return ctx, recorder
+}
+
+func (s *InventoryHandlerTestSuite) TestSetIngredient() {
+	var tests = []struct {
+		name          string
+		payload       []byte
+		expectedError error
+	}{
+		{
+			name:          "error_wrong_payload",
+			payload:       []byte(`{bad payload!}`),
+			expectedError: errs.Invalid("invalid_body", "error binding ingredient body"),
+		},
+		{
+			name:          "error_missing_stock",
+			payload:       []byte(`{"unit": "g"}`),
+			expectedError: errs.Invalid("validation_failed", "stock is required"),
+		},
+		{
+			name:          "error_negative_stock",
+			payload:       []byte(`{"unit": "g", "stock": -1}`),
+			expectedError: errs.Invalid("validation_failed", "stock is invalid"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, _ := s.newContext(http.MethodPut, "/inventory/ingredients/carne", "name", "carne", tt.payload)
+
+			err := s.inventoryHandler.SetIngredient(ctx)
+			s.Require().Error(err)
+			s.Equal(tt.expectedError, err)
+		})
+	}
+
+	s.Run("success_escaped_name", func() {
+		ctx, recorder := s.newContext(http.MethodPut, "/inventory/ingredients/pan%20rallado", "name", "pan%20rallado",
+			[]byte(`{"unit": "g", "stock": 0}`))
+		saved := &inventory.Ingredient{BranchID: testBranch.ID, Name: "pan rallado", Unit: "g"}
+		s.inventoryUseCase.On("SetIngredient", mock.Anything, testBranch, inventory.Ingredient{Name: "pan rallado", Unit: "g"}).
+			Return(saved, nil).Once()
+
+		s.Require().NoError(s.inventoryHandler.SetIngredient(ctx))
+		s.Equal(http.StatusOK, recorder.Code)
+
+		response := &inventory.Ingredient{}
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+		s.Equal(saved, response)
+	})
+}
+
+func (s *InventoryHandlerTestSuite) TestSetRecipe() {
+	ctx, _ := s.newContext(http.MethodPut, "/inventory/recipes/Milanesa", "item", "Milanesa",
+		[]byte(`{"lines": [{"ingredient": "carne", "quantity": 0}]}`))
+
+	err := s.inventoryHandler.SetRecipe(ctx)
+	s.Equal(errs.Invalid("validation_failed", "quantity is required"), err)
+}
+
+func (s *InventoryHandlerTestSuite) TestListMenu() {
+	items := []menu.Item{{Name: "Milanesa", Price: 2500, Available: false}, {Name: "Agua", Price: 600, Available: true}}
+
+	ctx, recorder := s.newContext(http.MethodGet, "/menu", "", "", nil)
+	s.inventoryUseCase.On("ListMenu", mock.Anything, testBranch).Return(items, nil)
+
+	s.Require().NoError(s.inventoryHandler.ListMenu(ctx))
+	s.Equal(http.StatusOK, recorder.Code)
+
+	var response []menu.Item
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal(items, response)
+}
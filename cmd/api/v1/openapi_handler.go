@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strings"
+)
+
+//go:embed openapi.json
+var openAPISpec []byte
+
+const streamedBodyExtension = "x-streamed-body"
+
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Yuno orders API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>window.ui = SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+
+// LoadSpec parses and validates the embedded OpenAPI document, so a broken spec stops the API at
+// startup instead of rejecting requests.
+func LoadSpec() (*openapi3.T, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, fmt.Errorf("error loading openapi spec: %w", err)
+	}
+
+	if err := spec.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+
+	return spec, nil
+}
+
+func NewOpenAPIHandler(e *echo.Echo) {
+	e.GET("/openapi.json", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, openAPISpec)
+	})
+	e.GET("/docs", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, swaggerUI)
+	})
+}
+
+// ValidateRequests checks parameters and bodies against the operation the spec declares for the
+// matched route. Routes the spec doesn't describe (metrics, health) pass through untouched.
+func ValidateRequests(spec *openapi3.T) echo.MiddlewareFunc {
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+	// operations marked x-streamed-body read their body as it arrives, validating it would buffer
+	// it whole
+	streamed := *options
+	streamed.ExcludeRequestBody = true
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			path := specPath(c.Path())
+			pathItem := spec.Paths.Value(path)
+			if pathItem == nil {
+				return next(c)
+			}
+
+			req := c.Request()
+			operation := pathItem.GetOperation(req.Method)
+			if operation == nil {
+				return next(c)
+			}
+
+			pathParams := make(map[string]string, len(c.ParamNames()))
+			for _, name := range c.ParamNames() {
+				pathParams[name] = c.Param(name)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route: &routers.Route{
+					Spec:      spec,
+					Path:      path,
+					PathItem:  pathItem,
+					Method:    req.Method,
+					Operation: operation,
+				},
+				Options: options,
+			}
+			if _, ok := operation.Extensions[streamedBodyExtension]; ok {
+				input.Options = &streamed
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return errs.Invalid("validation_failed", validationMessage(err))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// specPath turns an echo route like /order/:ID into its OpenAPI template /order/{ID}.
+func specPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// validationMessage keeps the field and the reason of a validation error and drops the schema
+// dump kin-openapi appends to it.
+func validationMessage(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	field := ""
+	if requestErr.Parameter != nil {
+		field = requestErr.Parameter.Name
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(requestErr.Err, &schemaErr) {
+		if pointer := schemaErr.JSONPointer(); len(pointer) > 0 {
+			field = strings.Join(pointer, ".")
+		}
+		if len(field) == 0 {
+			return schemaErr.Reason
+		}
+		return fmt.Sprintf("%s: %s", field, schemaErr.Reason)
+	}
+
+	reason := requestErr.Reason
+	if len(reason) == 0 && requestErr.Err != nil {
+		reason = requestErr.Err.Error()
+	}
+	if len(field) == 0 {
+		return reason
+	}
+	return fmt.Sprintf("%s: %s", field, reason)
+}
@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/mocks"
+	"challenge-yuno/internal/platform/problem"
+	"challenge-yuno/internal/platform/repositories/kvstore"
+	"encoding/json"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type OpenAPITestSuite struct {
+	suite.Suite
+	spec *openapi3.T
+}
+
+func (s *OpenAPITestSuite) SetupSuite() {
+	spec, err := LoadSpec()
+	s.Require().NoError(err)
+	s.spec = spec
+}
+
+func TestOpenAPI(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+// documentedPrefixes are the routes described in the OpenAPI spec.
+var documentedPrefixes = []string{"/order", "/table", "/reservation", "/menu", "/inventory", "/report"}
+
+// TestSpecMatchesRoutes fails when a route is added to a documented handler without documenting
+// it, or the spec keeps an operation the handlers no longer serve.
+func (s *OpenAPITestSuite) TestSpecMatchesRoutes() {
+	e := echo.New()
+	branchRepo := kvstore.NewBranchRepository([]tenant.Branch{testBranch})
+	NewOrderHandler(e, new(mocks.MockOrderUsecase), new(mocks.MockAuditUsecase), branchRepo, "admin-key", discardLogger)
+	NewTableHandler(e, new(mocks.MockDiningUsecase), branchRepo)
+	NewReservationHandler(e, new(mocks.MockReservationUsecase), new(mocks.MockAuditUsecase), branchRepo, discardLogger)
+	NewInventoryHandler(e, new(mocks.MockInventoryUsecase), branchRepo)
+	NewReportHandler(e, new(mocks.MockReportUsecase), branchRepo)
+
+	var registered []string
+	for _, route := range e.Routes() {
+		documented := slices.ContainsFunc(documentedPrefixes, func(prefix string) bool { return strings.HasPrefix(route.Path, prefix) })
+		if documented && route.Method != echo.RouteNotFound {
+			registered = append(registered, route.Method+" "+specPath(route.Path))
+		}
+	}
+
+	var documented []string
+	for path, item := range s.spec.Paths.Map() {
+		for method := range item.Operations() {
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+	s.Equal(documented, registered)
+}
+
+func (s *OpenAPITestSuite) TestValidateRequests() {
+	var tests = []struct {
+		name           string
+		method         string
+		path           string
+		payload        string
+		expectedStatus int
+		expectedDetail string
+	}{
+		{
+			name:           "valid_order",
+			method:         http.MethodPost,
+			path:           "/order",
+			payload:        `{"menu": ["food"], "status": "PENDING", "source": "PHONE", "type": "VIP"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown_status",
+			method:         http.MethodPost,
+			path:           "/order",
+			payload:        `{"menu": ["food"], "status": "BANANA", "source": "PHONE"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "status: value is not one of the allowed values",
+		},
+		{
+			name:           "unknown_source",
+			method:         http.MethodPost,
+			path:           "/order",
+			payload:        `{"menu": ["food"], "status": "PENDING", "source": "MARS"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "source: value is not one of the allowed values",
+		},
+		{
+			name:           "unknown_type",
+			method:         http.MethodPost,
+			path:           "/order",
+			payload:        `{"menu": ["food"], "status": "PENDING", "source": "PHONE", "type": "GOLD"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "type: value is not one of the allowed values",
+		},
+		{
+			name:           "missing_menu",
+			method:         http.MethodPost,
+			path:           "/order",
+			payload:        `{"status": "PENDING", "source": "PHONE"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: `property "menu" is missing`,
+		},
+		{
+			name:           "wrong_priority_type",
+			method:         http.MethodPut,
+			path:           "/order/123/status",
+			payload:        `{"status": "FINISHED", "priority": "high"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedDetail: "priority: value must be an integer",
+		},
+		{
+			name:           "undocumented_route",
+			method:         http.MethodPost,
+			path:           "/undocumented",
+			payload:        `{"anything": true}`,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			e := echo.New()
+			e.HTTPErrorHandler = problem.ErrorHandler(discardLogger)
+			e.Use(ValidateRequests(s.spec))
+			ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+			e.POST("/order", ok)
+			e.PUT("/order/:ID/status", ok)
+			e.POST("/undocumented", ok)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				return
+			}
+
+			details := problem.Details{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &details))
+			s.Equal("validation_failed", details.Code)
+			s.Contains(details.Detail, tt.expectedDetail)
+		})
+	}
+}
+
+func (s *OpenAPITestSuite) TestServesSpecAndDocs() {
+	e := echo.New()
+	NewOpenAPIHandler(e)
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	s.Equal(http.StatusOK, recorder.Code)
+
+	served, err := openapi3.NewLoader().LoadFromData(recorder.Body.Bytes())
+	s.Require().NoError(err)
+	s.Equal(s.spec.Info.Title, served.Info.Title)
+
+	recorder = httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Contains(recorder.Body.String(), "/openapi.json")
+}
@@ -0,0 +1,136 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/tracing"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// AddOrders creates a batch of orders in a single transaction. It responds 201 when every order
+// was created; otherwise 409 in all_or_nothing mode, where nothing was created, or 207 in
+// best_effort mode with the orders that were.
+func (h *OrderHandler) AddOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.AddOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	batch := OrderBatch{}
+	if err := c.Bind(&batch); err != nil {
+		return bindError(err)
+	}
+
+	if err := model.Validate(batch); err != nil {
+		return err
+	}
+
+	orders := make([]model.Order, len(batch.Orders))
+	for i := range batch.Orders {
+		orders[i] = batch.Orders[i].ToModel()
+	}
+
+	mode := batchMode(batch.Mode)
+	results, err := h.OrderUsecase.AddOrders(ctx, branch, orders, mode)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Applied() {
+			h.recordAudit(ctx, c, branch, nil, result.Order)
+		}
+	}
+
+	response := newBatchResponse(mode, results, nil)
+	return c.JSON(batchStatus(http.StatusCreated, response), response)
+}
+
+// UpdateOrders moves a batch of orders to the same status in a single transaction, e.g. to
+// deliver every finished order at closing time. It responds like AddOrders, with 200 on success.
+func (h *OrderHandler) UpdateOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.UpdateOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	batch := StatusBatch{}
+	if err := c.Bind(&batch); err != nil {
+		return bindError(err)
+	}
+
+	if err := model.Validate(batch); err != nil {
+		return err
+	}
+
+	updates := make([]model.StatusUpdate, len(batch.IDs))
+	for i, orderID := range batch.IDs {
+		updates[i] = model.StatusUpdate{OrderID: orderID, Status: batch.Status}
+	}
+
+	mode := batchMode(batch.Mode)
+	results, err := h.OrderUsecase.UpdateOrders(ctx, branch, updates, mode)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Applied() {
+			h.recordAudit(ctx, c, branch, result.Previous, result.Order)
+		}
+	}
+
+	response := newBatchResponse(mode, results, batch.IDs)
+	return c.JSON(batchStatus(http.StatusOK, response), response)
+}
+
+func batchMode(mode model.BatchMode) model.BatchMode {
+	if len(mode) == 0 {
+		return model.AllOrNothing
+	}
+	return mode
+}
+
+func newBatchResponse(mode model.BatchMode, results []model.BatchResult, ids []string) BatchResponse {
+	response := BatchResponse{Mode: mode, Results: make([]BatchItem, len(results))}
+	for i, result := range results {
+		item := BatchItem{Index: i, Applied: result.Applied(), Order: result.Order}
+		if i < len(ids) {
+			item.ID = ids[i]
+		}
+
+		if result.Err != nil {
+			response.Failed++
+			item.Code, item.Message = "batch_item_failed", "item wasn't applied"
+			if known, ok := errs.As(result.Err); ok {
+				item.Code, item.Message = known.Code, known.Message
+			}
+		} else {
+			response.Applied++
+		}
+
+		response.Results[i] = item
+	}
+
+	return response
+}
+
+// batchStatus is success when every item was applied, 409 when an all_or_nothing batch was rolled
+// back and 207 when a best_effort batch was applied in part.
+func batchStatus(success int, response BatchResponse) int {
+	switch {
+	case response.Failed == 0:
+		return success
+	case response.Mode == model.AllOrNothing:
+		return http.StatusConflict
+	default:
+		return http.StatusMultiStatus
+	}
+}
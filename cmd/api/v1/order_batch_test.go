@@ -0,0 +1,160 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *OrderHandlerTestSuite) TestAddOrders() {
+	created := &order.Order{ID: "1", Menu: []string{"pizza"}, Status: order.Pending, Source: order.Phone, Type: order.Normal}
+	outOfStock := errs.Conflict("insufficient_stock", "not enough stock")
+	payload := `{"mode": "%s", "orders": [{"menu": ["pizza"], "source": "PHONE"}, {"menu": ["salad"], "source": "PHONE"}]}`
+
+	var tests = []struct {
+		name             string
+		payload          string
+		mode             order.BatchMode
+		mockResults      []order.BatchResult
+		expectedStatus   int
+		expectedResponse *BatchResponse
+	}{
+		{
+			name:           "error_wrong_payload",
+			payload:        `{bad payload!}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error_invalid_mode",
+			payload:        `{"mode": "sometimes", "orders": [{"menu": ["pizza"], "source": "PHONE"}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error_invalid_order",
+			payload:        `{"orders": [{"menu": ["pizza"]}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "success",
+			payload:        `{"orders": [{"menu": ["pizza"], "source": "PHONE"}, {"menu": ["salad"], "source": "PHONE"}]}`,
+			mode:           order.AllOrNothing,
+			mockResults:    []order.BatchResult{{Order: created}, {Order: created}},
+			expectedStatus: http.StatusCreated,
+			expectedResponse: &BatchResponse{Mode: order.AllOrNothing, Applied: 2, Results: []BatchItem{
+				{Index: 0, Applied: true, Order: created},
+				{Index: 1, Applied: true, Order: created},
+			}},
+		},
+		{
+			name:           "aborted",
+			payload:        fmt.Sprintf(payload, order.AllOrNothing),
+			mode:           order.AllOrNothing,
+			mockResults:    []order.BatchResult{{Err: order.ErrBatchAborted}, {Err: outOfStock}},
+			expectedStatus: http.StatusConflict,
+			expectedResponse: &BatchResponse{Mode: order.AllOrNothing, Failed: 2, Results: []BatchItem{
+				{Index: 0, Code: "batch_aborted", Message: "not applied, another item of the batch failed"},
+				{Index: 1, Code: "insufficient_stock", Message: "not enough stock"},
+			}},
+		},
+		{
+			name:           "partial",
+			payload:        fmt.Sprintf(payload, order.BestEffort),
+			mode:           order.BestEffort,
+			mockResults:    []order.BatchResult{{Order: created}, {Err: outOfStock}},
+			expectedStatus: http.StatusMultiStatus,
+			expectedResponse: &BatchResponse{Mode: order.BestEffort, Applied: 1, Failed: 1, Results: []BatchItem{
+				{Index: 0, Applied: true, Order: created},
+				{Index: 1, Code: "insufficient_stock", Message: "not enough stock"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			if tt.mockResults != nil {
+				s.orderUseCase.On("AddOrders", mock.Anything, testBranch, mock.Anything, tt.mode).Return(tt.mockResults, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/order/batch", bytes.NewBufferString(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedResponse == nil {
+				return
+			}
+
+			response := &BatchResponse{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(tt.expectedResponse, response)
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestUpdateOrders() {
+	finished := &order.Order{ID: "1", Menu: []string{"pizza"}, Status: order.Finished, Source: order.Phone, Type: order.Normal}
+	delivered := &order.Order{ID: "1", Menu: []string{"pizza"}, Status: order.Delivered, Source: order.Phone, Type: order.Normal}
+	notFound := errs.NotFound("order_not_found", "order not found")
+
+	var tests = []struct {
+		name             string
+		payload          string
+		mockResults      []order.BatchResult
+		expectedStatus   int
+		expectedResponse *BatchResponse
+	}{
+		{
+			name:           "error_missing_status",
+			payload:        `{"ids": ["1"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error_empty_id",
+			payload:        `{"ids": [""], "status": "DELIVERED"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "partial",
+			payload:        `{"mode": "best_effort", "ids": ["1", "2"], "status": "DELIVERED"}`,
+			mockResults:    []order.BatchResult{{Order: delivered, Previous: finished}, {Err: notFound}},
+			expectedStatus: http.StatusMultiStatus,
+			expectedResponse: &BatchResponse{Mode: order.BestEffort, Applied: 1, Failed: 1, Results: []BatchItem{
+				{Index: 0, ID: "1", Applied: true, Order: delivered},
+				{Index: 1, ID: "2", Code: "order_not_found", Message: "order not found"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			if tt.mockResults != nil {
+				s.orderUseCase.On("UpdateOrders", mock.Anything, testBranch, []order.StatusUpdate{
+					{OrderID: "1", Status: order.Delivered},
+					{OrderID: "2", Status: order.Delivered},
+				}, order.BestEffort).Return(tt.mockResults, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/order/status", bytes.NewBufferString(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedResponse == nil {
+				return
+			}
+
+			response := &BatchResponse{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(tt.expectedResponse, response)
+			s.auditUseCase.AssertNumberOfCalls(s.T(), "Record", 1)
+		})
+	}
+}
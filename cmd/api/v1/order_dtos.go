@@ -1,20 +1,26 @@
 package v1
 
-import model "challenge-yuno/internal/business/domain/order"
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"time"
+)
 
 type Order struct {
 	Menu   []string         `json:"menu" validate:"required"`
-	Status model.Status     `json:"status" validate:"required"`
-	Source model.Source     `json:"source" validate:"required"`
-	Type   *model.OrderType `json:"type,omitempty"`
+	Status model.Status     `json:"status,omitempty" validate:"omitempty,order_status"`
+	Source model.Source     `json:"source" validate:"required,order_source"`
+	Type   *model.OrderType `json:"type,omitempty" validate:"omitempty,order_type"`
+	// ScheduledFor turns the order into a pre-order that enters the kitchen queue shortly before it
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
 }
 
 func (o *Order) ToModel() model.Order {
 	order := model.Order{
-		Menu:   o.Menu,
-		Status: o.Status,
-		Source: o.Source,
-		Type:   model.Normal,
+		Menu:         o.Menu,
+		Status:       o.Status,
+		Source:       o.Source,
+		Type:         model.Normal,
+		ScheduledFor: o.ScheduledFor,
 	}
 
 	if o.Type != nil {
@@ -25,6 +31,167 @@ func (o *Order) ToModel() model.Order {
 }
 
 type OrderUpdate struct {
-	Status   model.Status `json:"status" validate:"required"`
+	Status   model.Status `json:"status" validate:"required,order_target_status"`
 	Priority *int         `json:"priority,omitempty"`
 }
+
+// OrderMove is the body of POST /order/:ID/move, with exactly one of Before, After and Top.
+type OrderMove struct {
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+	Top    bool   `json:"top,omitempty"`
+	Force  bool   `json:"force,omitempty"`
+}
+
+func (m *OrderMove) ToModel(orderID string) model.Move {
+	return model.Move{OrderID: orderID, Before: m.Before, After: m.After, Top: m.Top, Force: m.Force}
+}
+
+// OrderEdit is the body of PATCH /order/:ID. Fields left out keep their value.
+type OrderEdit struct {
+	AddItems    []string         `json:"add_items,omitempty" validate:"omitempty,dive,required"`
+	RemoveItems []string         `json:"remove_items,omitempty" validate:"omitempty,dive,required"`
+	Source      *model.Source    `json:"source,omitempty" validate:"omitempty,order_source"`
+	Type        *model.OrderType `json:"type,omitempty" validate:"omitempty,order_type"`
+	Notes       *string          `json:"notes,omitempty" validate:"omitempty,max=500"`
+	KitchenAck  string           `json:"kitchen_ack,omitempty" validate:"max=255"`
+}
+
+func (e *OrderEdit) ToModel(orderID string, version *int) model.Edit {
+	return model.Edit{
+		OrderID:     orderID,
+		AddItems:    e.AddItems,
+		RemoveItems: e.RemoveItems,
+		Source:      e.Source,
+		Type:        e.Type,
+		Notes:       e.Notes,
+		KitchenAck:  e.KitchenAck,
+		Version:     version,
+	}
+}
+
+// EditedOrder is the response of PATCH /order/:ID: the order as edited and what it comes to now.
+type EditedOrder struct {
+	Order *model.Order `json:"order"`
+	model.Quote
+}
+
+// HistoricalOrder is an order loaded from another system, so unlike Order it carries its own
+// status and timestamps.
+type HistoricalOrder struct {
+	Menu      []string         `json:"menu" validate:"required"`
+	Status    model.Status     `json:"status" validate:"required,order_status"`
+	Source    model.Source     `json:"source" validate:"required,order_source"`
+	Type      *model.OrderType `json:"type,omitempty" validate:"omitempty,order_type"`
+	Priority  int              `json:"priority"`
+	CreatedAt time.Time        `json:"created_at" validate:"required"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+}
+
+func (o *HistoricalOrder) ToModel() model.Order {
+	order := model.Order{
+		Menu:      o.Menu,
+		Status:    o.Status,
+		Source:    o.Source,
+		Type:      model.Normal,
+		Priority:  o.Priority,
+		CreatedAt: o.CreatedAt,
+		UpdatedAt: o.CreatedAt,
+	}
+
+	if o.Type != nil {
+		order.Type = *o.Type
+	}
+	if o.UpdatedAt != nil {
+		order.UpdatedAt = *o.UpdatedAt
+	}
+
+	return order
+}
+
+// OrderRecord is a row of a bulk import. It has the shape orders are exported with, so the export
+// of a branch can be imported into another; the ID isn't kept and imported orders get a new one.
+type OrderRecord struct {
+	Menu         []string        `json:"menu" validate:"required"`
+	Status       model.Status    `json:"status" validate:"required,order_status"`
+	Source       model.Source    `json:"order_source" validate:"required,order_source"`
+	Type         model.OrderType `json:"order_type,omitempty" validate:"omitempty,order_type"`
+	Priority     int             `json:"priority"`
+	ScheduledFor *time.Time      `json:"scheduled_for,omitempty"`
+	CreatedAt    time.Time       `json:"created_at" validate:"required"`
+	UpdatedAt    *time.Time      `json:"updated_at,omitempty"`
+	Notes        string          `json:"notes,omitempty"`
+}
+
+func (o *OrderRecord) ToModel() model.Order {
+	order := model.Order{
+		Menu:         o.Menu,
+		Status:       o.Status,
+		Source:       o.Source,
+		Type:         model.Normal,
+		Priority:     o.Priority,
+		ScheduledFor: o.ScheduledFor,
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.CreatedAt,
+		Notes:        o.Notes,
+	}
+
+	if len(o.Type) > 0 {
+		order.Type = o.Type
+	}
+	if o.UpdatedAt != nil {
+		order.UpdatedAt = *o.UpdatedAt
+	}
+
+	return order
+}
+
+// ImportResult tells how a bulk import went. In a dry run Imported counts the rows that would have
+// been imported.
+type ImportResult struct {
+	DryRun   bool       `json:"dry_run"`
+	Rows     int        `json:"rows"`
+	Imported int        `json:"imported"`
+	Failed   int        `json:"failed"`
+	Errors   []RowError `json:"errors"`
+}
+
+// RowError is why a row of a bulk import was rejected. Rows are numbered from 1, not counting
+// the CSV header.
+type RowError struct {
+	Row     int    `json:"row"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// OrderBatch is a batch of new orders. Mode defaults to all_or_nothing.
+type OrderBatch struct {
+	Mode   model.BatchMode `json:"mode,omitempty"`
+	Orders []Order         `json:"orders" validate:"required,dive"`
+}
+
+// StatusBatch moves every order in IDs to Status. Mode defaults to all_or_nothing.
+type StatusBatch struct {
+	Mode   model.BatchMode `json:"mode,omitempty"`
+	IDs    []string        `json:"ids" validate:"required,dive,required"`
+	Status model.Status    `json:"status" validate:"required,order_target_status"`
+}
+
+// BatchResponse tells how a batch went, with one result per item in the order they were sent.
+type BatchResponse struct {
+	Mode    model.BatchMode `json:"mode"`
+	Applied int             `json:"applied"`
+	Failed  int             `json:"failed"`
+	Results []BatchItem     `json:"results"`
+}
+
+// BatchItem is the result of an item of a batch: the order as it was saved, or why it wasn't.
+// ID is the order the item referred to, for updates.
+type BatchItem struct {
+	Index   int          `json:"index"`
+	ID      string       `json:"id,omitempty"`
+	Applied bool         `json:"applied"`
+	Order   *model.Order `json:"order,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Message string       `json:"message,omitempty"`
+}
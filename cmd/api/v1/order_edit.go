@@ -0,0 +1,86 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// EditOrder changes the items, source, type or notes of an order that hasn't left the kitchen,
+// and responds with the order along with its recalculated total and ETA.
+func (h *OrderHandler) EditOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.EditOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	edit := OrderEdit{}
+	if err := c.Bind(&edit); err != nil {
+		return bindError(err)
+	}
+
+	if err := model.Validate(edit); err != nil {
+		return err
+	}
+
+	orderID := c.Param("ID")
+	if len(orderID) == 0 {
+		return errs.Invalid("order_id_required", "ID param can't be empty")
+	}
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	precondition, err := ifMatchFrom(c)
+	if err != nil {
+		return err
+	}
+
+	before, err := h.OrderUsecase.GetOrder(ctx, branch, orderID)
+	if err != nil {
+		return err
+	}
+
+	version, err := precondition.version(before)
+	if err != nil {
+		return err
+	}
+
+	order, quote, err := h.OrderUsecase.EditOrder(ctx, branch, edit.ToModel(orderID, version))
+	if err != nil {
+		return err
+	}
+
+	h.recordAudit(ctx, c, branch, before, order)
+
+	setETag(c, order)
+	return c.JSON(http.StatusOK, EditedOrder{Order: order, Quote: *quote})
+}
+
+// ListRevisions responds with the edits of an order, oldest first.
+func (h *OrderHandler) ListRevisions(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.ListRevisions")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	orderID := c.Param("ID")
+	if len(orderID) == 0 {
+		return errs.Invalid("order_id_required", "ID param can't be empty")
+	}
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	response, err := h.OrderUsecase.ListRevisions(ctx, branch, orderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
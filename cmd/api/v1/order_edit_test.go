@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+func (s *OrderHandlerTestSuite) TestEditOrder() {
+	vip := order.VIP
+	version := 3
+	eta := time.Date(2024, 5, 10, 12, 20, 0, 0, time.UTC)
+	edited := &order.Order{ID: "1", Menu: []string{"pizza", "soda"}, Status: order.Pending, Source: order.Phone, Type: order.VIP, Version: 4}
+	quote := &order.Quote{Total: 1500, ETA: &eta}
+
+	var tests = []struct {
+		name           string
+		payload        string
+		ifMatch        string
+		mockEdit       *order.Edit
+		mockError      error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "error_wrong_payload",
+			payload:        `{bad payload!}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_body",
+		},
+		{
+			name:           "error_invalid_type",
+			payload:        `{"type": "SPECIAL"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "error_empty_item",
+			payload:        `{"add_items": [""]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "validation_failed",
+		},
+		{
+			name:           "success",
+			payload:        `{"add_items": ["soda"], "type": "VIP"}`,
+			ifMatch:        `"3"`,
+			mockEdit:       &order.Edit{OrderID: "1", AddItems: []string{"soda"}, Type: &vip, Version: &version},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error_kitchen_ack_required",
+			payload:        `{"add_items": ["soda"]}`,
+			mockEdit:       &order.Edit{OrderID: "1", AddItems: []string{"soda"}},
+			mockError:      order.ErrKitchenAckRequired,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "kitchen_ack_required",
+		},
+		{
+			name:           "error_modified",
+			payload:        `{"remove_items": ["pizza"], "kitchen_ack": "chef"}`,
+			ifMatch:        `"3"`,
+			mockEdit:       &order.Edit{OrderID: "1", RemoveItems: []string{"pizza"}, KitchenAck: "chef", Version: &version},
+			mockError:      order.ErrOrderModified,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "order_modified",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.orderUseCase.On("GetOrder", mock.Anything, testBranch, "1").Return(&order.Order{ID: "1", Status: order.Pending, Version: 3}, nil)
+			if tt.mockEdit != nil {
+				if tt.mockError != nil {
+					s.orderUseCase.On("EditOrder", mock.Anything, testBranch, *tt.mockEdit).Return(nil, nil, tt.mockError)
+				} else {
+					s.orderUseCase.On("EditOrder", mock.Anything, testBranch, *tt.mockEdit).Return(edited, quote, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/order/1", bytes.NewBufferString(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if len(tt.ifMatch) > 0 {
+				req.Header.Set(HeaderIfMatch, tt.ifMatch)
+			}
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if len(tt.expectedCode) > 0 {
+				s.Contains(recorder.Body.String(), `"code":"`+tt.expectedCode+`"`)
+				return
+			}
+
+			response := EditedOrder{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+			s.Equal(EditedOrder{Order: edited, Quote: *quote}, response)
+			s.Equal(`"4"`, recorder.Header().Get(HeaderETag))
+			s.auditUseCase.AssertNumberOfCalls(s.T(), "Record", 1)
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestListRevisions() {
+	notes := "no onions"
+	revisions := []order.Revision{
+		{ID: "r1", OrderID: "1", Status: order.Pending, Notes: &notes, EditedAt: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)},
+		{ID: "r2", OrderID: "1", Status: order.InPreparation, Added: []string{"soda"}, KitchenAck: "chef", EditedAt: time.Date(2024, 5, 10, 12, 5, 0, 0, time.UTC)},
+	}
+
+	s.Run("success", func() {
+		s.SetupTest()
+		s.orderUseCase.On("ListRevisions", mock.Anything, testBranch, "1").Return(revisions, nil)
+
+		recorder := s.serve(httptest.NewRequest(http.MethodGet, "/order/1/edits", nil))
+
+		s.Equal(http.StatusOK, recorder.Code)
+		var response []order.Revision
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		s.Equal(revisions, response)
+	})
+
+	s.Run("not_found", func() {
+		s.SetupTest()
+		s.orderUseCase.On("ListRevisions", mock.Anything, testBranch, "2").Return(nil, errs.NotFound("order_not_found", "order not found"))
+
+		recorder := s.serve(httptest.NewRequest(http.MethodGet, "/order/2/edits", nil))
+
+		s.Equal(http.StatusNotFound, recorder.Code)
+	})
+}
@@ -2,58 +2,148 @@ package v1
 
 import (
 	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
 	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel"
+	"log/slog"
 	"net/http"
-	"sync"
+	"strings"
+	"time"
 )
 
+var tracer = otel.Tracer("challenge-yuno/cmd/api/v1")
+
 type OrderHandler struct {
 	OrderUsecase interfaces.OrderUsecase
+	AuditUsecase interfaces.AuditUsecase
+	Logger       *slog.Logger
 }
 
-func NewOrderHandler(e *echo.Echo, orderUsecase interfaces.OrderUsecase) {
+func NewOrderHandler(e *echo.Echo, orderUsecase interfaces.OrderUsecase, auditUsecase interfaces.AuditUsecase,
+	branchRepository interfaces.BranchRepository, adminKey string, logger *slog.Logger) {
 	handler := &OrderHandler{
 		OrderUsecase: orderUsecase,
+		AuditUsecase: auditUsecase,
+		Logger:       logging.Named(logger, "handler"),
 	}
 
-	e.POST("/order", handler.AddOrder)
-	e.GET("/order/active", handler.ListActiveOrders)
-	e.GET("/order/:ID", handler.GetOrder)
-	e.PUT("/order/:ID/cancel", handler.CancelOrder)
-	e.PUT("/order/:ID/status", handler.UpdateOrder)
+	g := e.Group("/order", BranchScope(branchRepository))
+	g.POST("", handler.AddOrder)
+	g.POST("/batch", handler.AddOrders)
+	g.PATCH("/status", handler.UpdateOrders)
+	g.GET("/active", handler.ListActiveOrders)
+	g.GET("/active/stream", handler.StreamQueue)
+	g.GET("/scheduled", handler.ListScheduledOrders)
+	g.GET("/:ID", handler.GetOrder)
+	g.PATCH("/:ID", handler.EditOrder)
+	g.GET("/:ID/edits", handler.ListRevisions)
+	g.PUT("/:ID/cancel", handler.CancelOrder)
+	g.PUT("/:ID/status", handler.UpdateOrder)
+	g.POST("/:ID/move", handler.MoveOrder)
 
-	e.POST("/order/test", handler.TestOrders)
-	e.GET("/order/all", handler.GetAllOrders)
+	g.POST("/historical", handler.ImportOrder, RequireAdminKey(adminKey))
+	g.GET("/export", handler.ExportOrders)
+	g.POST("/import", handler.ImportOrders, RequireAdminKey(adminKey))
+	g.POST("/test", handler.TestOrders, RequireAdminKey(adminKey))
+	g.GET("/all", handler.GetAllOrders)
 }
 
-func (h *OrderHandler) AddOrder(c echo.Context) error {
-	order := Order{}
+func (h *OrderHandler) AddOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.AddOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	_, bindSpan := tracer.Start(ctx, "OrderHandler.bind")
+	order, err := bindOrder(c)
+	tracing.End(bindSpan, err)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.OrderUsecase.AddOrder(ctx, branch, order.ToModel())
+	if err != nil {
+		return err
+	}
+
+	h.recordAudit(ctx, c, branch, nil, response)
+
+	setETag(c, response)
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h *OrderHandler) ImportOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.ImportOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	order := HistoricalOrder{}
 	if err := c.Bind(&order); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "error binding order body")
+		return bindError(err)
 	}
 
 	if err := model.Validate(order); err != nil {
 		return err
 	}
 
-	response, err := h.OrderUsecase.AddOrder(order.ToModel())
+	response, err := h.OrderUsecase.ImportOrder(ctx, branch, order.ToModel())
 	if err != nil {
 		return err
 	}
 
+	h.recordAudit(ctx, c, branch, nil, response)
+
 	return c.JSON(http.StatusCreated, response)
 }
 
-func (h *OrderHandler) GetOrder(c echo.Context) error {
+func (h *OrderHandler) GetOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.GetOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
 	orderID := c.Param("ID")
 	if len(orderID) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "ID param can't be empty")
+		return errs.Invalid("order_id_required", "ID param can't be empty")
+	}
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	response, err := h.OrderUsecase.GetOrder(ctx, branch, orderID)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, response)
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *OrderHandler) ListActiveOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.ListActiveOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
 	}
 
-	response, err := h.OrderUsecase.GetOrder(orderID)
+	response, err := h.OrderUsecase.ListActiveOrders(ctx, branch)
 	if err != nil {
 		return err
 	}
@@ -61,9 +151,16 @@ func (h *OrderHandler) GetOrder(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-func (h *OrderHandler) ListActiveOrders(c echo.Context) error {
+func (h *OrderHandler) ListScheduledOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.ListScheduledOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
 
-	response, err := h.OrderUsecase.ListActiveOrders()
+	response, err := h.OrderUsecase.ListScheduledOrders(ctx, branch)
 	if err != nil {
 		return err
 	}
@@ -71,24 +168,59 @@ func (h *OrderHandler) ListActiveOrders(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
-func (h *OrderHandler) CancelOrder(c echo.Context) error {
+func (h *OrderHandler) CancelOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.CancelOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
 	orderID := c.Param("ID")
 	if len(orderID) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "ID param can't be empty")
+		return errs.Invalid("order_id_required", "ID param can't be empty")
+	}
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	precondition, err := ifMatchFrom(c)
+	if err != nil {
+		return err
 	}
 
-	response, err := h.OrderUsecase.UpdateOrder(orderID, model.Canceled, nil)
+	before, err := h.OrderUsecase.GetOrder(ctx, branch, orderID)
 	if err != nil {
 		return err
 	}
 
+	version, err := precondition.version(before)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.OrderUsecase.UpdateOrder(ctx, branch, model.StatusUpdate{OrderID: orderID, Status: model.Canceled, Version: version})
+	if err != nil {
+		return err
+	}
+
+	h.recordAudit(ctx, c, branch, before, response)
+
+	setETag(c, response)
 	return c.JSON(http.StatusOK, response)
 }
 
-func (h *OrderHandler) UpdateOrder(c echo.Context) error {
+func (h *OrderHandler) UpdateOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.UpdateOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
 	order := OrderUpdate{}
 	if err := c.Bind(&order); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "error binding order body")
+		return bindError(err)
 	}
 
 	if err := model.Validate(order); err != nil {
@@ -97,49 +229,145 @@ func (h *OrderHandler) UpdateOrder(c echo.Context) error {
 
 	orderID := c.Param("ID")
 	if len(orderID) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "ID param can't be empty")
+		return errs.Invalid("order_id_required", "ID param can't be empty")
+	}
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	precondition, err := ifMatchFrom(c)
+	if err != nil {
+		return err
+	}
+
+	before, err := h.OrderUsecase.GetOrder(ctx, branch, orderID)
+	if err != nil {
+		return err
 	}
 
-	response, err := h.OrderUsecase.UpdateOrder(orderID, order.Status, order.Priority)
+	version, err := precondition.version(before)
 	if err != nil {
 		return err
 	}
 
+	response, err := h.OrderUsecase.UpdateOrder(ctx, branch, model.StatusUpdate{
+		OrderID:  orderID,
+		Status:   order.Status,
+		Priority: order.Priority,
+		Version:  version,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.recordAudit(ctx, c, branch, before, response)
+
+	setETag(c, response)
 	return c.JSON(http.StatusCreated, response)
 }
 
-func (h *OrderHandler) TestOrders(c echo.Context) error {
-	var wg sync.WaitGroup
+func (h *OrderHandler) TestOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.TestOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
 	sources := []model.Source{model.InPerson, model.Phone, model.Delivery}
 	statuses := []model.Status{model.Pending, model.InPreparation, model.Finished, model.Delivered, model.Canceled}
+	now := time.Now()
 
-	for i := 1; i <= 100; i++ {
-		wg.Add(1)
-		go func(i int) {
-			defer wg.Done()
-			order := model.Order{
-				Menu:   []string{fmt.Sprintf("Plato # %d", i)},
-				Status: statuses[i%len(statuses)],
-				Source: sources[i%len(sources)],
-				Type:   model.Normal,
-			}
-
-			_, err := h.OrderUsecase.AddOrder(order)
-			if err != nil {
-				log.Errorf("error saving order: %w", err)
-			}
-		}(i)
+	// seeded orders go through the import so they can start in any status
+	orders := make([]model.Order, 100)
+	for i := range orders {
+		createdAt := now.Add(-time.Duration(i+1) * time.Minute)
+		orders[i] = model.Order{
+			Menu:      []string{fmt.Sprintf("Plato # %d", i+1)},
+			Status:    statuses[(i+1)%len(statuses)],
+			Source:    sources[(i+1)%len(sources)],
+			Type:      model.Normal,
+			Priority:  i + 1,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
 	}
 
-	wg.Wait()
+	results, err := h.OrderUsecase.ImportOrders(ctx, branch, orders, model.BestEffort)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			h.Logger.ErrorContext(ctx, "error saving test order", slog.Any("error", result.Err))
+		}
+	}
 
 	return c.JSON(http.StatusCreated, "all orders created")
 }
 
-func (h *OrderHandler) GetAllOrders(c echo.Context) error {
-	result, err := h.OrderUsecase.GetAllOrders()
+func (h *OrderHandler) GetAllOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.GetAllOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := orderFilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := h.OrderUsecase.GetAllOrders(ctx, branch, filter)
 	if err != nil {
 		return err
 	}
 	return c.JSON(http.StatusOK, result)
 }
+
+// orderFilterFromQuery reads the status, source and type filters. Each may be repeated or hold
+// comma separated values, e.g. status=PENDING,IN_PREPARATION.
+func orderFilterFromQuery(c echo.Context) (model.Filter, error) {
+	values := func(param string) []string {
+		var result []string
+		for _, value := range c.QueryParams()[param] {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); len(item) > 0 {
+					result = append(result, item)
+				}
+			}
+		}
+		return result
+	}
+
+	return model.NewFilter(values("status"), values("source"), values("type"))
+}
+
+func bindOrder(c echo.Context) (Order, error) {
+	order := Order{}
+	if err := c.Bind(&order); err != nil {
+		return Order{}, bindError(err)
+	}
+
+	if err := model.Validate(order); err != nil {
+		return Order{}, err
+	}
+
+	return order, nil
+}
+
+// bindError keeps the reason when the body was rejected because of an unknown enum value, so
+// the client learns which values are accepted.
+func bindError(err error) error {
+	var invalidValue *model.InvalidValueError
+	if errors.As(err, &invalidValue) {
+		return errs.Invalid("validation_failed", invalidValue.Error())
+	}
+
+	return errs.Invalid("invalid_body", "error binding order body")
+}
+
+func (h *OrderHandler) recordAudit(ctx context.Context, c echo.Context, branch tenant.Branch, before, after *model.Order) {
+	recordAudit(ctx, c, h.AuditUsecase, h.Logger, branch, before, after)
+}
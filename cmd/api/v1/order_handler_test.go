@@ -3,32 +3,70 @@ package v1
 import (
 	"bytes"
 	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
 	"challenge-yuno/internal/mocks"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/problem"
+	"challenge-yuno/internal/platform/repositories/kvstore"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+)
+
+var (
+	testBranch    = tenant.Branch{ID: "test-branch"}
+	discardLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
 )
 
 type OrderHandlerTestSuite struct {
 	suite.Suite
-	orderHandler *OrderHandler
-	orderUseCase *mocks.MockOrderUsecase
+	orderHandler   *OrderHandler
+	orderUseCase   *mocks.MockOrderUsecase
+	auditUseCase   *mocks.MockAuditUsecase
+	spanExporter   *tracetest.InMemoryExporter
+	tracerProvider *sdktrace.TracerProvider
+}
+
+func (s *OrderHandlerTestSuite) SetupSuite() {
+	s.spanExporter = tracetest.NewInMemoryExporter()
+	s.tracerProvider = tracing.NewProvider(s.spanExporter)
 }
 
 func (s *OrderHandlerTestSuite) SetupTest() {
 	s.orderUseCase = new(mocks.MockOrderUsecase)
-	s.orderHandler = &OrderHandler{s.orderUseCase}
+	s.auditUseCase = new(mocks.MockAuditUsecase)
+	s.auditUseCase.On("Record", mock.Anything, testBranch, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.orderHandler = &OrderHandler{OrderUsecase: s.orderUseCase, AuditUsecase: s.auditUseCase, Logger: discardLogger}
 }
 
 func TestOrderHandler(t *testing.T) {
 	suite.Run(t, new(OrderHandlerTestSuite))
 }
 
+// serve sends req through the routes of NewOrderHandler, with an admin key of "secret".
+func (s *OrderHandlerTestSuite) serve(req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.HTTPErrorHandler = problem.ErrorHandler(discardLogger)
+	NewOrderHandler(e, s.orderUseCase, s.auditUseCase, kvstore.NewBranchRepository([]tenant.Branch{testBranch}), "secret", discardLogger)
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, req)
+	return recorder
+}
+
 func (s *OrderHandlerTestSuite) TestAddOrder() {
 	var tests = []struct {
 		name                 string
@@ -44,7 +82,7 @@ func (s *OrderHandlerTestSuite) TestAddOrder() {
 			mockExpectedResponse: &order.Order{ID: "123456"},
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, "error binding order body"),
+			expectedError:        errs.Invalid("invalid_body", "error binding order body"),
 		},
 		{
 			name:                 "error_validating_payload",
@@ -52,7 +90,23 @@ func (s *OrderHandlerTestSuite) TestAddOrder() {
 			mockExpectedResponse: &order.Order{ID: "123456"},
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("error validating model: %s", "Key: 'Order.Status' Error:Field validation for 'Status' failed on the 'required' tag\nKey: 'Order.Source' Error:Field validation for 'Source' failed on the 'required' tag")),
+			expectedError:        errs.Invalid("validation_failed", "source is required"),
+		},
+		{
+			name:                 "error_unknown_status",
+			payload:              []byte(`{"menu": ["food"], "status": "BANANA", "source": "MARS"}`),
+			mockExpectedResponse: &order.Order{ID: "123456"},
+			mockExpectedError:    nil,
+			expectedResponse:     nil,
+			expectedError:        errs.Invalid("validation_failed", `invalid status "BANANA", expected one of SCHEDULED, PENDING, IN_PREPARATION, FINISHED, DELIVERED, CANCELED`),
+		},
+		{
+			name:                 "error_unknown_type",
+			payload:              []byte(`{"menu": ["food"], "status": "PENDING", "source": "PHONE", "type": "GOLD"}`),
+			mockExpectedResponse: &order.Order{ID: "123456"},
+			mockExpectedError:    nil,
+			expectedResponse:     nil,
+			expectedError:        errs.Invalid("validation_failed", `invalid order type "GOLD", expected one of NORMAL, VIP`),
 		},
 		{
 			name:                 "error_adding_order",
@@ -81,7 +135,9 @@ func (s *OrderHandlerTestSuite) TestAddOrder() {
 			e := echo.New()
 			ctx := e.NewContext(req, recorder)
 
-			s.orderUseCase.On("AddOrder", *tt.mockExpectedResponse).
+			ctx.Set(branchContextKey, testBranch)
+
+			s.orderUseCase.On("AddOrder", mock.Anything, testBranch, *tt.mockExpectedResponse).
 				Return(tt.mockExpectedResponse, tt.mockExpectedError)
 
 			err = s.orderHandler.AddOrder(ctx)
@@ -120,7 +176,7 @@ func (s *OrderHandlerTestSuite) TestGetOrder() {
 			mockExpectedResponse: &order.Order{ID: "123456"},
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, "ID param can't be empty"),
+			expectedError:        errs.Invalid("order_id_required", "ID param can't be empty"),
 		},
 		{
 			name:                 "error_getting_order",
@@ -147,8 +203,9 @@ func (s *OrderHandlerTestSuite) TestGetOrder() {
 			ctx := e.NewContext(req, recorder)
 			ctx.SetParamNames("ID")
 			ctx.SetParamValues(tt.orderID)
+			ctx.Set(branchContextKey, testBranch)
 
-			s.orderUseCase.On("GetOrder", tt.orderID).
+			s.orderUseCase.On("GetOrder", mock.Anything, testBranch, tt.orderID).
 				Return(tt.mockExpectedResponse, tt.mockExpectedError)
 
 			err = s.orderHandler.GetOrder(ctx)
@@ -202,8 +259,9 @@ func (s *OrderHandlerTestSuite) TestListActiveOrders() {
 			recorder := httptest.NewRecorder()
 			e := echo.New()
 			ctx := e.NewContext(req, recorder)
+			ctx.Set(branchContextKey, testBranch)
 
-			s.orderUseCase.On("ListActiveOrders").
+			s.orderUseCase.On("ListActiveOrders", mock.Anything, testBranch).
 				Return(tt.mockExpectedResponse, tt.mockExpectedError).Once()
 
 			err = s.orderHandler.ListActiveOrders(ctx)
@@ -243,7 +301,7 @@ func (s *OrderHandlerTestSuite) TestCancelOrder() {
 			mockExpectedResponse: &order.Order{ID: "123456"},
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, "ID param can't be empty"),
+			expectedError:        errs.Invalid("order_id_required", "ID param can't be empty"),
 		},
 		{
 			name:                 "error_getting_order",
@@ -270,8 +328,11 @@ func (s *OrderHandlerTestSuite) TestCancelOrder() {
 			ctx := e.NewContext(req, recorder)
 			ctx.SetParamNames("ID")
 			ctx.SetParamValues(tt.orderID)
+			ctx.Set(branchContextKey, testBranch)
 
-			s.orderUseCase.On("UpdateOrder", tt.orderID, order.Canceled, mock.Anything).
+			s.orderUseCase.On("GetOrder", mock.Anything, testBranch, tt.orderID).
+				Return(tt.mockExpectedResponse, tt.mockExpectedError)
+			s.orderUseCase.On("UpdateOrder", mock.Anything, testBranch, order.StatusUpdate{OrderID: tt.orderID, Status: order.Canceled}).
 				Return(tt.mockExpectedResponse, tt.mockExpectedError)
 
 			err = s.orderHandler.CancelOrder(ctx)
@@ -309,7 +370,7 @@ func (s *OrderHandlerTestSuite) TestUpdateOrder() {
 			mockExpectedResponse: &order.Order{ID: "123456"},
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, "error binding order body"),
+			expectedError:        errs.Invalid("invalid_body", "error binding order body"),
 		},
 		{
 			name:                 "error_validating_payload",
@@ -318,7 +379,17 @@ func (s *OrderHandlerTestSuite) TestUpdateOrder() {
 			mockExpectedResponse: nil,
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("error validating model: %s", "Key: 'OrderUpdate.Status' Error:Field validation for 'Status' failed on the 'required' tag")),
+			expectedError:        errs.Invalid("validation_failed", "status is required"),
+		},
+		{
+			name:                 "error_scheduled_status",
+			orderID:              "123457",
+			payload:              []byte(`{"status": "SCHEDULED"}`),
+			mockExpectedResponse: nil,
+			mockExpectedError:    nil,
+			expectedResponse:     nil,
+			expectedError: errs.Invalid("validation_failed",
+				`status must be one of PENDING, IN_PREPARATION, FINISHED, DELIVERED, CANCELED, got "SCHEDULED"`),
 		},
 		{
 			name:                 "error_empty_param",
@@ -327,7 +398,7 @@ func (s *OrderHandlerTestSuite) TestUpdateOrder() {
 			mockExpectedResponse: &order.Order{ID: "123456"},
 			mockExpectedError:    nil,
 			expectedResponse:     nil,
-			expectedError:        echo.NewHTTPError(http.StatusBadRequest, "ID param can't be empty"),
+			expectedError:        errs.Invalid("order_id_required", "ID param can't be empty"),
 		},
 		{
 			name:                 "success",
@@ -350,8 +421,11 @@ func (s *OrderHandlerTestSuite) TestUpdateOrder() {
 			ctx := e.NewContext(req, recorder)
 			ctx.SetParamNames("ID")
 			ctx.SetParamValues(tt.orderID)
+			ctx.Set(branchContextKey, testBranch)
 
-			s.orderUseCase.On("UpdateOrder", tt.orderID, order.Delivered, mock.Anything).
+			s.orderUseCase.On("GetOrder", mock.Anything, testBranch, tt.orderID).
+				Return(&order.Order{ID: tt.orderID, Status: order.Pending}, nil)
+			s.orderUseCase.On("UpdateOrder", mock.Anything, testBranch, order.StatusUpdate{OrderID: tt.orderID, Status: order.Delivered}).
 				Return(tt.mockExpectedResponse, tt.mockExpectedError)
 
 			err = s.orderHandler.UpdateOrder(ctx)
@@ -363,6 +437,8 @@ func (s *OrderHandlerTestSuite) TestUpdateOrder() {
 			}
 
 			s.Require().NoError(err)
+			s.auditUseCase.AssertCalled(s.T(), "Record", mock.Anything, testBranch, mock.Anything,
+				&order.Order{ID: tt.orderID, Status: order.Pending}, tt.mockExpectedResponse)
 			response := &order.Order{}
 			err = json.Unmarshal(recorder.Body.Bytes(), response)
 			s.Require().NoError(err)
@@ -370,3 +446,267 @@ func (s *OrderHandlerTestSuite) TestUpdateOrder() {
 		})
 	}
 }
+
+func (s *OrderHandlerTestSuite) TestListScheduledOrders() {
+	scheduledFor := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	req, err := http.NewRequest(http.MethodGet, "/order/scheduled", nil)
+	s.Require().NoError(err)
+
+	var tests = []struct {
+		name                 string
+		mockExpectedResponse []order.Order
+		mockExpectedError    error
+		expectedResponse     []order.Order
+		expectedError        error
+	}{
+		{
+			name:                 "error_no_scheduled_orders",
+			mockExpectedResponse: nil,
+			mockExpectedError:    errs.NotFound("no_scheduled_orders", "there is no scheduled orders"),
+			expectedResponse:     nil,
+			expectedError:        errs.NotFound("no_scheduled_orders", "there is no scheduled orders"),
+		},
+		{
+			name:                 "success",
+			mockExpectedResponse: []order.Order{{ID: "123456", Status: order.Scheduled, ScheduledFor: &scheduledFor}},
+			mockExpectedError:    nil,
+			expectedResponse:     []order.Order{{ID: "123456", Status: order.Scheduled, ScheduledFor: &scheduledFor}},
+			expectedError:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			recorder := httptest.NewRecorder()
+			e := echo.New()
+			ctx := e.NewContext(req, recorder)
+			ctx.Set(branchContextKey, testBranch)
+
+			s.orderUseCase.On("ListScheduledOrders", mock.Anything, testBranch).
+				Return(tt.mockExpectedResponse, tt.mockExpectedError).Once()
+
+			err = s.orderHandler.ListScheduledOrders(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(http.StatusOK, recorder.Code)
+			var response []order.Order
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+			s.Equal(tt.expectedResponse, response)
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestTestOrders() {
+	s.Run("error_missing_admin_key", func() {
+		s.SetupTest()
+
+		recorder := s.serve(httptest.NewRequest(http.MethodPost, "/order/test", nil))
+
+		s.Equal(http.StatusUnauthorized, recorder.Code)
+		s.orderUseCase.AssertNotCalled(s.T(), "ImportOrders", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("success", func() {
+		s.SetupTest()
+		s.orderUseCase.On("ImportOrders", mock.Anything, testBranch, mock.MatchedBy(func(orders []order.Order) bool {
+			return len(orders) == 100
+		}), order.BestEffort).Return([]order.BatchResult{}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/order/test", nil)
+		req.Header.Set(HeaderAdminKey, "secret")
+		recorder := s.serve(req)
+
+		s.Equal(http.StatusCreated, recorder.Code)
+		s.orderUseCase.AssertExpectations(s.T())
+	})
+}
+
+func (s *OrderHandlerTestSuite) TestImportOrder() {
+	createdAt := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	imported := &order.Order{ID: "123456", Menu: []string{"food"}, Status: order.Delivered, Source: order.Phone,
+		Type: order.Normal, CreatedAt: createdAt, UpdatedAt: createdAt}
+	payload := `{"menu": ["food"], "status": "DELIVERED", "source": "PHONE", "created_at": "2024-05-10T20:00:00Z"}`
+
+	var tests = []struct {
+		name           string
+		adminKey       string
+		header         string
+		payload        string
+		expectedStatus int
+	}{
+		{
+			name:           "error_disabled",
+			adminKey:       "",
+			header:         "secret",
+			payload:        payload,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "error_wrong_key",
+			adminKey:       "secret",
+			header:         "guess",
+			payload:        payload,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "error_missing_created_at",
+			adminKey:       "secret",
+			header:         "secret",
+			payload:        `{"menu": ["food"], "status": "DELIVERED", "source": "PHONE"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "success",
+			adminKey:       "secret",
+			header:         "secret",
+			payload:        payload,
+			expectedStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.orderUseCase.On("ImportOrder", mock.Anything, testBranch, order.Order{Menu: []string{"food"}, Status: order.Delivered,
+				Source: order.Phone, Type: order.Normal, CreatedAt: createdAt, UpdatedAt: createdAt}).Return(imported, nil)
+
+			e := echo.New()
+			e.HTTPErrorHandler = problem.ErrorHandler(discardLogger)
+			NewOrderHandler(e, s.orderUseCase, s.auditUseCase, kvstore.NewBranchRepository([]tenant.Branch{testBranch}), tt.adminKey, discardLogger)
+
+			req := httptest.NewRequest(http.MethodPost, "/order/historical", bytes.NewBufferString(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set(HeaderAdminKey, tt.header)
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+
+			response := &order.Order{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(order.Delivered, response.Status)
+			s.True(createdAt.Equal(response.CreatedAt))
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestBranchScope() {
+	branchRepo := kvstore.NewBranchRepository([]tenant.Branch{{ID: "centro"}, {ID: "norte"}})
+
+	var tests = []struct {
+		name           string
+		header         string
+		expectedBranch string
+		expectedError  error
+	}{
+		{
+			name:          "error_missing_header",
+			header:        "",
+			expectedError: errs.Invalid("branch_required", "X-Branch-ID header is required"),
+		},
+		{
+			name:          "error_unknown_branch",
+			header:        "sur",
+			expectedError: errs.NotFound("branch_not_found", "branch not found"),
+		},
+		{
+			name:           "success",
+			header:         "norte",
+			expectedBranch: "norte",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			req, err := http.NewRequest(http.MethodGet, "/order/active", nil)
+			s.Require().NoError(err)
+			if len(tt.header) > 0 {
+				req.Header.Set(HeaderBranchID, tt.header)
+			}
+			e := echo.New()
+			ctx := e.NewContext(req, httptest.NewRecorder())
+
+			var resolved tenant.Branch
+			err = BranchScope(branchRepo)(func(c echo.Context) error {
+				resolved, err = branchFromContext(c)
+				return err
+			})(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tt.expectedBranch, resolved.ID)
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestAddOrderSpans() {
+	s.Require().NoError(s.tracerProvider.ForceFlush(context.Background()))
+	s.spanExporter.Reset()
+
+	req, err := http.NewRequest(http.MethodPost, "/order", bytes.NewReader([]byte(`{"menu": ["food"], "status": "PENDING", "source": "PHONE"}`)))
+	s.Require().NoError(err)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	e := echo.New()
+	ctx := e.NewContext(req, httptest.NewRecorder())
+	ctx.Set(branchContextKey, testBranch)
+
+	created := &order.Order{ID: "123456", Menu: []string{"food"}, Status: order.Pending, Source: order.Phone, Type: order.Normal}
+	s.orderUseCase.On("AddOrder", mock.Anything, testBranch, mock.Anything).Return(created, nil)
+
+	err = s.orderHandler.AddOrder(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(s.tracerProvider.ForceFlush(context.Background()))
+
+	spans := s.spanExporter.GetSpans()
+	s.Require().Len(spans, 2)
+	s.Equal("OrderHandler.bind", spans[0].Name)
+	s.Equal("OrderHandler.AddOrder", spans[1].Name)
+	s.Equal(spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}
+
+func (s *OrderHandlerTestSuite) TestLogCorrelation() {
+	var logs bytes.Buffer
+	logger := logging.New(&logs, logging.Config{Level: slog.LevelInfo})
+
+	auditUseCase := new(mocks.MockAuditUsecase)
+	auditUseCase.On("Record", mock.Anything, testBranch, mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("mock error"))
+	handler := &OrderHandler{OrderUsecase: s.orderUseCase, AuditUsecase: auditUseCase, Logger: logging.Named(logger, "handler")}
+
+	canceled := &order.Order{ID: "123456", Status: order.Canceled}
+	s.orderUseCase.On("GetOrder", mock.Anything, testBranch, "123456").Return(&order.Order{ID: "123456"}, nil)
+	s.orderUseCase.On("UpdateOrder", mock.Anything, testBranch, order.StatusUpdate{OrderID: "123456", Status: order.Canceled}).Return(canceled, nil)
+
+	e := echo.New()
+	e.Use(logging.Middleware(discardLogger))
+	e.PUT("/order/:ID/cancel", handler.CancelOrder, BranchScope(kvstore.NewBranchRepository([]tenant.Branch{testBranch})))
+
+	req := httptest.NewRequest(http.MethodPut, "/order/123456/cancel", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-1")
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, req)
+
+	s.Require().Equal(http.StatusOK, recorder.Code)
+	s.Equal("req-1", recorder.Header().Get(echo.HeaderXRequestID))
+
+	line := map[string]any{}
+	s.Require().NoError(json.Unmarshal(logs.Bytes(), &line))
+	s.Equal("error recording audit entry", line["msg"])
+	s.Equal("req-1", line[logging.RequestIDKey])
+	s.Equal("123456", line[logging.OrderIDKey])
+	s.Equal(testBranch.ID, line[logging.TenantKey])
+	s.Equal("handler", line[logging.PackageKey])
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+const (
+	mimeEventStream = "text/event-stream"
+
+	// queueKeepAlive is how often an idle queue stream sends a comment, so proxies don't close it.
+	queueKeepAlive = 15 * time.Second
+)
+
+// MoveOrder places a waiting order before or after another one, or at the top of the queue, and
+// responds with the whole queue in its new order.
+func (h *OrderHandler) MoveOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.MoveOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	move := OrderMove{}
+	if err := c.Bind(&move); err != nil {
+		return bindError(err)
+	}
+
+	orderID := c.Param("ID")
+	if len(orderID) == 0 {
+		return errs.Invalid("order_id_required", "ID param can't be empty")
+	}
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	before, err := h.OrderUsecase.GetOrder(ctx, branch, orderID)
+	if err != nil {
+		return err
+	}
+
+	queue, err := h.OrderUsecase.MoveOrder(ctx, branch, move.ToModel(orderID))
+	if err != nil {
+		return err
+	}
+
+	for i := range queue {
+		if queue[i].ID == orderID {
+			h.recordAudit(ctx, c, branch, before, &queue[i])
+		}
+	}
+
+	return c.JSON(http.StatusOK, queue)
+}
+
+// StreamQueue sends the queue of the branch as server-sent events: the current one first, then
+// every time it changes. Each event is a "queue" event holding the whole queue.
+func (h *OrderHandler) StreamQueue(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.StreamQueue")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	// subscribed before reading the queue, so a change in between isn't missed
+	updates := h.OrderUsecase.WatchQueue(ctx, branch)
+
+	queue, err := h.OrderUsecase.ListActiveOrders(ctx, branch)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return err
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, mimeEventStream)
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.WriteHeader(http.StatusOK)
+
+	write := func(queue []model.Order) error {
+		if queue == nil {
+			queue = []model.Order{}
+		}
+		data, err := json.Marshal(queue)
+		if err != nil {
+			return err
+		}
+		if _, err = fmt.Fprintf(res, "event: queue\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+
+	// once the stream started errors can't be sent as a response, the client just reconnects
+	if write(queue) != nil {
+		return nil
+	}
+
+	keepAlive := time.NewTicker(queueKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case queue, open := <-updates:
+			if !open {
+				return nil
+			}
+			if write(queue) != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, writeErr := fmt.Fprint(res, ": keep-alive\n\n"); writeErr != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+)
+
+func (s *OrderHandlerTestSuite) TestMoveOrder() {
+	moved := order.Order{ID: "2", Status: order.Pending, Type: order.Normal, Priority: 1, Version: 2}
+	queue := []order.Order{moved, {ID: "1", Status: order.Pending, Type: order.Normal, Priority: 2, Version: 2}}
+
+	var tests = []struct {
+		name           string
+		payload        string
+		mockMove       *order.Move
+		mockError      error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "error_wrong_payload",
+			payload:        `{bad payload!}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_body",
+		},
+		{
+			name:           "success",
+			payload:        `{"before": "1"}`,
+			mockMove:       &order.Move{OrderID: "2", Before: "1"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "error_invalid_move",
+			payload:        `{"before": "1", "top": true}`,
+			mockMove:       &order.Move{OrderID: "2", Before: "1", Top: true},
+			mockError:      errs.Invalid("invalid_move", "a move needs exactly one of before, after or top"),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "invalid_move",
+		},
+		{
+			name:           "error_vip_precedence",
+			payload:        `{"top": true}`,
+			mockMove:       &order.Move{OrderID: "2", Top: true},
+			mockError:      order.ErrVIPPrecedence,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "vip_precedence",
+		},
+		{
+			name:           "forced",
+			payload:        `{"top": true, "force": true}`,
+			mockMove:       &order.Move{OrderID: "2", Top: true, Force: true},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			s.orderUseCase.On("GetOrder", mock.Anything, testBranch, "2").Return(&order.Order{ID: "2", Status: order.Pending}, nil)
+			if tt.mockMove != nil {
+				if tt.mockError != nil {
+					s.orderUseCase.On("MoveOrder", mock.Anything, testBranch, *tt.mockMove).Return(nil, tt.mockError)
+				} else {
+					s.orderUseCase.On("MoveOrder", mock.Anything, testBranch, *tt.mockMove).Return(queue, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/order/2/move", bytes.NewBufferString(tt.payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if len(tt.expectedCode) > 0 {
+				s.Contains(recorder.Body.String(), `"code":"`+tt.expectedCode+`"`)
+				s.auditUseCase.AssertNotCalled(s.T(), "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			var response []order.Order
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+			s.Equal(queue, response)
+			s.auditUseCase.AssertNumberOfCalls(s.T(), "Record", 1)
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestStreamQueue() {
+	queue := []order.Order{{ID: "1", Status: order.Pending, Type: order.VIP, Priority: 1, Version: 1}}
+
+	s.Run("sends the current queue and then every change", func() {
+		s.SetupTest()
+		updates := make(chan []order.Order, 1)
+		updates <- queue
+		close(updates)
+		s.orderUseCase.On("WatchQueue", mock.Anything, testBranch).Return((<-chan []order.Order)(updates))
+		s.orderUseCase.On("ListActiveOrders", mock.Anything, testBranch).Return(nil, errs.NotFound("no_active_orders", "there is no active orders"))
+
+		recorder := s.serve(httptest.NewRequest(http.MethodGet, "/order/active/stream", nil))
+
+		data, err := json.Marshal(queue)
+		s.Require().NoError(err)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Equal(mimeEventStream, recorder.Header().Get(echo.HeaderContentType))
+		s.Equal("event: queue\ndata: []\n\nevent: queue\ndata: "+string(data)+"\n\n", recorder.Body.String())
+	})
+
+	s.Run("error", func() {
+		s.SetupTest()
+		s.orderUseCase.On("WatchQueue", mock.Anything, testBranch).Return((<-chan []order.Order)(make(chan []order.Order)))
+		s.orderUseCase.On("ListActiveOrders", mock.Anything, testBranch).Return(nil, errs.Unavailable("database_timeout", "database timeout", nil))
+
+		recorder := s.serve(httptest.NewRequest(http.MethodGet, "/order/active/stream", nil))
+
+		s.Equal(http.StatusServiceUnavailable, recorder.Code)
+	})
+}
@@ -0,0 +1,359 @@
+package v1
+
+import (
+	"bufio"
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/tracing"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+
+	// maxImportErrors bounds the row errors an import reports; the rest are only counted.
+	maxImportErrors = 100
+	// maxImportLine bounds an NDJSON row, so a body without newlines can't be buffered whole.
+	maxImportLine = 1 << 20
+	// menuSeparator joins the items of an order in a CSV cell. Commas already separate items in
+	// the database, so item names can't hold either.
+	menuSeparator = ";"
+)
+
+// orderColumns are the CSV columns of exports. Imports find columns by name, so they may come in
+// any order and id is ignored.
+var orderColumns = []string{"id", "status", "order_source", "order_type", "priority", "menu", "scheduled_for", "created_at", "updated_at", "notes"}
+
+// ExportOrders streams the orders matching the listing filters as CSV or NDJSON, one row per
+// order, without loading them all first.
+func (h *OrderHandler) ExportOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.ExportOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	filter, err := orderFilterFromQuery(c)
+	if err != nil {
+		return err
+	}
+
+	format := c.QueryParam("format")
+	if len(format) == 0 {
+		format = formatNDJSON
+		if strings.Contains(c.Request().Header.Get(echo.HeaderAccept), mimeCSV) {
+			format = formatCSV
+		}
+	}
+
+	res := c.Response()
+	var write func(model.Order) error
+	switch format {
+	case formatCSV:
+		res.Header().Set(echo.HeaderContentType, mimeCSV+"; charset=utf-8")
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="orders.csv"`)
+		writer := csv.NewWriter(res)
+		write = func(order model.Order) error {
+			if err := writer.Write(orderCSVRecord(order)); err != nil {
+				return err
+			}
+			writer.Flush()
+			return writer.Error()
+		}
+	case formatNDJSON:
+		res.Header().Set(echo.HeaderContentType, mimeNDJSON)
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="orders.ndjson"`)
+		encoder := json.NewEncoder(res)
+		write = func(order model.Order) error {
+			return encoder.Encode(order)
+		}
+	default:
+		return errs.Invalid("invalid_format", "format must be csv or ndjson")
+	}
+
+	// the status and the CSV header go out with the first order, so errors before it still get a
+	// proper response
+	start := func() error {
+		res.WriteHeader(http.StatusOK)
+		if format == formatCSV {
+			writer := csv.NewWriter(res)
+			writer.Write(orderColumns)
+			writer.Flush()
+			return writer.Error()
+		}
+		return nil
+	}
+
+	err = h.OrderUsecase.ExportOrders(ctx, branch, filter, func(order model.Order) error {
+		if !res.Committed {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		if err := write(order); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !res.Committed {
+		return start()
+	}
+
+	return nil
+}
+
+// ImportOrders loads orders in bulk from CSV or NDJSON, in the shape they are exported. Rows are
+// read, validated and imported one at a time, so the body is never held whole, and a bad row is
+// reported without stopping the rest. With dry_run=true rows are only validated.
+func (h *OrderHandler) ImportOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "OrderHandler.ImportOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	dryRun := false
+	if value := c.QueryParam("dry_run"); len(value) > 0 {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return errs.Invalid("invalid_filter", "dry_run must be true or false")
+		}
+	}
+
+	next, err := importReader(c)
+	if err != nil {
+		return err
+	}
+
+	result := ImportResult{DryRun: dryRun, Errors: []RowError{}}
+	reject := func(err error) {
+		result.Failed++
+		if len(result.Errors) >= maxImportErrors {
+			return
+		}
+		rowError := RowError{Row: result.Rows, Code: "import_failed", Message: "order wasn't imported"}
+		if known, ok := errs.As(err); ok {
+			rowError.Code, rowError.Message = known.Code, known.Message
+		}
+		result.Errors = append(result.Errors, rowError)
+	}
+
+	for {
+		record, readErr := next()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		result.Rows++
+		if readErr != nil {
+			if _, recoverable := errs.As(readErr); !recoverable {
+				// the body can't be read any further, what was imported so far stays
+				h.Logger.ErrorContext(ctx, "error reading import body", slog.Any("error", readErr))
+				reject(errs.Invalid("unreadable_body", "body can't be read past this row"))
+				break
+			}
+			reject(readErr)
+			continue
+		}
+
+		if err := model.Validate(record); err != nil {
+			reject(err)
+			continue
+		}
+
+		order := record.ToModel()
+		if dryRun {
+			if err := h.OrderUsecase.CheckImport(order); err != nil {
+				reject(err)
+				continue
+			}
+			result.Imported++
+			continue
+		}
+
+		imported, err := h.OrderUsecase.ImportOrder(ctx, branch, order)
+		if err != nil {
+			reject(err)
+			continue
+		}
+		result.Imported++
+		h.recordAudit(ctx, c, branch, nil, imported)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// importReader picks the decoder of the body from the format param or the content type. The
+// returned func gives one row per call and io.EOF after the last. Typed errs errors only affect
+// their row, any other means the body can't be read on.
+func importReader(c echo.Context) (func() (OrderRecord, error), error) {
+	format := c.QueryParam("format")
+	if len(format) == 0 {
+		contentType := c.Request().Header.Get(echo.HeaderContentType)
+		switch {
+		case strings.HasPrefix(contentType, mimeCSV):
+			format = formatCSV
+		case strings.HasPrefix(contentType, mimeNDJSON):
+			format = formatNDJSON
+		}
+	}
+
+	body := c.Request().Body
+	switch format {
+	case formatCSV:
+		return csvImportReader(body)
+	case formatNDJSON:
+		return ndjsonImportReader(body), nil
+	default:
+		return nil, errs.Invalid("invalid_format", "format must be csv or ndjson, set it or send the body as text/csv or application/x-ndjson")
+	}
+}
+
+func ndjsonImportReader(body io.Reader) func() (OrderRecord, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	return func() (OrderRecord, error) {
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(strings.TrimSpace(string(line))) == 0 {
+				continue
+			}
+
+			record := OrderRecord{}
+			if err := json.Unmarshal(line, &record); err != nil {
+				return OrderRecord{}, bindError(err)
+			}
+			return record, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return OrderRecord{}, err
+		}
+		return OrderRecord{}, io.EOF
+	}
+}
+
+// csvImportReader reads the header first, so a file missing a required column is rejected
+// before any row is imported.
+func csvImportReader(body io.Reader) (func() (OrderRecord, error), error) {
+	reader := csv.NewReader(body)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errs.Invalid("invalid_header", "CSV body must start with a header row")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"status", "order_source", "menu", "created_at"} {
+		if _, found := columns[required]; !found {
+			return nil, errs.Invalid("invalid_header", "CSV header is missing the "+required+" column")
+		}
+	}
+
+	return func() (OrderRecord, error) {
+		row, err := reader.Read()
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return OrderRecord{}, errs.Invalid("invalid_row", parseErr.Err.Error())
+			}
+			return OrderRecord{}, err
+		}
+
+		return orderRecordFromCSV(columns, row)
+	}, nil
+}
+
+func orderRecordFromCSV(columns map[string]int, row []string) (OrderRecord, error) {
+	cell := func(name string) string {
+		if i, found := columns[name]; found && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	timestamp := func(name string) (*time.Time, error) {
+		value := cell(name)
+		if len(value) == 0 {
+			return nil, nil
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errs.Invalid("invalid_row", name+" must be an RFC3339 timestamp")
+		}
+		return &t, nil
+	}
+
+	record := OrderRecord{
+		Status: model.Status(cell("status")),
+		Source: model.Source(cell("order_source")),
+		Type:   model.OrderType(cell("order_type")),
+	}
+	if menu := cell("menu"); len(menu) > 0 {
+		record.Menu = strings.Split(menu, menuSeparator)
+	}
+	// notes are free text, so unlike the other cells they are kept as written
+	if i, found := columns["notes"]; found && i < len(row) {
+		record.Notes = row[i]
+	}
+	if priority := cell("priority"); len(priority) > 0 {
+		var err error
+		if record.Priority, err = strconv.Atoi(priority); err != nil {
+			return OrderRecord{}, errs.Invalid("invalid_row", "priority must be a number")
+		}
+	}
+
+	createdAt, err := timestamp("created_at")
+	if err != nil {
+		return OrderRecord{}, err
+	}
+	if createdAt != nil {
+		record.CreatedAt = *createdAt
+	}
+	if record.UpdatedAt, err = timestamp("updated_at"); err != nil {
+		return OrderRecord{}, err
+	}
+	if record.ScheduledFor, err = timestamp("scheduled_for"); err != nil {
+		return OrderRecord{}, err
+	}
+
+	return record, nil
+}
+
+func orderCSVRecord(order model.Order) []string {
+	scheduledFor := ""
+	if order.ScheduledFor != nil {
+		scheduledFor = order.ScheduledFor.Format(time.RFC3339Nano)
+	}
+
+	return []string{
+		order.ID,
+		string(order.Status),
+		string(order.Source),
+		string(order.Type),
+		strconv.Itoa(order.Priority),
+		strings.Join(order.Menu, menuSeparator),
+		scheduledFor,
+		order.CreatedAt.Format(time.RFC3339Nano),
+		order.UpdatedAt.Format(time.RFC3339Nano),
+		order.Notes,
+	}
+}
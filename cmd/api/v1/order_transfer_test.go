@@ -0,0 +1,245 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+func (s *OrderHandlerTestSuite) TestExportOrders() {
+	createdAt := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	exported := []order.Order{
+		{ID: "1", Menu: []string{"pizza", "soda"}, Status: order.Delivered, Source: order.Phone, Type: order.VIP,
+			Priority: 2, CreatedAt: createdAt, UpdatedAt: createdAt, Notes: "no onions, please"},
+		{ID: "2", Menu: []string{"salad"}, Status: order.Delivered, Source: order.Phone, Type: order.Normal,
+			CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+	filter := order.Filter{Statuses: []order.Status{order.Delivered}, Sources: []order.Source{order.Phone}}
+
+	var tests = []struct {
+		name           string
+		query          string
+		accept         string
+		orders         []order.Order
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		{
+			name:           "error_invalid_filter",
+			query:          "status=LOST",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error_invalid_format",
+			query:          "status=DELIVERED&source=PHONE&format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "success_csv",
+			query:          "status=DELIVERED&source=PHONE&format=csv",
+			orders:         exported,
+			expectedStatus: http.StatusOK,
+			expectedType:   mimeCSV,
+			expectedBody: "id,status,order_source,order_type,priority,menu,scheduled_for,created_at,updated_at,notes\n" +
+				"1,DELIVERED,PHONE,VIP,2,pizza;soda,,2024-05-10T20:00:00Z,2024-05-10T20:00:00Z,\"no onions, please\"\n" +
+				"2,DELIVERED,PHONE,NORMAL,0,salad,,2024-05-10T20:00:00Z,2024-05-10T20:00:00Z,\n",
+		},
+		{
+			name:           "error_invalid_comma_separated",
+			query:          "status=DELIVERED,PHONE&source=PHONE",
+			accept:         mimeCSV,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "success_csv_empty",
+			query:          "status=DELIVERED&source=PHONE",
+			accept:         mimeCSV,
+			expectedStatus: http.StatusOK,
+			expectedType:   mimeCSV,
+			expectedBody:   "id,status,order_source,order_type,priority,menu,scheduled_for,created_at,updated_at,notes\n",
+		},
+		{
+			name:           "success_ndjson",
+			query:          "status=DELIVERED&source=PHONE",
+			orders:         exported[1:],
+			expectedStatus: http.StatusOK,
+			expectedType:   mimeNDJSON,
+			expectedBody: `{"id":"2","branch_id":"","created_at":"2024-05-10T20:00:00Z","updated_at":"2024-05-10T20:00:00Z",` +
+				`"menu":["salad"],"status":"DELIVERED","order_source":"PHONE","order_type":"NORMAL","priority":0,"version":0}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			if tt.expectedType != "" {
+				s.orderUseCase.On("ExportOrders", mock.Anything, testBranch, filter, mock.Anything).
+					Run(func(args mock.Arguments) {
+						write := args.Get(3).(func(order.Order) error)
+						for _, o := range tt.orders {
+							s.Require().NoError(write(o))
+						}
+					}).Return(nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/order/export?"+tt.query, nil)
+			if len(tt.accept) > 0 {
+				req.Header.Set(echo.HeaderAccept, tt.accept)
+			}
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			s.True(strings.HasPrefix(recorder.Header().Get(echo.HeaderContentType), tt.expectedType))
+			s.Equal(tt.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestImportOrders() {
+	createdAt := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	valid := order.Order{Menu: []string{"pizza", "soda"}, Status: order.Delivered, Source: order.Phone, Type: order.Normal,
+		CreatedAt: createdAt, UpdatedAt: createdAt}
+	csvHeader := "id,status,order_source,order_type,priority,menu,scheduled_for,created_at,updated_at,notes\n"
+
+	var tests = []struct {
+		name           string
+		query          string
+		contentType    string
+		adminKey       string
+		payload        string
+		dryRun         bool
+		expectedStatus int
+		expectedResult *ImportResult
+	}{
+		{
+			name:           "error_wrong_key",
+			contentType:    mimeCSV,
+			adminKey:       "guess",
+			payload:        csvHeader,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "error_unknown_format",
+			contentType:    echo.MIMETextPlain,
+			adminKey:       "secret",
+			payload:        csvHeader,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "error_missing_column",
+			contentType:    mimeCSV,
+			adminKey:       "secret",
+			payload:        "id,status,menu\n1,DELIVERED,pizza\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "success_csv",
+			contentType: mimeCSV,
+			adminKey:    "secret",
+			payload: csvHeader +
+				"1,DELIVERED,PHONE,NORMAL,0,pizza;soda,,2024-05-10T20:00:00Z,,\n" +
+				"2,LOST,PHONE,NORMAL,0,salad,,2024-05-10T20:00:00Z,,\n" +
+				"3,DELIVERED,PHONE,NORMAL,0,salad,,yesterday,,\n",
+			expectedStatus: http.StatusOK,
+			expectedResult: &ImportResult{Rows: 3, Imported: 1, Failed: 2, Errors: []RowError{
+				{Row: 2, Code: "validation_failed", Message: "status must be one of SCHEDULED, PENDING, IN_PREPARATION, FINISHED, DELIVERED, CANCELED, got \"LOST\""},
+				{Row: 3, Code: "invalid_row", Message: "created_at must be an RFC3339 timestamp"},
+			}},
+		},
+		{
+			name:           "success_ndjson",
+			query:          "format=ndjson",
+			adminKey:       "secret",
+			payload:        `{"menu":["pizza","soda"],"status":"DELIVERED","order_source":"PHONE","created_at":"2024-05-10T20:00:00Z"}` + "\n\n{bad row}\n",
+			expectedStatus: http.StatusOK,
+			expectedResult: &ImportResult{Rows: 2, Imported: 1, Failed: 1, Errors: []RowError{
+				{Row: 2, Code: "invalid_body", Message: "error binding order body"},
+			}},
+		},
+		{
+			name:           "success_dry_run",
+			query:          "dry_run=true",
+			contentType:    mimeNDJSON,
+			adminKey:       "secret",
+			payload:        `{"menu":["pizza","soda"],"status":"DELIVERED","order_source":"PHONE","created_at":"2024-05-10T20:00:00Z"}`,
+			dryRun:         true,
+			expectedStatus: http.StatusOK,
+			expectedResult: &ImportResult{DryRun: true, Rows: 1, Imported: 1, Errors: []RowError{}},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.SetupTest()
+			if tt.expectedResult != nil {
+				if tt.dryRun {
+					s.orderUseCase.On("CheckImport", valid).Return(nil)
+				} else {
+					s.orderUseCase.On("ImportOrder", mock.Anything, testBranch, valid).Return(&valid, nil)
+				}
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/order/import?"+tt.query, bytes.NewBufferString(tt.payload))
+			if len(tt.contentType) > 0 {
+				req.Header.Set(echo.HeaderContentType, tt.contentType)
+			}
+			req.Header.Set(HeaderAdminKey, tt.adminKey)
+			recorder := s.serve(req)
+
+			s.Equal(tt.expectedStatus, recorder.Code)
+			if tt.expectedResult == nil {
+				return
+			}
+
+			result := &ImportResult{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), result))
+			s.Equal(tt.expectedResult, result)
+			if tt.dryRun {
+				s.orderUseCase.AssertNotCalled(s.T(), "ImportOrder", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func (s *OrderHandlerTestSuite) TestCSVImportReader() {
+	_, err := csvImportReader(strings.NewReader(""))
+	s.Equal(errs.Invalid("invalid_header", "CSV body must start with a header row"), err)
+
+	next, err := csvImportReader(strings.NewReader("status,order_source,menu,created_at\nDELIVERED,PHONE,pizza\n"))
+	s.Require().NoError(err)
+	_, err = next()
+	s.Equal(errs.Invalid("invalid_row", "wrong number of fields"), err)
+}
+
+func (s *OrderHandlerTestSuite) TestOrderCSVRoundTrip() {
+	createdAt := time.Date(2024, 5, 10, 20, 0, 0, 0, time.UTC)
+	scheduledFor := createdAt.Add(time.Hour)
+	exported := order.Order{ID: "1", Menu: []string{"pizza", "soda"}, Status: order.Delivered, Source: order.Phone, Type: order.VIP,
+		Priority: 2, ScheduledFor: &scheduledFor, CreatedAt: createdAt, UpdatedAt: createdAt, Notes: " no onions, \"well done\""}
+
+	columns := make(map[string]int, len(orderColumns))
+	for i, name := range orderColumns {
+		columns[name] = i
+	}
+	record, err := orderRecordFromCSV(columns, orderCSVRecord(exported))
+	s.Require().NoError(err)
+
+	imported := record.ToModel()
+	s.Equal(exported.Notes, imported.Notes)
+	s.Equal(exported.Menu, imported.Menu)
+	s.Equal(exported.Status, imported.Status)
+	s.Equal(exported.Priority, imported.Priority)
+	s.True(exported.ScheduledFor.Equal(*imported.ScheduledFor))
+	s.True(exported.CreatedAt.Equal(imported.CreatedAt))
+}
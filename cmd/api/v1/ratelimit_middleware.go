@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/ratelimit"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+)
+
+const (
+	HeaderAPIKey             = "X-API-Key"
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+
+	KeyByAPIKey = "api_key"
+	KeyByTenant = "tenant"
+	KeyByIP     = "ip"
+)
+
+// RateLimit applies the limit configured for the route, keyed as "METHOD /path", e.g.
+// "POST /order". Routes without a limit are not throttled. Clients are told apart by API key,
+// branch (X-Branch-ID) or IP depending on keyBy. Only the keys in apiKeys and the branches of
+// branchRepository the key may act on identify a client; missing or unknown values fall back to
+// the IP, otherwise a client could get a fresh bucket on every request by sending a new header
+// value.
+// If the limiter fails the request is let through, as losing the limiter shouldn't take the
+// API down.
+func RateLimit(limiter ratelimit.Limiter, limits map[string]ratelimit.Limit, keyBy string, apiKeys []string,
+	branchRepository interfaces.BranchRepository, logger *slog.Logger) echo.MiddlewareFunc {
+	// buckets may be stored in the database, so the keys themselves are never kept
+	hashedKeys := make(map[string]bool, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		hashedKeys[hashAPIKey(apiKey)] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := c.Request().Method + " " + c.Path()
+			limit, limited := limits[route]
+			if !limited {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			decision, err := limiter.Allow(ctx, route+"|"+rateLimitClient(c, keyBy, hashedKeys, branchRepository), limit)
+			if err != nil {
+				logger.ErrorContext(ctx, "error checking rate limit, letting request through", slog.Any("error", err))
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(limit.Burst))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(decision.Remaining))
+
+			if !decision.Allowed {
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(decision.RetryAfter.Seconds()))))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "rate limit exceeded")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func rateLimitClient(c echo.Context, keyBy string, hashedKeys map[string]bool, branchRepository interfaces.BranchRepository) string {
+	switch keyBy {
+	case KeyByAPIKey:
+		if apiKey := c.Request().Header.Get(HeaderAPIKey); len(apiKey) > 0 {
+			if hashed := hashAPIKey(apiKey); hashedKeys[hashed] {
+				return "key:" + hashed
+			}
+		}
+	case KeyByTenant:
+		if branchID := c.Request().Header.Get(HeaderBranchID); len(branchID) > 0 {
+			// a branch the key can't act on would spend another client's bucket
+			if _, err := branchRepository.GetBranch(branchID); err == nil && branchAllowed(c, branchID) {
+				return "branch:" + branchID
+			}
+		}
+	}
+
+	return "ip:" + c.RealIP()
+}
+
+func hashAPIKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,151 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/platform/problem"
+	"challenge-yuno/internal/platform/ratelimit"
+	"challenge-yuno/internal/platform/repositories/kvstore"
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, fmt.Errorf("mock error")
+}
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+// testProxy is the range of the address httptest requests come from, trusted as a proxy.
+var _, testProxy, _ = net.ParseCIDR("192.0.2.0/24")
+
+func (s *RateLimitTestSuite) newEcho(limiter ratelimit.Limiter, keyBy string) *echo.Echo {
+	e := echo.New()
+	e.IPExtractor = ClientIP([]*net.IPNet{testProxy})
+	e.HTTPErrorHandler = problem.ErrorHandler(discardLogger)
+	branchRepo := kvstore.NewBranchRepository([]tenant.Branch{{ID: "centro"}, {ID: "norte"}})
+	e.Use(RateLimit(limiter, map[string]ratelimit.Limit{"POST /order": {Burst: 1, Per: time.Minute}}, keyBy,
+		[]string{"a", "b"}, branchRepo, discardLogger))
+	e.POST("/order", func(c echo.Context) error { return c.NoContent(http.StatusCreated) })
+	e.GET("/order/active", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	return e
+}
+
+func (s *RateLimitTestSuite) do(e *echo.Echo, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (s *RateLimitTestSuite) TestLimitsRoute() {
+	e := s.newEcho(ratelimit.NewMemoryLimiter(), KeyByIP)
+
+	first := s.do(e, http.MethodPost, "/order", nil)
+	s.Equal(http.StatusCreated, first.Code)
+	s.Equal("1", first.Header().Get(HeaderRateLimitLimit))
+	s.Equal("0", first.Header().Get(HeaderRateLimitRemaining))
+
+	second := s.do(e, http.MethodPost, "/order", nil)
+	s.Equal(http.StatusTooManyRequests, second.Code)
+	s.Equal("60", second.Header().Get(echo.HeaderRetryAfter))
+	s.Equal(problem.MIMEProblemJSON, second.Header().Get(echo.HeaderContentType))
+
+	for i := 0; i < 3; i++ {
+		s.Equal(http.StatusOK, s.do(e, http.MethodGet, "/order/active", nil).Code)
+	}
+}
+
+func (s *RateLimitTestSuite) TestKeys() {
+	var tests = []struct {
+		name    string
+		keyBy   string
+		first   map[string]string
+		second  map[string]string
+		limited bool
+	}{
+		{name: "same_api_key", keyBy: KeyByAPIKey, first: map[string]string{HeaderAPIKey: "a"}, second: map[string]string{HeaderAPIKey: "a"}, limited: true},
+		{name: "other_api_key", keyBy: KeyByAPIKey, first: map[string]string{HeaderAPIKey: "a"}, second: map[string]string{HeaderAPIKey: "b"}},
+		{name: "same_branch", keyBy: KeyByTenant, first: map[string]string{HeaderBranchID: "centro"}, second: map[string]string{HeaderBranchID: "centro"}, limited: true},
+		{name: "other_branch", keyBy: KeyByTenant, first: map[string]string{HeaderBranchID: "centro"}, second: map[string]string{HeaderBranchID: "norte"}},
+		{name: "unknown_api_key", keyBy: KeyByAPIKey, first: map[string]string{HeaderAPIKey: "x"}, second: map[string]string{HeaderAPIKey: "y"}, limited: true},
+		{name: "unknown_branch", keyBy: KeyByTenant, first: map[string]string{HeaderBranchID: "x"}, second: map[string]string{HeaderBranchID: "y"}, limited: true},
+		{name: "other_ip", keyBy: KeyByIP, first: map[string]string{echo.HeaderXForwardedFor: "203.0.113.1"}, second: map[string]string{echo.HeaderXForwardedFor: "203.0.113.2"}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			e := s.newEcho(ratelimit.NewMemoryLimiter(), tt.keyBy)
+
+			s.Equal(http.StatusCreated, s.do(e, http.MethodPost, "/order", tt.first).Code)
+			second := s.do(e, http.MethodPost, "/order", tt.second)
+			if tt.limited {
+				s.Equal(http.StatusTooManyRequests, second.Code)
+				return
+			}
+			s.Equal(http.StatusCreated, second.Code)
+		})
+	}
+}
+
+func (s *RateLimitTestSuite) TestFailsOpen() {
+	e := s.newEcho(failingLimiter{}, KeyByIP)
+
+	for i := 0; i < 3; i++ {
+		s.Equal(http.StatusCreated, s.do(e, http.MethodPost, "/order", nil).Code)
+	}
+}
+
+func (s *RateLimitTestSuite) TestSpoofedForwardedFor() {
+	var tests = []struct {
+		name           string
+		trustedProxies []*net.IPNet
+		forwardedFor   string
+	}{
+		{name: "direct", forwardedFor: "203.0.113.%d"},
+		{name: "behind_proxy", trustedProxies: []*net.IPNet{testProxy}, forwardedFor: "203.0.113.%d, 198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			e := s.newEcho(ratelimit.NewMemoryLimiter(), KeyByIP)
+			e.IPExtractor = ClientIP(tt.trustedProxies)
+
+			first := s.do(e, http.MethodPost, "/order", map[string]string{echo.HeaderXForwardedFor: fmt.Sprintf(tt.forwardedFor, 1)})
+			s.Equal(http.StatusCreated, first.Code)
+			for i := 2; i < 5; i++ {
+				next := s.do(e, http.MethodPost, "/order", map[string]string{echo.HeaderXForwardedFor: fmt.Sprintf(tt.forwardedFor, i)})
+				s.Equal(http.StatusTooManyRequests, next.Code)
+			}
+		})
+	}
+}
+
+func (s *RateLimitTestSuite) TestBranchOutsideKeyAccess() {
+	e := s.newEcho(ratelimit.NewMemoryLimiter(), KeyByTenant)
+	e.Pre(BranchAccess(map[string][]string{"a": {"centro"}, "b": {"norte"}}))
+
+	// key a can't act on norte, so its request doesn't spend the bucket of norte
+	spoofed := map[string]string{HeaderAPIKey: "a", HeaderBranchID: "norte", echo.HeaderXForwardedFor: "203.0.113.1"}
+	s.Equal(http.StatusCreated, s.do(e, http.MethodPost, "/order", spoofed).Code)
+
+	owner := map[string]string{HeaderAPIKey: "b", HeaderBranchID: "norte", echo.HeaderXForwardedFor: "203.0.113.2"}
+	s.Equal(http.StatusCreated, s.do(e, http.MethodPost, "/order", owner).Code)
+}
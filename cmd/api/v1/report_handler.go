@@ -0,0 +1,242 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/report"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/tracing"
+	"encoding/csv"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	mimeCSV = "text/csv"
+
+	// defaultTopItems is how many items the items report returns when the query doesn't set limit.
+	defaultTopItems = 10
+)
+
+type ReportHandler struct {
+	ReportUsecase interfaces.ReportUsecase
+}
+
+func NewReportHandler(e *echo.Echo, reportUsecase interfaces.ReportUsecase, branchRepository interfaces.BranchRepository) {
+	handler := &ReportHandler{
+		ReportUsecase: reportUsecase,
+	}
+
+	g := e.Group("/report", BranchScope(branchRepository))
+	g.GET("/orders/:dimension", handler.CountOrders)
+	g.GET("/summary", handler.Summary)
+	g.GET("/items", handler.TopItems)
+	g.GET("/daily", handler.ListDailySummaries)
+}
+
+// CountOrders reports the orders placed in the range grouped by hour, source or type.
+func (h *ReportHandler) CountOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReportHandler.CountOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	dimension := model.Dimension(c.Param("dimension"))
+	if !dimension.Valid() {
+		return errs.NotFound("report_not_found", "orders can be reported by hour, source or type")
+	}
+
+	rng, asCSV, err := reportQuery(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.ReportUsecase.CountOrders(ctx, branch, rng, dimension)
+	if err != nil {
+		return err
+	}
+
+	if asCSV {
+		records := make([][]string, 0, len(response))
+		for _, count := range response {
+			records = append(records, []string{count.Key, formatInt(count.Orders), formatInt(count.Canceled)})
+		}
+		return writeCSV(c, "orders-by-"+string(dimension)+".csv", []string{string(dimension), "orders", "canceled"}, records)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Summary reports the totals of the range: orders, cancellation rate, revenue, and the wait and
+// preparation times.
+func (h *ReportHandler) Summary(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReportHandler.Summary")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	rng, asCSV, err := reportQuery(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.ReportUsecase.Summary(ctx, branch, rng)
+	if err != nil {
+		return err
+	}
+
+	if asCSV {
+		return writeCSV(c, "summary.csv", summaryHeader, [][]string{summaryRecord(*response)})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// TopItems reports the best selling menu items of the range and their revenue.
+func (h *ReportHandler) TopItems(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReportHandler.TopItems")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	rng, asCSV, err := reportQuery(c)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultTopItems
+	if value := c.QueryParam("limit"); len(value) > 0 {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return errs.Invalid("invalid_filter", "limit must be a positive number")
+		}
+	}
+
+	response, err := h.ReportUsecase.TopItems(ctx, branch, rng, limit)
+	if err != nil {
+		return err
+	}
+
+	if asCSV {
+		records := make([][]string, 0, len(response))
+		for _, item := range response {
+			records = append(records, []string{item.Item, formatInt(item.Quantity), formatInt(item.Revenue)})
+		}
+		return writeCSV(c, "items.csv", []string{"item", "quantity", "revenue"}, records)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ListDailySummaries returns the snapshots the end-of-day close took of the days starting in the
+// range, which outlive the orders once they are archived.
+func (h *ReportHandler) ListDailySummaries(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReportHandler.ListDailySummaries")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	rng, asCSV, err := reportQuery(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.ReportUsecase.ListDailySummaries(ctx, branch, rng)
+	if err != nil {
+		return err
+	}
+
+	if asCSV {
+		records := make([][]string, 0, len(response))
+		for _, summary := range response {
+			records = append(records, append([]string{summary.Day}, summaryRecord(summary.Summary)...))
+		}
+		return writeCSV(c, "daily.csv", append([]string{"day"}, summaryHeader...), records)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+var summaryHeader = []string{"from", "to", "orders", "canceled", "cancellation_rate", "revenue",
+	"wait_orders", "wait_average_seconds", "wait_p90_seconds",
+	"prep_orders", "prep_average_seconds", "prep_p90_seconds"}
+
+func summaryRecord(summary model.Summary) []string {
+	return []string{summary.From.Format(time.RFC3339), summary.To.Format(time.RFC3339),
+		formatInt(summary.Orders), formatInt(summary.Canceled), formatFloat(summary.CancellationRate), formatInt(summary.Revenue),
+		formatInt(summary.Wait.Orders), formatFloat(summary.Wait.AverageSeconds), formatFloat(summary.Wait.P90Seconds),
+		formatInt(summary.Prep.Orders), formatFloat(summary.Prep.AverageSeconds), formatFloat(summary.Prep.P90Seconds)}
+}
+
+// reportQuery reads the range and output format shared by every report. The range defaults to
+// the current UTC day, and CSV is chosen with format=csv or by accepting text/csv.
+func reportQuery(c echo.Context) (_ model.Range, asCSV bool, err error) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if value := c.QueryParam("from"); len(value) > 0 {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return model.Range{}, false, errs.Invalid("invalid_filter", "from must be an RFC3339 timestamp")
+		}
+	}
+
+	to := from.Add(24 * time.Hour)
+	if value := c.QueryParam("to"); len(value) > 0 {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			return model.Range{}, false, errs.Invalid("invalid_filter", "to must be an RFC3339 timestamp")
+		}
+	}
+
+	rng, err := model.NewRange(from, to)
+	if err != nil {
+		return model.Range{}, false, err
+	}
+
+	switch c.QueryParam("format") {
+	case "csv":
+		asCSV = true
+	case "json":
+	case "":
+		asCSV = strings.Contains(c.Request().Header.Get(echo.HeaderAccept), mimeCSV)
+	default:
+		return model.Range{}, false, errs.Invalid("invalid_format", "format must be json or csv")
+	}
+
+	return rng, asCSV, nil
+}
+
+func writeCSV(c echo.Context, filename string, header []string, records [][]string) error {
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, mimeCSV+"; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	res.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(res)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func formatInt(value int64) string {
+	return strconv.FormatInt(value, 10)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
@@ -0,0 +1,189 @@
+package v1
+
+import (
+	model "challenge-yuno/internal/business/domain/report"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/mocks"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type ReportHandlerTestSuite struct {
+	suite.Suite
+	reportHandler *ReportHandler
+	reportUseCase *mocks.MockReportUsecase
+}
+
+func (s *ReportHandlerTestSuite) SetupTest() {
+	s.reportUseCase = new(mocks.MockReportUsecase)
+	s.reportHandler = &ReportHandler{ReportUsecase: s.reportUseCase}
+}
+
+func TestReportHandler(t *testing.T) {
+	suite.Run(t, new(ReportHandlerTestSuite))
+}
+
+var reportRange = model.Range{
+	From: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	To:   time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC),
+}
+
+func (s *ReportHandlerTestSuite) newContext(target, accept string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if len(accept) > 0 {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, recorder)
+	ctx.Set(branchContextKey, testBranch)
+
+	return ctx, recorder
+}
+
+func (s *ReportHandlerTestSuite) TestCountOrders() {
+	var tests = []struct {
+		name          string
+		dimension     string
+		query         string
+		expectedError error
+	}{
+		{
+			name:          "error_unknown_dimension",
+			dimension:     "weekday",
+			query:         "",
+			expectedError: errs.NotFound("report_not_found", "orders can be reported by hour, source or type"),
+		},
+		{
+			name:          "error_wrong_from",
+			dimension:     "hour",
+			query:         "?from=yesterday",
+			expectedError: errs.Invalid("invalid_filter", "from must be an RFC3339 timestamp"),
+		},
+		{
+			name:          "error_empty_range",
+			dimension:     "hour",
+			query:         "?from=2026-10-19T00:00:00Z&to=2026-10-18T00:00:00Z",
+			expectedError: errs.Invalid("invalid_range", "to must be after from"),
+		},
+		{
+			name:          "error_wrong_format",
+			dimension:     "source",
+			query:         "?format=xml",
+			expectedError: errs.Invalid("invalid_format", "format must be json or csv"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, _ := s.newContext("/report/orders/"+tt.dimension+tt.query, "")
+			ctx.SetParamNames("dimension")
+			ctx.SetParamValues(tt.dimension)
+
+			err := s.reportHandler.CountOrders(ctx)
+			s.Require().Error(err)
+			s.Equal(tt.expectedError, err)
+		})
+	}
+
+	counts := []model.Count{{Key: "DELIVERY", Orders: 4, Canceled: 1}, {Key: "PHONE", Orders: 2}}
+	s.reportUseCase.On("CountOrders", mock.Anything, testBranch, reportRange, model.Source).Return(counts, nil)
+
+	s.Run("success_json", func() {
+		ctx, recorder := s.newContext("/report/orders/source?from=2026-10-19T00:00:00Z", "")
+		ctx.SetParamNames("dimension")
+		ctx.SetParamValues("source")
+
+		s.Require().NoError(s.reportHandler.CountOrders(ctx))
+		s.Equal(http.StatusOK, recorder.Code)
+
+		var response []model.Count
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		s.Equal(counts, response)
+	})
+
+	s.Run("success_csv_accept", func() {
+		ctx, recorder := s.newContext("/report/orders/source?from=2026-10-19T00:00:00Z&to=2026-10-20T00:00:00Z", "text/csv")
+		ctx.SetParamNames("dimension")
+		ctx.SetParamValues("source")
+
+		s.Require().NoError(s.reportHandler.CountOrders(ctx))
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Equal("text/csv; charset=utf-8", recorder.Header().Get(echo.HeaderContentType))
+		s.Equal("source,orders,canceled\nDELIVERY,4,1\nPHONE,2,0\n", recorder.Body.String())
+	})
+}
+
+func (s *ReportHandlerTestSuite) TestSummary() {
+	summary := &model.Summary{
+		Range:            reportRange,
+		Orders:           8,
+		Canceled:         2,
+		CancellationRate: 0.25,
+		Revenue:          15000,
+		Wait:             model.Durations{Orders: 6, AverageSeconds: 120, P90Seconds: 300},
+		Prep:             model.Durations{Orders: 5, AverageSeconds: 600.5, P90Seconds: 900},
+	}
+	s.reportUseCase.On("Summary", mock.Anything, testBranch, reportRange).Return(summary, nil)
+
+	ctx, recorder := s.newContext("/report/summary?from=2026-10-19T00:00:00Z&format=csv", "")
+
+	s.Require().NoError(s.reportHandler.Summary(ctx))
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal("from,to,orders,canceled,cancellation_rate,revenue,wait_orders,wait_average_seconds,wait_p90_seconds,prep_orders,prep_average_seconds,prep_p90_seconds\n"+
+		"2026-10-19T00:00:00Z,2026-10-20T00:00:00Z,8,2,0.25,15000,6,120,300,5,600.5,900\n", recorder.Body.String())
+}
+
+func (s *ReportHandlerTestSuite) TestTopItems() {
+	s.Run("error_wrong_limit", func() {
+		ctx, _ := s.newContext("/report/items?limit=0", "")
+
+		err := s.reportHandler.TopItems(ctx)
+		s.Equal(errs.Invalid("invalid_filter", "limit must be a positive number"), err)
+	})
+
+	s.Run("success_default_limit", func() {
+		items := []model.ItemSales{{Item: "Milanesa", Quantity: 5, Revenue: 12500}}
+		s.reportUseCase.On("TopItems", mock.Anything, testBranch, reportRange, defaultTopItems).Return(items, nil).Once()
+		ctx, recorder := s.newContext("/report/items?from=2026-10-19T00:00:00Z", "")
+
+		s.Require().NoError(s.reportHandler.TopItems(ctx))
+
+		var response []model.ItemSales
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		s.Equal(items, response)
+	})
+}
+
+func (s *ReportHandlerTestSuite) TestListDailySummaries() {
+	summaries := []model.DailySummary{{
+		BranchID: testBranch.ID,
+		Day:      "2026-10-19",
+		Summary:  model.Summary{Range: reportRange, Orders: 3, Revenue: 5000},
+		ClosedAt: reportRange.To.Add(time.Hour),
+	}}
+	s.reportUseCase.On("ListDailySummaries", mock.Anything, testBranch, reportRange).Return(summaries, nil)
+
+	s.Run("success_json", func() {
+		ctx, recorder := s.newContext("/report/daily?from=2026-10-19T00:00:00Z", "")
+
+		s.Require().NoError(s.reportHandler.ListDailySummaries(ctx))
+
+		var response []model.DailySummary
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		s.Equal(summaries, response)
+	})
+
+	s.Run("success_csv", func() {
+		ctx, recorder := s.newContext("/report/daily?from=2026-10-19T00:00:00Z&format=csv", "")
+
+		s.Require().NoError(s.reportHandler.ListDailySummaries(ctx))
+		s.Equal("day,from,to,orders,canceled,cancellation_rate,revenue,wait_orders,wait_average_seconds,wait_p90_seconds,prep_orders,prep_average_seconds,prep_p90_seconds\n"+
+			"2026-10-19,2026-10-19T00:00:00Z,2026-10-20T00:00:00Z,3,0,0,5000,0,0,0,0,0,0\n", recorder.Body.String())
+	})
+}
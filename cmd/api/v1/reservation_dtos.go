@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	model "challenge-yuno/internal/business/domain/reservation"
+	"time"
+)
+
+type Reservation struct {
+	Name      string    `json:"name" validate:"required"`
+	PartySize int       `json:"party_size" validate:"required,gt=0"`
+	StartsAt  time.Time `json:"starts_at" validate:"required"`
+	// EndsAt defaults to starts_at plus RESERVATION_DURATION.
+	EndsAt *time.Time `json:"ends_at,omitempty"`
+	// TableID is optional; without it the smallest free table that seats the party is assigned.
+	TableID string `json:"table_id,omitempty"`
+}
+
+func (r *Reservation) ToModel() model.Reservation {
+	reservation := model.Reservation{
+		Name:      r.Name,
+		PartySize: r.PartySize,
+		StartsAt:  r.StartsAt,
+		TableID:   r.TableID,
+	}
+
+	if r.EndsAt != nil {
+		reservation.EndsAt = *r.EndsAt
+	}
+
+	return reservation
+}
+
+type ReservationUpdate struct {
+	Status model.Status `json:"status" validate:"required"`
+}
+
+// ReservationOrder is a pre-order for a reservation. Its source, status and schedule come from
+// the reservation, so only the dishes and the type are sent.
+type ReservationOrder struct {
+	Menu []string         `json:"menu" validate:"required"`
+	Type *order.OrderType `json:"type,omitempty" validate:"omitempty,order_type"`
+}
+
+func (o *ReservationOrder) ToModel() order.Order {
+	result := order.Order{
+		Menu: o.Menu,
+		Type: order.Normal,
+	}
+
+	if o.Type != nil {
+		result.Type = *o.Type
+	}
+
+	return result
+}
@@ -0,0 +1,192 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// calendarWindow is how far the calendar looks ahead when the query doesn't set to.
+const calendarWindow = 24 * time.Hour
+
+type ReservationHandler struct {
+	ReservationUsecase interfaces.ReservationUsecase
+	AuditUsecase       interfaces.AuditUsecase
+	Logger             *slog.Logger
+}
+
+func NewReservationHandler(e *echo.Echo, reservationUsecase interfaces.ReservationUsecase, auditUsecase interfaces.AuditUsecase,
+	branchRepository interfaces.BranchRepository, logger *slog.Logger) {
+	handler := &ReservationHandler{
+		ReservationUsecase: reservationUsecase,
+		AuditUsecase:       auditUsecase,
+		Logger:             logging.Named(logger, "handler"),
+	}
+
+	g := e.Group("/reservation", BranchScope(branchRepository))
+	g.POST("", handler.AddReservation)
+	g.GET("", handler.ListReservations)
+	g.GET("/:ID", handler.GetReservation)
+	g.PUT("/:ID/status", handler.UpdateStatus)
+	g.POST("/:ID/orders", handler.AddOrder)
+}
+
+func (h *ReservationHandler) AddReservation(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReservationHandler.AddReservation")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	reservation := Reservation{}
+	if err := c.Bind(&reservation); err != nil {
+		return errs.Invalid("invalid_body", "error binding reservation body")
+	}
+
+	if err := order.Validate(reservation); err != nil {
+		return err
+	}
+
+	response, err := h.ReservationUsecase.AddReservation(ctx, branch, reservation.ToModel())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ListReservations is the booking calendar: the reservations overlapping [from, to), which
+// default to the next 24 hours.
+func (h *ReservationHandler) ListReservations(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReservationHandler.ListReservations")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	from := time.Now()
+	if value := c.QueryParam("from"); len(value) > 0 {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			return errs.Invalid("invalid_filter", "from must be an RFC3339 timestamp")
+		}
+	}
+
+	to := from.Add(calendarWindow)
+	if value := c.QueryParam("to"); len(value) > 0 {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			return errs.Invalid("invalid_filter", "to must be an RFC3339 timestamp")
+		}
+	}
+
+	response, err := h.ReservationUsecase.ListReservations(ctx, branch, from, to)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *ReservationHandler) GetReservation(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReservationHandler.GetReservation")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	reservationID, err := reservationIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.ReservationUsecase.GetReservation(ctx, branch, reservationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *ReservationHandler) UpdateStatus(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReservationHandler.UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	reservationID, err := reservationIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	body := ReservationUpdate{}
+	if err := c.Bind(&body); err != nil {
+		return errs.Invalid("invalid_body", "error binding reservation body")
+	}
+
+	if err := order.Validate(body); err != nil {
+		return err
+	}
+
+	response, err := h.ReservationUsecase.UpdateStatus(ctx, branch, reservationID, body.Status)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *ReservationHandler) AddOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "ReservationHandler.AddOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	reservationID, err := reservationIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	body := ReservationOrder{}
+	if err := c.Bind(&body); err != nil {
+		return bindError(err)
+	}
+
+	if err := order.Validate(body); err != nil {
+		return err
+	}
+
+	response, err := h.ReservationUsecase.AddOrder(ctx, branch, reservationID, body.ToModel())
+	if err != nil {
+		return err
+	}
+
+	recordAudit(ctx, c, h.AuditUsecase, h.Logger, branch, nil, response)
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func reservationIDParam(c echo.Context) (string, error) {
+	reservationID := c.Param("ID")
+	if len(reservationID) == 0 {
+		return "", errs.Invalid("reservation_id_required", "ID param can't be empty")
+	}
+
+	return reservationID, nil
+}
@@ -0,0 +1,185 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/reservation"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/mocks"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type ReservationHandlerTestSuite struct {
+	suite.Suite
+	reservationHandler *ReservationHandler
+	reservationUseCase *mocks.MockReservationUsecase
+	auditUseCase       *mocks.MockAuditUsecase
+}
+
+func (s *ReservationHandlerTestSuite) SetupTest() {
+	s.reservationUseCase = new(mocks.MockReservationUsecase)
+	s.auditUseCase = new(mocks.MockAuditUsecase)
+	s.auditUseCase.On("Record", mock.Anything, testBranch, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	s.reservationHandler = &ReservationHandler{ReservationUsecase: s.reservationUseCase, AuditUsecase: s.auditUseCase, Logger: discardLogger}
+}
+
+func TestReservationHandler(t *testing.T) {
+	suite.Run(t, new(ReservationHandlerTestSuite))
+}
+
+func (s *ReservationHandlerTestSuite) newContext(method, target, reservationID string, payload []byte) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, target, bytes.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, recorder)
+	ctx.Set(branchContextKey, testBranch)
+	if len(reservationID) > 0 {
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(reservationID)
+	}
+
+	return ctx, recorder
+}
+
+func (s *ReservationHandlerTestSuite) TestAddReservation() {
+	startsAt := time.Date(2026, 10, 20, 21, 0, 0, 0, time.UTC)
+	expected := reservation.Reservation{Name: "Perez", PartySize: 4, StartsAt: startsAt}
+
+	var tests = []struct {
+		name                 string
+		payload              []byte
+		mockExpectedResponse *reservation.Reservation
+		mockExpectedError    error
+		expectedError        error
+	}{
+		{
+			name:          "error_wrong_payload",
+			payload:       []byte(`{bad payload!}`),
+			expectedError: errs.Invalid("invalid_body", "error binding reservation body"),
+		},
+		{
+			name:          "error_validating_payload",
+			payload:       []byte(`{"name": "Perez", "starts_at": "2026-10-20T21:00:00Z"}`),
+			expectedError: errs.Invalid("validation_failed", "party_size is required"),
+		},
+		{
+			name:              "error_no_table_available",
+			payload:           []byte(`{"name": "Perez", "party_size": 4, "starts_at": "2026-10-20T21:00:00Z"}`),
+			mockExpectedError: errs.Conflict("no_table_available", "no table for 4 is free for that slot"),
+			expectedError:     errs.Conflict("no_table_available", "no table for 4 is free for that slot"),
+		},
+		{
+			name:    "success",
+			payload: []byte(`{"name": "Perez", "party_size": 4, "starts_at": "2026-10-20T21:00:00Z"}`),
+			mockExpectedResponse: &reservation.Reservation{ID: "r1", TableID: "12", Name: "Perez", PartySize: 4,
+				StartsAt: startsAt, EndsAt: startsAt.Add(90 * time.Minute), Status: reservation.Booked, OrderIDs: []string{}},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, recorder := s.newContext(http.MethodPost, "/reservation", "", tt.payload)
+			if tt.mockExpectedResponse != nil || tt.mockExpectedError != nil {
+				s.reservationUseCase.On("AddReservation", mock.Anything, testBranch, expected).
+					Return(tt.mockExpectedResponse, tt.mockExpectedError).Once()
+			}
+
+			err := s.reservationHandler.AddReservation(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(http.StatusCreated, recorder.Code)
+			response := &reservation.Reservation{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(tt.mockExpectedResponse, response)
+		})
+	}
+}
+
+func (s *ReservationHandlerTestSuite) TestListReservations() {
+	from := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	s.Run("error_invalid_from", func() {
+		ctx, _ := s.newContext(http.MethodGet, "/reservation?from=tomorrow", "", nil)
+
+		err := s.reservationHandler.ListReservations(ctx)
+		s.Equal(errs.Invalid("invalid_filter", "from must be an RFC3339 timestamp"), err)
+	})
+
+	s.Run("default_window", func() {
+		ctx, recorder := s.newContext(http.MethodGet, "/reservation?from=2026-10-20T00:00:00Z", "", nil)
+		s.reservationUseCase.On("ListReservations", mock.Anything, testBranch, from, from.Add(24*time.Hour)).
+			Return([]reservation.Reservation{{ID: "r1"}}, nil).Once()
+
+		s.Require().NoError(s.reservationHandler.ListReservations(ctx))
+		s.Equal(http.StatusOK, recorder.Code)
+	})
+}
+
+func (s *ReservationHandlerTestSuite) TestAddOrder() {
+	scheduledFor := time.Date(2026, 10, 20, 21, 0, 0, 0, time.UTC)
+	created := &order.Order{ID: "123456", Menu: []string{"Milanesa"}, Status: order.Scheduled,
+		Source: order.InPerson, Type: order.Normal, ScheduledFor: &scheduledFor}
+
+	var tests = []struct {
+		name                 string
+		payload              []byte
+		mockExpectedResponse *order.Order
+		mockExpectedError    error
+		expectedError        error
+	}{
+		{
+			name:          "error_invalid_type",
+			payload:       []byte(`{"menu": ["Milanesa"], "type": "GOLD"}`),
+			expectedError: errs.Invalid("validation_failed", `invalid order type "GOLD", expected one of NORMAL, VIP`),
+		},
+		{
+			name:              "error_not_booked",
+			payload:           []byte(`{"menu": ["Milanesa"]}`),
+			mockExpectedError: errs.Conflict("reservation_not_booked", "pre-orders can only be placed for BOOKED reservations"),
+			expectedError:     errs.Conflict("reservation_not_booked", "pre-orders can only be placed for BOOKED reservations"),
+		},
+		{
+			name:                 "success",
+			payload:              []byte(`{"menu": ["Milanesa"]}`),
+			mockExpectedResponse: created,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, recorder := s.newContext(http.MethodPost, "/reservation/r1/orders", "r1", tt.payload)
+			if tt.mockExpectedResponse != nil || tt.mockExpectedError != nil {
+				s.reservationUseCase.On("AddOrder", mock.Anything, testBranch, "r1", order.Order{Menu: []string{"Milanesa"}, Type: order.Normal}).
+					Return(tt.mockExpectedResponse, tt.mockExpectedError).Once()
+			}
+
+			err := s.reservationHandler.AddOrder(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(http.StatusCreated, recorder.Code)
+			response := &order.Order{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(tt.mockExpectedResponse, response)
+			s.auditUseCase.AssertCalled(s.T(), "Record", mock.Anything, testBranch, mock.Anything, (*order.Order)(nil), tt.mockExpectedResponse)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package v1
+
+import model "challenge-yuno/internal/business/domain/dining"
+
+type Table struct {
+	ID    string `json:"id" validate:"required"`
+	Seats int    `json:"seats" validate:"required,gt=0"`
+}
+
+func (t *Table) ToModel() model.Table {
+	return model.Table{
+		ID:    t.ID,
+		Seats: t.Seats,
+	}
+}
+
+type SessionOrder struct {
+	OrderID string `json:"order_id" validate:"required"`
+}
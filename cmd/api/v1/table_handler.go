@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/tracing"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type TableHandler struct {
+	DiningUsecase interfaces.DiningUsecase
+}
+
+func NewTableHandler(e *echo.Echo, diningUsecase interfaces.DiningUsecase, branchRepository interfaces.BranchRepository) {
+	handler := &TableHandler{
+		DiningUsecase: diningUsecase,
+	}
+
+	g := e.Group("/table", BranchScope(branchRepository))
+	g.POST("", handler.AddTable)
+	g.GET("", handler.ListTables)
+	g.POST("/:ID/session", handler.OpenSession)
+	g.PUT("/:ID/session/close", handler.CloseSession)
+	g.POST("/:ID/orders", handler.AttachOrder)
+	g.GET("/:ID/orders", handler.ListTableOrders)
+	g.GET("/:ID/bill", handler.GetBill)
+}
+
+func (h *TableHandler) AddTable(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.AddTable")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	table := Table{}
+	if err := c.Bind(&table); err != nil {
+		return errs.Invalid("invalid_body", "error binding table body")
+	}
+
+	if err := order.Validate(table); err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.AddTable(ctx, branch, table.ToModel())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h *TableHandler) ListTables(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.ListTables")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.ListTables(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *TableHandler) OpenSession(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.OpenSession")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	tableID, err := tableIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.OpenSession(ctx, branch, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+func (h *TableHandler) CloseSession(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.CloseSession")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	tableID, err := tableIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.CloseSession(ctx, branch, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *TableHandler) AttachOrder(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.AttachOrder")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	tableID, err := tableIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	body := SessionOrder{}
+	if err := c.Bind(&body); err != nil {
+		return errs.Invalid("invalid_body", "error binding session order body")
+	}
+
+	if err := order.Validate(body); err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.AttachOrder(ctx, branch, tableID, body.OrderID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *TableHandler) ListTableOrders(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.ListTableOrders")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	tableID, err := tableIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.ListTableOrders(ctx, branch, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (h *TableHandler) GetBill(c echo.Context) (err error) {
+	ctx, span := tracer.Start(c.Request().Context(), "TableHandler.GetBill")
+	defer func() { tracing.End(span, err) }()
+
+	branch, err := branchFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	tableID, err := tableIDParam(c)
+	if err != nil {
+		return err
+	}
+
+	response, err := h.DiningUsecase.GetBill(ctx, branch, tableID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func tableIDParam(c echo.Context) (string, error) {
+	tableID := c.Param("ID")
+	if len(tableID) == 0 {
+		return "", errs.Invalid("table_id_required", "ID param can't be empty")
+	}
+
+	return tableID, nil
+}
@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"bytes"
+	"challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/mocks"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type TableHandlerTestSuite struct {
+	suite.Suite
+	tableHandler  *TableHandler
+	diningUseCase *mocks.MockDiningUsecase
+}
+
+func (s *TableHandlerTestSuite) SetupTest() {
+	s.diningUseCase = new(mocks.MockDiningUsecase)
+	s.tableHandler = &TableHandler{DiningUsecase: s.diningUseCase}
+}
+
+func TestTableHandler(t *testing.T) {
+	suite.Run(t, new(TableHandlerTestSuite))
+}
+
+func (s *TableHandlerTestSuite) newContext(method, path, tableID string, payload []byte) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	recorder := httptest.NewRecorder()
+	ctx := echo.New().NewContext(req, recorder)
+	ctx.Set(branchContextKey, testBranch)
+	if len(tableID) > 0 {
+		ctx.SetParamNames("ID")
+		ctx.SetParamValues(tableID)
+	}
+
+	return ctx, recorder
+}
+
+func (s *TableHandlerTestSuite) TestAddTable() {
+	var tests = []struct {
+		name              string
+		payload           []byte
+		mockExpectedError error
+		expectedError     error
+	}{
+		{
+			name:          "error_wrong_payload",
+			payload:       []byte(`{bad payload!}`),
+			expectedError: errs.Invalid("invalid_body", "error binding table body"),
+		},
+		{
+			name:          "error_validating_payload",
+			payload:       []byte(`{"id": "12", "seats": 0}`),
+			expectedError: errs.Invalid("validation_failed", "seats is required"),
+		},
+		{
+			name:              "error_table_exists",
+			payload:           []byte(`{"id": "12", "seats": 4}`),
+			mockExpectedError: errs.Conflict("table_already_exists", "table already exists"),
+			expectedError:     errs.Conflict("table_already_exists", "table already exists"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, _ := s.newContext(http.MethodPost, "/table", "", tt.payload)
+			if tt.mockExpectedError != nil {
+				s.diningUseCase.On("AddTable", mock.Anything, testBranch, dining.Table{ID: "12", Seats: 4}).
+					Return(nil, tt.mockExpectedError).Once()
+			}
+
+			err := s.tableHandler.AddTable(ctx)
+			s.Require().Error(err)
+			s.Equal(tt.expectedError, err)
+		})
+	}
+
+	s.Run("success", func() {
+		ctx, recorder := s.newContext(http.MethodPost, "/table", "", []byte(`{"id": "12", "seats": 4}`))
+		s.diningUseCase.On("AddTable", mock.Anything, testBranch, dining.Table{ID: "12", Seats: 4}).
+			Return(&dining.Table{ID: "12", BranchID: testBranch.ID, Seats: 4}, nil).Once()
+
+		s.Require().NoError(s.tableHandler.AddTable(ctx))
+		s.Equal(http.StatusCreated, recorder.Code)
+
+		response := dining.Table{}
+		s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		s.Equal(dining.Table{ID: "12", BranchID: testBranch.ID, Seats: 4}, response)
+	})
+}
+
+func (s *TableHandlerTestSuite) TestAttachOrder() {
+	var tests = []struct {
+		name                 string
+		payload              []byte
+		mockExpectedResponse *dining.Session
+		mockExpectedError    error
+		expectedError        error
+	}{
+		{
+			name:          "error_missing_order",
+			payload:       []byte(`{}`),
+			expectedError: errs.Invalid("validation_failed", "order_id is required"),
+		},
+		{
+			name:              "error_not_in_person",
+			payload:           []byte(`{"order_id": "123456"}`),
+			mockExpectedError: errs.Invalid("order_not_in_person", "only IN_PERSON orders can be attached to a table"),
+			expectedError:     errs.Invalid("order_not_in_person", "only IN_PERSON orders can be attached to a table"),
+		},
+		{
+			name:                 "success",
+			payload:              []byte(`{"order_id": "123456"}`),
+			mockExpectedResponse: &dining.Session{ID: "session-1", TableID: "12", OrderIDs: []string{"123456"}},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, recorder := s.newContext(http.MethodPost, "/table/12/orders", "12", tt.payload)
+			if tt.mockExpectedResponse != nil || tt.mockExpectedError != nil {
+				s.diningUseCase.On("AttachOrder", mock.Anything, testBranch, "12", "123456").
+					Return(tt.mockExpectedResponse, tt.mockExpectedError).Once()
+			}
+
+			err := s.tableHandler.AttachOrder(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			response := &dining.Session{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(tt.mockExpectedResponse, response)
+		})
+	}
+}
+
+func (s *TableHandlerTestSuite) TestCloseSession() {
+	bill := &dining.Bill{SessionID: "session-1", TableID: "12",
+		Lines: []dining.BillLine{{Item: "Milanesa", Quantity: 2, UnitPrice: 2500, Subtotal: 5000}}, Total: 5000}
+
+	var tests = []struct {
+		name                 string
+		mockExpectedResponse *dining.Bill
+		mockExpectedError    error
+		expectedError        error
+	}{
+		{
+			name:              "error_open_orders",
+			mockExpectedError: errs.Conflict("session_has_open_orders", "orders 1 must be DELIVERED or CANCELED before closing the session"),
+			expectedError:     errs.Conflict("session_has_open_orders", "orders 1 must be DELIVERED or CANCELED before closing the session"),
+		},
+		{
+			name:                 "success",
+			mockExpectedResponse: bill,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ctx, recorder := s.newContext(http.MethodPut, "/table/12/session/close", "12", nil)
+			s.diningUseCase.On("CloseSession", mock.Anything, testBranch, "12").
+				Return(tt.mockExpectedResponse, tt.mockExpectedError).Once()
+
+			err := s.tableHandler.CloseSession(ctx)
+
+			if tt.expectedError != nil {
+				s.Require().Error(err)
+				s.Equal(tt.expectedError, err)
+				return
+			}
+
+			s.Require().NoError(err)
+			response := &dining.Bill{}
+			s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+			s.Equal(tt.mockExpectedResponse, response)
+		})
+	}
+}
+
+func (s *TableHandlerTestSuite) TestGetBill() {
+	bill := &dining.Bill{SessionID: "session-1", TableID: "12", Lines: []dining.BillLine{}, Unpriced: []string{"Flan"}}
+
+	ctx, recorder := s.newContext(http.MethodGet, "/table/12/bill", "12", nil)
+	s.diningUseCase.On("GetBill", mock.Anything, testBranch, "12").Return(bill, nil)
+
+	s.Require().NoError(s.tableHandler.GetBill(ctx))
+	s.Equal(http.StatusOK, recorder.Code)
+
+	response := &dining.Bill{}
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+	s.Equal(bill, response)
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"reflect"
+	"time"
+)
+
+type Entry struct {
+	ID        string       `json:"id"`
+	BranchID  string       `json:"branch_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	Actor     string       `json:"actor"`
+	Endpoint  string       `json:"endpoint"`
+	OrderID   string       `json:"order_id"`
+	ClientIP  string       `json:"client_ip"`
+	RequestID string       `json:"request_id"`
+	Before    *order.Order `json:"before,omitempty"`
+	After     *order.Order `json:"after,omitempty"`
+	Changes   []Change     `json:"changes"`
+}
+
+type Change struct {
+	Field  string `json:"field"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+type Filter struct {
+	Actor    string
+	Endpoint string
+	OrderID  string
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+}
+
+// Diff returns the fields that differ between two snapshots of the same order. A nil before
+// means the order was created, so every field of after is reported.
+func Diff(before, after *order.Order) []Change {
+	var b, a order.Order
+	if before != nil {
+		b = *before
+	}
+	if after != nil {
+		a = *after
+	}
+
+	fields := []struct {
+		name   string
+		before any
+		after  any
+	}{
+		{"status", b.Status, a.Status},
+		{"priority", b.Priority, a.Priority},
+		{"menu", b.Menu, a.Menu},
+		{"order_source", b.Source, a.Source},
+		{"order_type", b.Type, a.Type},
+	}
+
+	changes := make([]Change, 0, len(fields))
+	for _, f := range fields {
+		if reflect.DeepEqual(f.before, f.after) {
+			continue
+		}
+
+		change := Change{Field: f.name, After: f.after}
+		if before != nil {
+			change.Before = f.before
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
@@ -0,0 +1,92 @@
+package dining
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Table struct {
+	ID        string    `json:"id"`
+	BranchID  string    `json:"branch_id"`
+	Seats     int       `json:"seats"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Session is a party seated at a table, from when they sit down until the bill is settled. A
+// table has at most one open session, and every round they order is attached to it.
+type Session struct {
+	ID       string     `json:"id"`
+	BranchID string     `json:"branch_id"`
+	TableID  string     `json:"table_id"`
+	OpenedAt time.Time  `json:"opened_at"`
+	ClosedAt *time.Time `json:"closed_at,omitempty"`
+	OrderIDs []string   `json:"order_ids"`
+}
+
+type Bill struct {
+	SessionID string     `json:"session_id"`
+	TableID   string     `json:"table_id"`
+	Lines     []BillLine `json:"lines"`
+	Total     int64      `json:"total"`
+	// Unpriced lists the items missing from the menu, which are billed at zero.
+	Unpriced []string `json:"unpriced,omitempty"`
+}
+
+type BillLine struct {
+	Item      string `json:"item"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice int64  `json:"unit_price"`
+	Subtotal  int64  `json:"subtotal"`
+}
+
+// NewBill adds up the items of every order of the session, leaving out canceled orders. Lines are
+// sorted by item so the same session always renders the same bill.
+func NewBill(session Session, orders []order.Order, prices map[string]int64) Bill {
+	quantities := make(map[string]int)
+	for _, o := range orders {
+		if o.Status == order.Canceled {
+			continue
+		}
+		for _, item := range o.Menu {
+			quantities[item]++
+		}
+	}
+
+	bill := Bill{SessionID: session.ID, TableID: session.TableID, Lines: make([]BillLine, 0, len(quantities))}
+	for item, quantity := range quantities {
+		price, priced := prices[item]
+		if !priced {
+			bill.Unpriced = append(bill.Unpriced, item)
+		}
+
+		line := BillLine{Item: item, Quantity: quantity, UnitPrice: price, Subtotal: price * int64(quantity)}
+		bill.Lines = append(bill.Lines, line)
+		bill.Total += line.Subtotal
+	}
+
+	sort.Slice(bill.Lines, func(i, j int) bool { return bill.Lines[i].Item < bill.Lines[j].Item })
+	sort.Strings(bill.Unpriced)
+
+	return bill
+}
+
+// CanClose checks every order of the session was served or canceled before the table is freed.
+func CanClose(orders []order.Order) error {
+	var open []string
+	for _, o := range orders {
+		if o.Status != order.Delivered && o.Status != order.Canceled {
+			open = append(open, o.ID)
+		}
+	}
+
+	if len(open) > 0 {
+		return errs.Conflict("session_has_open_orders",
+			fmt.Sprintf("orders %s must be DELIVERED or CANCELED before closing the session", strings.Join(open, ", ")))
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package dining
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type DiningTestSuite struct {
+	suite.Suite
+}
+
+func TestDining(t *testing.T) {
+	suite.Run(t, new(DiningTestSuite))
+}
+
+func (s *DiningTestSuite) TestNewBill() {
+	session := Session{ID: "session-1", TableID: "12"}
+	orders := []order.Order{
+		{ID: "1", Menu: []string{"Milanesa", "Agua"}, Status: order.Delivered},
+		{ID: "2", Menu: []string{"Agua", "Flan"}, Status: order.Delivered},
+		{ID: "3", Menu: []string{"Milanesa"}, Status: order.Canceled},
+	}
+	prices := map[string]int64{"Milanesa": 2500, "Agua": 600}
+
+	bill := NewBill(session, orders, prices)
+
+	s.Equal(Bill{
+		SessionID: "session-1",
+		TableID:   "12",
+		Lines: []BillLine{
+			{Item: "Agua", Quantity: 2, UnitPrice: 600, Subtotal: 1200},
+			{Item: "Flan", Quantity: 1, UnitPrice: 0, Subtotal: 0},
+			{Item: "Milanesa", Quantity: 1, UnitPrice: 2500, Subtotal: 2500},
+		},
+		Total:    3700,
+		Unpriced: []string{"Flan"},
+	}, bill)
+}
+
+func (s *DiningTestSuite) TestCanClose() {
+	s.NoError(CanClose(nil))
+	s.NoError(CanClose([]order.Order{{ID: "1", Status: order.Delivered}, {ID: "2", Status: order.Canceled}}))
+
+	err := CanClose([]order.Order{{ID: "1", Status: order.Delivered}, {ID: "2", Status: order.InPreparation}})
+	s.ErrorIs(err, errs.ErrConflict)
+	s.Contains(err.Error(), "orders 2 must be")
+}
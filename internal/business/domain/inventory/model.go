@@ -0,0 +1,72 @@
+package inventory
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Ingredient is the stock a branch has of something the kitchen cooks with. Stock is in the
+// ingredient's own unit, e.g. grams or units, so recipes can use whole numbers.
+type Ingredient struct {
+	BranchID  string    `json:"branch_id"`
+	Name      string    `json:"name"`
+	Unit      string    `json:"unit"`
+	Stock     int64     `json:"stock"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Recipe lists what one portion of a menu item takes. Items without a recipe aren't tracked and
+// are always available.
+type Recipe struct {
+	Item  string       `json:"item"`
+	Lines []RecipeLine `json:"lines"`
+}
+
+type RecipeLine struct {
+	Ingredient string `json:"ingredient"`
+	Quantity   int64  `json:"quantity"`
+}
+
+// Requirements adds up the ingredients needed to prepare every dish of the order.
+func Requirements(menu []string, recipes []Recipe) map[string]int64 {
+	byItem := make(map[string]Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byItem[recipe.Item] = recipe
+	}
+
+	required := make(map[string]int64)
+	for _, item := range menu {
+		for _, line := range byItem[item].Lines {
+			required[line.Ingredient] += line.Quantity
+		}
+	}
+
+	return required
+}
+
+// Missing returns the ingredients, sorted by name, whose stock doesn't cover what is required.
+// An ingredient without stock counts as zero.
+func Missing(required, stock map[string]int64) []string {
+	var missing []string
+	for ingredient, quantity := range required {
+		if stock[ingredient] < quantity {
+			missing = append(missing, ingredient)
+		}
+	}
+
+	sort.Strings(missing)
+	return missing
+}
+
+// Available tells whether there is stock to prepare one portion of the recipe.
+func Available(recipe Recipe, stock map[string]int64) bool {
+	return len(Missing(Requirements([]string{recipe.Item}, []Recipe{recipe}), stock)) == 0
+}
+
+func InsufficientStock(missing []string) error {
+	return errs.Conflict("insufficient_stock",
+		fmt.Sprintf("not enough %s to prepare the order", strings.Join(missing, ", ")))
+}
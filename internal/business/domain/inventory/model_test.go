@@ -0,0 +1,47 @@
+package inventory
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type InventoryTestSuite struct {
+	suite.Suite
+}
+
+func TestInventory(t *testing.T) {
+	suite.Run(t, new(InventoryTestSuite))
+}
+
+var recipes = []Recipe{
+	{Item: "Milanesa", Lines: []RecipeLine{{Ingredient: "carne", Quantity: 200}, {Ingredient: "pan rallado", Quantity: 50}}},
+	{Item: "Ensalada", Lines: []RecipeLine{{Ingredient: "lechuga", Quantity: 1}}},
+}
+
+func (s *InventoryTestSuite) TestRequirements() {
+	required := Requirements([]string{"Milanesa", "Milanesa", "Agua", "Ensalada"}, recipes)
+
+	s.Equal(map[string]int64{"carne": 400, "pan rallado": 100, "lechuga": 1}, required)
+	s.Empty(Requirements([]string{"Agua"}, recipes))
+}
+
+func (s *InventoryTestSuite) TestMissing() {
+	required := map[string]int64{"carne": 400, "pan rallado": 100, "lechuga": 1}
+
+	s.Empty(Missing(required, map[string]int64{"carne": 400, "pan rallado": 500, "lechuga": 3}))
+	s.Equal([]string{"carne", "lechuga"}, Missing(required, map[string]int64{"carne": 399, "pan rallado": 500}))
+}
+
+func (s *InventoryTestSuite) TestAvailable() {
+	s.True(Available(recipes[0], map[string]int64{"carne": 200, "pan rallado": 50}))
+	s.False(Available(recipes[0], map[string]int64{"carne": 199, "pan rallado": 50}))
+	s.True(Available(Recipe{Item: "Agua"}, nil))
+}
+
+func (s *InventoryTestSuite) TestInsufficientStock() {
+	err := InsufficientStock([]string{"carne", "lechuga"})
+
+	s.ErrorIs(err, errs.ErrConflict)
+	s.Equal("not enough carne, lechuga to prepare the order", err.Error())
+}
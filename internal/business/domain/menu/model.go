@@ -0,0 +1,19 @@
+package menu
+
+// Item is a dish of the menu. Prices are in minor units (cents) so bills add up exactly.
+type Item struct {
+	Name  string `json:"name"`
+	Price int64  `json:"price"`
+	// Available is false while the branch lacks the stock to prepare the item.
+	Available bool `json:"available"`
+}
+
+// Prices indexes the price of every item by its name, which is how orders reference dishes.
+func Prices(items []Item) map[string]int64 {
+	prices := make(map[string]int64, len(items))
+	for _, item := range items {
+		prices[item.Name] = item.Price
+	}
+
+	return prices
+}
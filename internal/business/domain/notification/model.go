@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"time"
+)
+
+// Message is a notification waiting in the outbox. It is written together with the status change
+// that triggers it and delivered later by the dispatcher, so a failing notification channel
+// never fails the order update.
+type Message struct {
+	ID            string      `json:"id"`
+	BranchID      string      `json:"branch_id"`
+	OrderID       string      `json:"order_id"`
+	Order         order.Order `json:"order"`
+	Attempts      int         `json:"attempts"`
+	CreatedAt     time.Time   `json:"created_at"`
+	NextAttemptAt *time.Time  `json:"next_attempt_at,omitempty"`
+	SentAt        *time.Time  `json:"sent_at,omitempty"`
+	LastError     string      `json:"last_error,omitempty"`
+}
+
+const (
+	MaxAttempts = 10
+
+	baseRetryDelay = 10 * time.Second
+	maxRetryDelay  = time.Hour
+)
+
+// RetryDelay is the time to wait before the given attempt, doubling from baseRetryDelay up to
+// maxRetryDelay.
+func RetryDelay(attempt int) time.Duration {
+	delay := baseRetryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay
+}
@@ -0,0 +1,71 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+)
+
+// BatchMode tells what a batch operation does when some of its items fail.
+type BatchMode string
+
+const (
+	// AllOrNothing applies the batch only if every item succeeds.
+	AllOrNothing BatchMode = "all_or_nothing"
+	// BestEffort applies the items that succeed and reports the ones that don't.
+	BestEffort BatchMode = "best_effort"
+)
+
+// MaxBatchSize bounds the items of a batch, which all run in a single transaction.
+const MaxBatchSize = 500
+
+// ErrBatchAborted is the result of the items of an all-or-nothing batch that weren't applied
+// because another item failed.
+var ErrBatchAborted = errs.Conflict("batch_aborted", "not applied, another item of the batch failed")
+
+// BatchResult is the outcome of an item of a batch, at the position the item had in it. Previous
+// is only set for updates.
+type BatchResult struct {
+	Order    *Order
+	Previous *Order
+	Err      error
+}
+
+func (r BatchResult) Applied() bool {
+	return r.Err == nil && r.Order != nil
+}
+
+// ValidateBatch checks the mode and the size of a batch before any item is applied.
+func ValidateBatch(mode BatchMode, size int) error {
+	if !mode.Valid() {
+		invalid := &InvalidValueError{Kind: "batch mode", Value: string(mode), Allowed: enumValues(BatchModes)}
+		return errs.Invalid("invalid_batch", invalid.Error())
+	}
+	if size == 0 || size > MaxBatchSize {
+		return errs.Invalid("invalid_batch", fmt.Sprintf("a batch must have between 1 and %d items", MaxBatchSize))
+	}
+
+	return nil
+}
+
+// Failed tells whether any item of the batch failed.
+func Failed(results []BatchResult) bool {
+	for _, result := range results {
+		if result.Err != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Abort undoes the results of an all-or-nothing batch that failed: the items that failed keep
+// their error and every other item gets ErrBatchAborted.
+func Abort(results []BatchResult) []BatchResult {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i] = BatchResult{Err: ErrBatchAborted}
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,34 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type BatchTestSuite struct {
+	suite.Suite
+}
+
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func (s *BatchTestSuite) TestValidateBatch() {
+	s.NoError(ValidateBatch(AllOrNothing, 1))
+	s.NoError(ValidateBatch(BestEffort, MaxBatchSize))
+
+	s.Equal(errs.Invalid("invalid_batch", `invalid batch mode "some", expected one of all_or_nothing, best_effort`),
+		ValidateBatch("some", 1))
+	s.Equal(errs.Invalid("invalid_batch", "a batch must have between 1 and 500 items"), ValidateBatch(BestEffort, 0))
+	s.Equal(errs.Invalid("invalid_batch", "a batch must have between 1 and 500 items"), ValidateBatch(BestEffort, MaxBatchSize+1))
+}
+
+func (s *BatchTestSuite) TestAbort() {
+	notFound := errs.NotFound("order_not_found", "order not found")
+	results := []BatchResult{{Order: &Order{ID: "1"}}, {Err: notFound}, {}}
+
+	s.True(Failed(results))
+	s.Equal([]BatchResult{{Err: ErrBatchAborted}, {Err: notFound}, {Err: ErrBatchAborted}}, Abort(results))
+	s.False(Failed([]BatchResult{{Order: &Order{ID: "1"}}}))
+}
@@ -0,0 +1,131 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EditableStatuses are the statuses whose orders can still be edited. Orders IN_PREPARATION also
+// need the kitchen to acknowledge the change.
+var EditableStatuses = []Status{Scheduled, Pending, InPreparation}
+
+var (
+	ErrNotEditable = errs.Conflict("order_not_editable",
+		fmt.Sprintf("only %s orders can be edited", strings.Join(enumValues(EditableStatuses), ", ")))
+	ErrKitchenAckRequired = errs.Conflict("kitchen_ack_required",
+		"orders IN_PREPARATION can only be edited with kitchen_ack, naming who in the kitchen accepted the change")
+)
+
+// Edit is a change to the content of an order. Nil fields are left as they are. When Version is
+// set the edit only applies to that version of the order.
+type Edit struct {
+	OrderID     string
+	AddItems    []string
+	RemoveItems []string
+	Source      *Source
+	Type        *OrderType
+	Notes       *string
+	// KitchenAck names who in the kitchen accepted the change of an order IN_PREPARATION.
+	KitchenAck string
+	Version    *int
+}
+
+// Revision is an entry of the edit history of an order: what an edit changed, in the status the
+// order had then. Source, Type and Notes are only set when they changed.
+type Revision struct {
+	ID         string     `json:"id"`
+	OrderID    string     `json:"order_id"`
+	Status     Status     `json:"status"`
+	Added      []string   `json:"added,omitempty"`
+	Removed    []string   `json:"removed,omitempty"`
+	Source     *Source    `json:"order_source,omitempty"`
+	Type       *OrderType `json:"order_type,omitempty"`
+	Notes      *string    `json:"notes,omitempty"`
+	KitchenAck string     `json:"kitchen_ack,omitempty"`
+	EditedAt   time.Time  `json:"edited_at"`
+}
+
+// Apply returns order with the edit applied and the revision that records it. Items are removed
+// one occurrence at a time, so an order with two pizzas keeps one after removing "pizza" once.
+func (e Edit) Apply(order Order, now time.Time) (Order, Revision, error) {
+	if !slices.Contains(EditableStatuses, order.Status) {
+		return Order{}, Revision{}, ErrNotEditable
+	}
+	if order.Status == InPreparation && len(e.KitchenAck) == 0 {
+		return Order{}, Revision{}, ErrKitchenAckRequired
+	}
+
+	edited := order
+	edited.Menu = slices.Clone(order.Menu)
+	revision := Revision{OrderID: order.ID, Status: order.Status, KitchenAck: e.KitchenAck, EditedAt: now}
+
+	for _, item := range e.RemoveItems {
+		i := slices.Index(edited.Menu, item)
+		if i < 0 {
+			return Order{}, Revision{}, errs.Invalid("invalid_edit", fmt.Sprintf("item %q isn't in the order", item))
+		}
+		edited.Menu = slices.Delete(edited.Menu, i, i+1)
+		revision.Removed = append(revision.Removed, item)
+	}
+	edited.Menu = append(edited.Menu, e.AddItems...)
+	revision.Added = slices.Clone(e.AddItems)
+	if len(edited.Menu) == 0 {
+		return Order{}, Revision{}, errs.Invalid("invalid_edit", "an order needs at least one item, cancel it instead")
+	}
+
+	if e.Source != nil && *e.Source != order.Source {
+		edited.Source = *e.Source
+		revision.Source = e.Source
+	}
+	if e.Type != nil && *e.Type != order.Type {
+		edited.Type = *e.Type
+		revision.Type = e.Type
+	}
+	if e.Notes != nil && *e.Notes != order.Notes {
+		edited.Notes = *e.Notes
+		revision.Notes = e.Notes
+	}
+
+	if len(revision.Added) == 0 && len(revision.Removed) == 0 && revision.Source == nil && revision.Type == nil && revision.Notes == nil {
+		return Order{}, Revision{}, errs.Invalid("invalid_edit", "the edit changes nothing")
+	}
+
+	return edited, revision, nil
+}
+
+// Quote is what an order comes to and when it should be ready. Edits recalculate it.
+type Quote struct {
+	Total int64 `json:"total"`
+	// Unpriced lists the items missing from the menu, which count as zero.
+	Unpriced []string `json:"unpriced,omitempty"`
+	// ETA is nil for order types without a preparation estimate.
+	ETA *time.Time `json:"eta,omitempty"`
+}
+
+// NewQuote prices the items of order and estimates it ready the preparation time of its type
+// after from, the time its current content reached the kitchen. Scheduled orders are promoted to
+// be ready when they are due, so that is their ETA.
+func NewQuote(order Order, prices map[string]int64, estimates map[OrderType]time.Duration, from time.Time) Quote {
+	var quote Quote
+	for _, item := range order.Menu {
+		price, priced := prices[item]
+		if !priced && !slices.Contains(quote.Unpriced, item) {
+			quote.Unpriced = append(quote.Unpriced, item)
+		}
+		quote.Total += price
+	}
+	slices.Sort(quote.Unpriced)
+
+	if order.Status == Scheduled && order.ScheduledFor != nil {
+		eta := *order.ScheduledFor
+		quote.ETA = &eta
+	} else if estimate, ok := estimates[order.Type]; ok {
+		eta := from.Add(estimate)
+		quote.ETA = &eta
+	}
+
+	return quote
+}
@@ -0,0 +1,98 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type EditTestSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func TestEdit(t *testing.T) {
+	suite.Run(t, new(EditTestSuite))
+}
+
+func (s *EditTestSuite) SetupTest() {
+	s.now = time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *EditTestSuite) order(status Status) Order {
+	return Order{ID: "1", Menu: []string{"pizza", "pizza", "salad"}, Status: status, Source: Phone, Type: Normal}
+}
+
+func (s *EditTestSuite) TestApply() {
+	vip := VIP
+	notes := "no onions"
+	edit := Edit{OrderID: "1", AddItems: []string{"soda"}, RemoveItems: []string{"pizza"}, Type: &vip, Notes: &notes}
+
+	edited, revision, err := edit.Apply(s.order(Pending), s.now)
+	s.NoError(err)
+	s.Equal([]string{"pizza", "salad", "soda"}, edited.Menu)
+	s.Equal(VIP, edited.Type)
+	s.Equal(Phone, edited.Source)
+	s.Equal("no onions", edited.Notes)
+	s.Equal(Revision{
+		OrderID:  "1",
+		Status:   Pending,
+		Added:    []string{"soda"},
+		Removed:  []string{"pizza"},
+		Type:     &vip,
+		Notes:    &notes,
+		EditedAt: s.now,
+	}, revision)
+}
+
+func (s *EditTestSuite) TestApplyLeavesTheOrderUntouched() {
+	order := s.order(Pending)
+	_, _, err := Edit{RemoveItems: []string{"pizza"}}.Apply(order, s.now)
+	s.NoError(err)
+	s.Equal(s.order(Pending), order)
+}
+
+func (s *EditTestSuite) TestApplyStatus() {
+	_, _, err := Edit{AddItems: []string{"soda"}}.Apply(s.order(Scheduled), s.now)
+	s.NoError(err)
+
+	_, _, err = Edit{AddItems: []string{"soda"}}.Apply(s.order(InPreparation), s.now)
+	s.Equal(ErrKitchenAckRequired, err)
+
+	_, revision, err := Edit{AddItems: []string{"soda"}, KitchenAck: "chef"}.Apply(s.order(InPreparation), s.now)
+	s.NoError(err)
+	s.Equal("chef", revision.KitchenAck)
+
+	_, _, err = Edit{AddItems: []string{"soda"}, KitchenAck: "chef"}.Apply(s.order(Finished), s.now)
+	s.Equal(errs.Conflict("order_not_editable", "only SCHEDULED, PENDING, IN_PREPARATION orders can be edited"), err)
+}
+
+func (s *EditTestSuite) TestApplyInvalid() {
+	phone := Phone
+
+	_, _, err := Edit{RemoveItems: []string{"soda"}}.Apply(s.order(Pending), s.now)
+	s.Equal(errs.Invalid("invalid_edit", `item "soda" isn't in the order`), err)
+
+	_, _, err = Edit{RemoveItems: []string{"pizza", "pizza", "salad"}}.Apply(s.order(Pending), s.now)
+	s.Equal(errs.Invalid("invalid_edit", "an order needs at least one item, cancel it instead"), err)
+
+	_, _, err = Edit{Source: &phone}.Apply(s.order(Pending), s.now)
+	s.Equal(errs.Invalid("invalid_edit", "the edit changes nothing"), err)
+}
+
+func (s *EditTestSuite) TestNewQuote() {
+	prices := map[string]int64{"pizza": 1200, "salad": 800}
+	estimates := map[OrderType]time.Duration{Normal: 20 * time.Minute}
+
+	quote := NewQuote(Order{Menu: []string{"pizza", "pizza", "salad", "soda"}, Status: Pending, Type: Normal}, prices, estimates, s.now)
+	eta := s.now.Add(20 * time.Minute)
+	s.Equal(Quote{Total: 3200, Unpriced: []string{"soda"}, ETA: &eta}, quote)
+
+	quote = NewQuote(Order{Menu: []string{"pizza"}, Status: Pending, Type: VIP}, prices, estimates, s.now)
+	s.Equal(Quote{Total: 1200}, quote)
+
+	due := s.now.Add(2 * time.Hour)
+	quote = NewQuote(Order{Menu: []string{"pizza"}, Status: Scheduled, Type: Normal, ScheduledFor: &due}, prices, estimates, s.now)
+	s.Equal(&due, quote.ETA)
+}
@@ -0,0 +1,85 @@
+package order
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	Statuses   = []Status{Scheduled, Pending, InPreparation, Finished, Delivered, Canceled}
+	Sources    = []Source{InPerson, Delivery, Phone}
+	OrderTypes = []OrderType{Normal, VIP}
+	BatchModes = []BatchMode{AllOrNothing, BestEffort}
+)
+
+// InvalidValueError is returned when decoding a value that doesn't belong to one of the enums.
+type InvalidValueError struct {
+	Kind    string
+	Value   string
+	Allowed []string
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid %s %q, expected one of %s", e.Kind, e.Value, strings.Join(e.Allowed, ", "))
+}
+
+func (s Status) Valid() bool {
+	return slices.Contains(Statuses, s)
+}
+
+func (s *Status) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "status", Statuses, s)
+}
+
+func (s Source) Valid() bool {
+	return slices.Contains(Sources, s)
+}
+
+func (s *Source) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "source", Sources, s)
+}
+
+func (t OrderType) Valid() bool {
+	return slices.Contains(OrderTypes, t)
+}
+
+func (t *OrderType) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "order type", OrderTypes, t)
+}
+
+func (m BatchMode) Valid() bool {
+	return slices.Contains(BatchModes, m)
+}
+
+func (m *BatchMode) UnmarshalJSON(data []byte) error {
+	return unmarshalEnum(data, "batch mode", BatchModes, m)
+}
+
+func unmarshalEnum[T ~string](data []byte, kind string, allowed []T, target *T) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	// the empty string is the zero value, left for the required rule to reject
+	if len(value) > 0 && !slices.Contains(allowed, T(value)) {
+		return &InvalidValueError{Kind: kind, Value: value, Allowed: enumValues(allowed)}
+	}
+
+	*target = T(value)
+	return nil
+}
+
+func enumValues[T ~string](allowed []T) []string {
+	values := make([]string, len(allowed))
+	for i, value := range allowed {
+		values[i] = string(value)
+	}
+	return values
+}
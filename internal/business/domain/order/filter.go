@@ -0,0 +1,45 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"slices"
+)
+
+// Filter narrows the orders listed or exported. Empty fields match every order.
+type Filter struct {
+	Statuses []Status
+	Sources  []Source
+	Types    []OrderType
+}
+
+// NewFilter builds a filter from raw values, e.g. the query of a request, rejecting the values
+// that don't belong to their enum.
+func NewFilter(statuses, sources, types []string) (Filter, error) {
+	var filter Filter
+	var err error
+
+	if filter.Statuses, err = parseEnums(statuses, "status", Statuses); err != nil {
+		return Filter{}, err
+	}
+	if filter.Sources, err = parseEnums(sources, "source", Sources); err != nil {
+		return Filter{}, err
+	}
+	if filter.Types, err = parseEnums(types, "order type", OrderTypes); err != nil {
+		return Filter{}, err
+	}
+
+	return filter, nil
+}
+
+func parseEnums[T ~string](values []string, kind string, allowed []T) ([]T, error) {
+	var parsed []T
+	for _, value := range values {
+		if !slices.Contains(allowed, T(value)) {
+			invalid := &InvalidValueError{Kind: kind, Value: value, Allowed: enumValues(allowed)}
+			return nil, errs.Invalid("invalid_filter", invalid.Error())
+		}
+		parsed = append(parsed, T(value))
+	}
+
+	return parsed, nil
+}
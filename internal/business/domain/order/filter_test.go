@@ -0,0 +1,24 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type FilterTestSuite struct {
+	suite.Suite
+}
+
+func TestFilter(t *testing.T) {
+	suite.Run(t, new(FilterTestSuite))
+}
+
+func (s *FilterTestSuite) TestNewFilter() {
+	filter, err := NewFilter([]string{"PENDING", "FINISHED"}, []string{"PHONE"}, nil)
+	s.Require().NoError(err)
+	s.Equal(Filter{Statuses: []Status{Pending, Finished}, Sources: []Source{Phone}}, filter)
+
+	_, err = NewFilter(nil, nil, []string{"GOLD"})
+	s.Equal(errs.Invalid("invalid_filter", `invalid order type "GOLD", expected one of NORMAL, VIP`), err)
+}
@@ -4,6 +4,7 @@ import "time"
 
 type Order struct {
 	ID        string    `json:"id"`
+	BranchID  string    `json:"branch_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Menu      []string  `json:"menu"`
@@ -11,11 +12,27 @@ type Order struct {
 	Source    Source    `json:"order_source"`
 	Type      OrderType `json:"order_type"`
 	Priority  int       `json:"priority"`
+	// ScheduledFor is when a pre-order is due; nil for orders to prepare right away.
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+	// Notes are free-form instructions for the kitchen, e.g. "no onions".
+	Notes string `json:"notes,omitempty"`
+	// Version starts at 1 and grows with every update, so writes can be based on a known state.
+	Version int `json:"version"`
+}
+
+// StatusUpdate is a change of status, and optionally of priority, of an order. When Version is
+// set the change only applies to that version of the order.
+type StatusUpdate struct {
+	OrderID  string
+	Status   Status
+	Priority *int
+	Version  *int
 }
 
 type Status string
 
 const (
+	Scheduled     Status = "SCHEDULED"
 	Pending       Status = "PENDING"
 	InPreparation Status = "IN_PREPARATION"
 	Finished      Status = "FINISHED"
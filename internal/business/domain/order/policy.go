@@ -0,0 +1,79 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// InitialStatuses are the statuses an order can be created in. Any other status is reached
+// through the kitchen workflow, or loaded with the historical import.
+var InitialStatuses = []Status{Pending, Scheduled}
+
+// TargetStatuses are the statuses a status change can move an order to. SCHEDULED is left out: it
+// needs a scheduled_for, so only orders created for later are in it.
+var TargetStatuses = []Status{Pending, InPreparation, Finished, Delivered, Canceled}
+
+// ClosedStatuses are the statuses an order never leaves, so it can be archived once it is old.
+var ClosedStatuses = []Status{Delivered, Canceled}
+
+// ErrOrderModified is returned when an order changed after the version a write was based on.
+var ErrOrderModified = errs.Stale("order_modified", "order was modified since it was read, get it again and retry")
+
+// InitialStatus applies the creation policy: orders with a scheduled_for in the future start as
+// SCHEDULED and every other order starts as PENDING. The client may send the status, but only
+// the one the policy would pick.
+func InitialStatus(order Order, now time.Time) (Status, error) {
+	expected := Pending
+	if order.ScheduledFor != nil {
+		if !order.ScheduledFor.After(now) {
+			return "", errs.Invalid("invalid_schedule", "scheduled_for must be in the future")
+		}
+		expected = Scheduled
+	}
+
+	if len(order.Status) == 0 || order.Status == expected {
+		return expected, nil
+	}
+
+	if order.Status == Scheduled {
+		return "", errs.Invalid("invalid_schedule", "scheduled orders require scheduled_for")
+	}
+
+	return "", errs.Invalid("invalid_initial_status", fmt.Sprintf("orders can't be created as %s, expected one of %s",
+		order.Status, strings.Join(enumValues(InitialStatuses), ", ")))
+}
+
+// ValidateHistorical checks the timestamps of an imported order, which are kept as sent instead
+// of being set by the database.
+func ValidateHistorical(order Order, now time.Time) error {
+	if order.CreatedAt.IsZero() {
+		return errs.Invalid("validation_failed", "created_at is required")
+	}
+	if order.CreatedAt.After(now) {
+		return errs.Invalid("validation_failed", "created_at can't be in the future")
+	}
+	if order.UpdatedAt.Before(order.CreatedAt) {
+		return errs.Invalid("validation_failed", "updated_at can't be before created_at")
+	}
+
+	return nil
+}
+
+// ReturnsStock tells whether an order moving from one status to another gives back the stock it
+// took when it entered IN_PREPARATION: it leaves the kitchen without being made, canceled or sent
+// back to the queue. Once finished or delivered the food is made.
+func ReturnsStock(from, to Status) bool {
+	return from == InPreparation && (to == Pending || to == Canceled)
+}
+
+// CheckVersion rejects a change based on another version of the order. A nil version matches
+// any, for clients that don't track them.
+func CheckVersion(order Order, version *int) error {
+	if version != nil && *version != order.Version {
+		return ErrOrderModified
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type PolicyTestSuite struct {
+	suite.Suite
+}
+
+func TestPolicy(t *testing.T) {
+	suite.Run(t, new(PolicyTestSuite))
+}
+
+func (s *PolicyTestSuite) TestInitialStatus() {
+	now := time.Now()
+	future := now.Add(3 * time.Hour)
+	past := now.Add(-time.Minute)
+
+	var tests = []struct {
+		name           string
+		order          Order
+		expectedStatus Status
+		expectedError  error
+	}{
+		{name: "default_pending", order: Order{}, expectedStatus: Pending},
+		{name: "pending", order: Order{Status: Pending}, expectedStatus: Pending},
+		{name: "scheduled", order: Order{ScheduledFor: &future}, expectedStatus: Scheduled},
+		{name: "scheduled_explicit", order: Order{Status: Scheduled, ScheduledFor: &future}, expectedStatus: Scheduled},
+		{name: "error_scheduled_in_past", order: Order{ScheduledFor: &past}, expectedError: errs.ErrInvalid},
+		{name: "error_scheduled_without_time", order: Order{Status: Scheduled}, expectedError: errs.ErrInvalid},
+		{name: "error_pending_with_time", order: Order{Status: Pending, ScheduledFor: &future}, expectedError: errs.ErrInvalid},
+		{name: "error_delivered", order: Order{Status: Delivered}, expectedError: errs.ErrInvalid},
+		{name: "error_canceled", order: Order{Status: Canceled}, expectedError: errs.ErrInvalid},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			status, err := InitialStatus(tt.order, now)
+			if tt.expectedError != nil {
+				s.ErrorIs(err, tt.expectedError)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tt.expectedStatus, status)
+		})
+	}
+}
+
+func (s *PolicyTestSuite) TestValidateHistorical() {
+	now := time.Now()
+
+	s.NoError(ValidateHistorical(Order{CreatedAt: now.Add(-time.Hour), UpdatedAt: now}, now))
+	s.ErrorIs(ValidateHistorical(Order{}, now), errs.ErrInvalid)
+	s.ErrorIs(ValidateHistorical(Order{CreatedAt: now.Add(time.Hour), UpdatedAt: now.Add(time.Hour)}, now), errs.ErrInvalid)
+	s.ErrorIs(ValidateHistorical(Order{CreatedAt: now.Add(-time.Hour), UpdatedAt: now.Add(-2 * time.Hour)}, now), errs.ErrInvalid)
+}
+
+func (s *PolicyTestSuite) TestCheckVersion() {
+	current, stale := 3, 2
+	order := Order{ID: "123456", Version: current}
+
+	s.NoError(CheckVersion(order, nil))
+	s.NoError(CheckVersion(order, &current))
+	s.Equal(ErrOrderModified, CheckVersion(order, &stale))
+}
+
+func (s *PolicyTestSuite) TestReturnsStock() {
+	s.True(ReturnsStock(InPreparation, Pending))
+	s.True(ReturnsStock(InPreparation, Canceled))
+	s.False(ReturnsStock(InPreparation, Finished))
+	s.False(ReturnsStock(InPreparation, Delivered))
+	s.False(ReturnsStock(InPreparation, InPreparation))
+	s.False(ReturnsStock(Pending, Canceled))
+}
@@ -0,0 +1,83 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+	"slices"
+)
+
+var (
+	// ErrNotQueued is returned when moving an order that isn't waiting in the kitchen queue.
+	ErrNotQueued = errs.Conflict("order_not_queued", "only PENDING orders can be moved in the queue")
+	// ErrVIPPrecedence is returned when a move would put a NORMAL order ahead of VIP orders.
+	ErrVIPPrecedence = errs.Conflict("vip_precedence", "NORMAL orders can't be moved ahead of VIP orders unless forced")
+)
+
+// Move places an order of the queue right before or after another one, or at its top. Exactly
+// one of Before, After and Top is set.
+type Move struct {
+	OrderID string
+	Before  string
+	After   string
+	Top     bool
+	// Force lets a NORMAL order jump ahead of VIP orders.
+	Force bool
+}
+
+func (m Move) Validate() error {
+	targets := 0
+	for _, set := range []bool{len(m.Before) > 0, len(m.After) > 0, m.Top} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return errs.Invalid("invalid_move", "a move needs exactly one of before, after or top")
+	}
+	if m.Before == m.OrderID || m.After == m.OrderID {
+		return errs.Invalid("invalid_move", "an order can't be moved relative to itself")
+	}
+
+	return nil
+}
+
+// Reorder applies move to queue, the waiting orders in their current order, and returns them in
+// the new order with their priorities renumbered from 1.
+func Reorder(queue []Order, move Move) ([]Order, error) {
+	if err := move.Validate(); err != nil {
+		return nil, err
+	}
+
+	from := slices.IndexFunc(queue, func(o Order) bool { return o.ID == move.OrderID })
+	if from < 0 {
+		return nil, ErrNotQueued
+	}
+	moved := queue[from]
+
+	reordered := slices.Delete(slices.Clone(queue), from, from+1)
+	to := 0
+	if !move.Top {
+		reference := move.Before + move.After
+		to = slices.IndexFunc(reordered, func(o Order) bool { return o.ID == reference })
+		if to < 0 {
+			return nil, errs.Conflict("order_not_queued", fmt.Sprintf("order %s isn't in the queue", reference))
+		}
+		if len(move.After) > 0 {
+			to++
+		}
+	}
+	reordered = slices.Insert(reordered, to, moved)
+
+	// only VIP orders that were ahead of the moved one count, one already behind it was left there
+	if moved.Type != VIP && !move.Force && slices.ContainsFunc(queue[:from], func(o Order) bool {
+		return o.Type == VIP && slices.IndexFunc(reordered, func(r Order) bool { return r.ID == o.ID }) > to
+	}) {
+		return nil, ErrVIPPrecedence
+	}
+
+	for i := range reordered {
+		reordered[i].Priority = i + 1
+	}
+
+	return reordered, nil
+}
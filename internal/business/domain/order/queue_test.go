@@ -0,0 +1,101 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type QueueTestSuite struct {
+	suite.Suite
+}
+
+func TestQueue(t *testing.T) {
+	suite.Run(t, new(QueueTestSuite))
+}
+
+func (s *QueueTestSuite) queue() []Order {
+	return []Order{
+		{ID: "a", Type: VIP, Priority: 3},
+		{ID: "b", Type: Normal, Priority: 5},
+		{ID: "c", Type: Normal, Priority: 8},
+		{ID: "d", Type: Normal, Priority: 9},
+	}
+}
+
+func ids(orders []Order) []string {
+	result := make([]string, len(orders))
+	for i, order := range orders {
+		result[i] = order.ID
+	}
+	return result
+}
+
+func (s *QueueTestSuite) TestValidate() {
+	invalid := errs.Invalid("invalid_move", "a move needs exactly one of before, after or top")
+
+	s.NoError(Move{OrderID: "a", Top: true}.Validate())
+	s.NoError(Move{OrderID: "a", Before: "b"}.Validate())
+	s.Equal(invalid, Move{OrderID: "a"}.Validate())
+	s.Equal(invalid, Move{OrderID: "a", Before: "b", Top: true}.Validate())
+	s.Equal(errs.Invalid("invalid_move", "an order can't be moved relative to itself"), Move{OrderID: "a", After: "a"}.Validate())
+}
+
+func (s *QueueTestSuite) TestReorder() {
+	s.Run("before", func() {
+		result, err := Reorder(s.queue(), Move{OrderID: "d", Before: "c"})
+		s.NoError(err)
+		s.Equal([]string{"a", "b", "d", "c"}, ids(result))
+		s.Equal([]int{1, 2, 3, 4}, []int{result[0].Priority, result[1].Priority, result[2].Priority, result[3].Priority})
+	})
+
+	s.Run("after", func() {
+		result, err := Reorder(s.queue(), Move{OrderID: "b", After: "d"})
+		s.NoError(err)
+		s.Equal([]string{"a", "c", "d", "b"}, ids(result))
+	})
+
+	s.Run("top of a VIP queue", func() {
+		result, err := Reorder(s.queue(), Move{OrderID: "a", Top: true})
+		s.NoError(err)
+		s.Equal([]string{"a", "b", "c", "d"}, ids(result))
+	})
+
+	s.Run("leaves the queue given untouched", func() {
+		queue := s.queue()
+		_, err := Reorder(queue, Move{OrderID: "d", Top: true, Force: true})
+		s.NoError(err)
+		s.Equal(s.queue(), queue)
+	})
+
+	s.Run("not queued", func() {
+		_, err := Reorder(s.queue(), Move{OrderID: "x", Top: true})
+		s.Equal(ErrNotQueued, err)
+
+		_, err = Reorder(s.queue(), Move{OrderID: "a", After: "x"})
+		s.Equal(errs.Conflict("order_not_queued", "order x isn't in the queue"), err)
+	})
+}
+
+func (s *QueueTestSuite) TestReorderVIPPrecedence() {
+	_, err := Reorder(s.queue(), Move{OrderID: "c", Top: true})
+	s.Equal(ErrVIPPrecedence, err)
+
+	_, err = Reorder(s.queue(), Move{OrderID: "b", Before: "a"})
+	s.Equal(ErrVIPPrecedence, err)
+
+	result, err := Reorder(s.queue(), Move{OrderID: "c", Top: true, Force: true})
+	s.NoError(err)
+	s.Equal([]string{"c", "a", "b", "d"}, ids(result))
+
+	// a NORMAL order already ahead of a VIP one can move without passing it
+	queue := []Order{{ID: "n1", Type: Normal}, {ID: "n2", Type: Normal}, {ID: "v", Type: VIP}}
+	result, err = Reorder(queue, Move{OrderID: "n2", Top: true})
+	s.NoError(err)
+	s.Equal([]string{"n2", "n1", "v"}, ids(result))
+
+	// VIP orders may be moved back behind NORMAL ones
+	result, err = Reorder(s.queue(), Move{OrderID: "a", After: "d"})
+	s.NoError(err)
+	s.Equal([]string{"b", "c", "d", "a"}, ids(result))
+}
@@ -1,10 +1,12 @@
 package order
 
 import (
+	"challenge-yuno/internal/business/errs"
 	"fmt"
 	"github.com/go-playground/validator/v10"
-	"github.com/labstack/echo/v4"
-	"net/http"
+	"reflect"
+	"slices"
+	"strings"
 )
 
 var (
@@ -13,6 +15,20 @@ var (
 
 func init() {
 	validate = validator.New()
+	validate.RegisterTagNameFunc(jsonFieldName)
+
+	validate.RegisterValidation("order_status", func(fl validator.FieldLevel) bool {
+		return Status(fl.Field().String()).Valid()
+	})
+	validate.RegisterValidation("order_target_status", func(fl validator.FieldLevel) bool {
+		return slices.Contains(TargetStatuses, Status(fl.Field().String()))
+	})
+	validate.RegisterValidation("order_source", func(fl validator.FieldLevel) bool {
+		return Source(fl.Field().String()).Valid()
+	})
+	validate.RegisterValidation("order_type", func(fl validator.FieldLevel) bool {
+		return OrderType(fl.Field().String()).Valid()
+	})
 }
 
 // Validate runs through the tags and validates all fields.
@@ -20,9 +36,44 @@ func Validate(model interface{}) error {
 	if err := validate.Struct(model); err != nil {
 		e, ok := err.(validator.ValidationErrors)
 		if ok {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("error validating model: %s", e.Error()))
+			messages := make([]string, len(e))
+			for i, fieldErr := range e {
+				messages[i] = fieldMessage(fieldErr)
+			}
+			return errs.Invalid("validation_failed", strings.Join(messages, "; "))
 		}
 		return err
 	}
 	return nil
 }
+
+// fieldMessage describes a failed rule using the JSON name of the field, which is what the client
+// sent.
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fieldErr.Field())
+	case "order_status":
+		return allowedMessage(fieldErr, enumValues(Statuses))
+	case "order_target_status":
+		return allowedMessage(fieldErr, enumValues(TargetStatuses))
+	case "order_source":
+		return allowedMessage(fieldErr, enumValues(Sources))
+	case "order_type":
+		return allowedMessage(fieldErr, enumValues(OrderTypes))
+	default:
+		return fmt.Sprintf("%s is invalid", fieldErr.Field())
+	}
+}
+
+func allowedMessage(fieldErr validator.FieldError, allowed []string) string {
+	return fmt.Sprintf("%s must be one of %s, got %q", fieldErr.Field(), strings.Join(allowed, ", "), fmt.Sprint(fieldErr.Value()))
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,94 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ValidatorTestSuite struct {
+	suite.Suite
+}
+
+func TestValidator(t *testing.T) {
+	suite.Run(t, new(ValidatorTestSuite))
+}
+
+type testOrder struct {
+	Status Status     `json:"status" validate:"required,order_status"`
+	Source Source     `json:"source" validate:"required,order_source"`
+	Type   *OrderType `json:"type,omitempty" validate:"omitempty,order_type"`
+}
+
+func (s *ValidatorTestSuite) TestValidate() {
+	gold := OrderType("GOLD")
+	vip := VIP
+
+	var tests = []struct {
+		name            string
+		model           testOrder
+		expectedMessage string
+	}{
+		{
+			name:  "valid",
+			model: testOrder{Status: Pending, Source: Phone, Type: &vip},
+		},
+		{
+			name:            "missing_fields",
+			model:           testOrder{},
+			expectedMessage: "status is required; source is required",
+		},
+		{
+			name:            "unknown_values",
+			model:           testOrder{Status: "BANANA", Source: "MARS", Type: &gold},
+			expectedMessage: `status must be one of SCHEDULED, PENDING, IN_PREPARATION, FINISHED, DELIVERED, CANCELED, got "BANANA"; source must be one of IN_PERSON, DELIVERY, PHONE, got "MARS"; type must be one of NORMAL, VIP, got "GOLD"`,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := Validate(tt.model)
+			if len(tt.expectedMessage) == 0 {
+				s.NoError(err)
+				return
+			}
+
+			s.ErrorIs(err, errs.ErrInvalid)
+			s.Equal(tt.expectedMessage, err.(*errs.Error).Message)
+		})
+	}
+}
+
+func (s *ValidatorTestSuite) TestUnmarshalJSON() {
+	order := testOrder{}
+	s.NoError(json.Unmarshal([]byte(`{"status": "IN_PREPARATION", "source": "DELIVERY", "type": "VIP"}`), &order))
+	s.Equal(InPreparation, order.Status)
+	s.Equal(Delivery, order.Source)
+	s.Equal(VIP, *order.Type)
+
+	s.NoError(json.Unmarshal([]byte(`{"status": "", "type": null}`), &order))
+	s.Equal(Status(""), order.Status)
+
+	err := json.Unmarshal([]byte(`{"status": "BANANA"}`), &order)
+	var invalidValue *InvalidValueError
+	s.Require().True(errors.As(err, &invalidValue))
+	s.Equal("status", invalidValue.Kind)
+	s.Equal("BANANA", invalidValue.Value)
+
+	s.Error(json.Unmarshal([]byte(`{"source": "MARS"}`), &order))
+	s.Error(json.Unmarshal([]byte(`{"type": "GOLD"}`), &order))
+}
+
+func (s *ValidatorTestSuite) TestTargetStatus() {
+	type statusChange struct {
+		Status Status `json:"status" validate:"required,order_target_status"`
+	}
+
+	s.NoError(Validate(statusChange{Status: Canceled}))
+
+	err := Validate(statusChange{Status: Scheduled})
+	s.ErrorIs(err, errs.ErrInvalid)
+	s.Equal(`status must be one of PENDING, IN_PREPARATION, FINISHED, DELIVERED, CANCELED, got "SCHEDULED"`, err.(*errs.Error).Message)
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"slices"
+	"sort"
+	"time"
+)
+
+// MaxRange bounds how much history a single report may aggregate.
+const MaxRange = 366 * 24 * time.Hour
+
+// Range is the half-open period [From, To) a report covers. Orders fall in the range by the time
+// they were placed.
+type Range struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// NewRange checks the period ends after it starts and isn't longer than MaxRange.
+func NewRange(from, to time.Time) (Range, error) {
+	if !to.After(from) {
+		return Range{}, errs.Invalid("invalid_range", "to must be after from")
+	}
+	if to.Sub(from) > MaxRange {
+		return Range{}, errs.Invalid("invalid_range", "range can't be longer than a year")
+	}
+
+	return Range{From: from, To: to}, nil
+}
+
+// Dimension is what order counts are grouped by.
+type Dimension string
+
+const (
+	Hour   Dimension = "hour"
+	Source Dimension = "source"
+	Type   Dimension = "type"
+)
+
+var Dimensions = []Dimension{Hour, Source, Type}
+
+func (d Dimension) Valid() bool {
+	return slices.Contains(Dimensions, d)
+}
+
+// Count is how many orders were placed for one value of a dimension, e.g. one hour or one
+// source. Hours are keyed by their start in UTC, e.g. "2026-10-19T13:00:00Z".
+type Count struct {
+	Key      string `json:"key"`
+	Orders   int64  `json:"orders"`
+	Canceled int64  `json:"canceled"`
+}
+
+// Durations describes how long orders took to go through a stage, in seconds. Orders that never
+// finished the stage aren't counted.
+type Durations struct {
+	Orders         int64   `json:"orders"`
+	AverageSeconds float64 `json:"average_seconds"`
+	P90Seconds     float64 `json:"p90_seconds"`
+}
+
+// Totals are the raw aggregates a summary is built from.
+type Totals struct {
+	Orders   int64
+	Canceled int64
+	// Wait goes from PENDING to IN_PREPARATION and Prep from IN_PREPARATION to FINISHED.
+	Wait Durations
+	Prep Durations
+}
+
+type Summary struct {
+	Range
+	Orders           int64     `json:"orders"`
+	Canceled         int64     `json:"canceled"`
+	CancellationRate float64   `json:"cancellation_rate"`
+	Revenue          int64     `json:"revenue"`
+	Wait             Durations `json:"wait"`
+	Prep             Durations `json:"prep"`
+}
+
+// DailySummary is the summary of a branch's local day, snapshotted by the end-of-day close so it
+// survives the orders being archived. Day is the local date, e.g. "2026-10-19".
+type DailySummary struct {
+	BranchID string `json:"branch_id"`
+	Day      string `json:"day"`
+	Summary
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// DayFormat is how days are written in daily summaries.
+const DayFormat = time.DateOnly
+
+// Day is the range of the local day that starts at startOfDay. Days are built from the calendar
+// so they last 23 or 25 hours when the clocks change.
+func Day(startOfDay time.Time) Range {
+	return Range{From: startOfDay, To: startOfDay.AddDate(0, 0, 1)}
+}
+
+// ItemSales is how many portions of a menu item were sold and what they brought in, in minor
+// units. Canceled orders don't count as sales.
+type ItemSales struct {
+	Item     string `json:"item"`
+	Quantity int64  `json:"quantity"`
+	Revenue  int64  `json:"revenue"`
+}
+
+// CancellationRate is the share of orders that were canceled, 0 when there are no orders.
+func CancellationRate(orders, canceled int64) float64 {
+	if orders == 0 {
+		return 0
+	}
+
+	return float64(canceled) / float64(orders)
+}
+
+// Price fills the revenue of every item from the menu prices and returns the total. Items no
+// longer on the menu bring in nothing.
+func Price(items []ItemSales, prices map[string]int64) int64 {
+	var total int64
+	for i := range items {
+		items[i].Revenue = items[i].Quantity * prices[items[i].Item]
+		total += items[i].Revenue
+	}
+
+	return total
+}
+
+// Top sorts the items by quantity sold, best sellers first, and keeps the first limit of them.
+// A limit of 0 or less keeps them all.
+func Top(items []ItemSales, limit int) []ItemSales {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Quantity != items[j].Quantity {
+			return items[i].Quantity > items[j].Quantity
+		}
+		return items[i].Item < items[j].Item
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items
+}
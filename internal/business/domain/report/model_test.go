@@ -0,0 +1,59 @@
+package report
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func TestReport(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
+
+func (s *ReportTestSuite) TestNewRange() {
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	rng, err := NewRange(from, from.Add(24*time.Hour))
+	s.Require().NoError(err)
+	s.Equal(Range{From: from, To: from.Add(24 * time.Hour)}, rng)
+
+	_, err = NewRange(from, from)
+	s.ErrorIs(err, errs.ErrInvalid)
+
+	_, err = NewRange(from, from.Add(MaxRange+time.Hour))
+	s.ErrorIs(err, errs.ErrInvalid)
+}
+
+func (s *ReportTestSuite) TestCancellationRate() {
+	s.Equal(0.25, CancellationRate(8, 2))
+	s.Zero(CancellationRate(0, 0))
+}
+
+func (s *ReportTestSuite) TestPriceAndTop() {
+	items := []ItemSales{{Item: "Agua", Quantity: 3}, {Item: "Milanesa", Quantity: 5}, {Item: "Flan", Quantity: 3}, {Item: "Retirado", Quantity: 1}}
+
+	total := Price(items, map[string]int64{"Agua": 600, "Milanesa": 2500, "Flan": 900})
+	s.Equal(int64(3*600+5*2500+3*900), total)
+
+	s.Equal([]ItemSales{
+		{Item: "Milanesa", Quantity: 5, Revenue: 12500},
+		{Item: "Agua", Quantity: 3, Revenue: 1800},
+	}, Top(items, 2))
+	s.Len(Top(items, 0), 4)
+}
+
+func (s *ReportTestSuite) TestDay() {
+	mendoza, err := time.LoadLocation("America/Argentina/Mendoza")
+	s.Require().NoError(err)
+	start := time.Date(2026, 10, 19, 0, 0, 0, 0, mendoza)
+
+	day := Day(start)
+	s.Equal(start, day.From)
+	s.Equal(time.Date(2026, 10, 20, 0, 0, 0, 0, mendoza), day.To)
+	s.Equal("2026-10-19", day.From.Format(DayFormat))
+}
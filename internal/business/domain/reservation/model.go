@@ -0,0 +1,128 @@
+package reservation
+
+import (
+	"challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/errs"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+type Reservation struct {
+	ID        string    `json:"id"`
+	BranchID  string    `json:"branch_id"`
+	TableID   string    `json:"table_id"`
+	Name      string    `json:"name"`
+	PartySize int       `json:"party_size"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Status    Status    `json:"status"`
+	// OrderIDs are the pre-orders placed for the reservation, created as SCHEDULED for StartsAt.
+	OrderIDs  []string  `json:"order_ids"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type Status string
+
+const (
+	Booked   Status = "BOOKED"
+	Seated   Status = "SEATED"
+	NoShow   Status = "NO_SHOW"
+	Canceled Status = "CANCELED"
+)
+
+// HoldingStatuses are the statuses that keep the table taken for the slot.
+var HoldingStatuses = []Status{Booked, Seated}
+
+// Holds tells whether the reservation still takes its table for the slot.
+func (r Reservation) Holds() bool {
+	return slices.Contains(HoldingStatuses, r.Status)
+}
+
+// Overlaps tells whether two slots share any time. Slots are half-open, so a reservation ending
+// at 21:00 doesn't overlap one starting at 21:00.
+func (r Reservation) Overlaps(other Reservation) bool {
+	return r.StartsAt.Before(other.EndsAt) && other.StartsAt.Before(r.EndsAt)
+}
+
+// ValidateSlot checks the reservation is for a future slot that ends after it starts.
+func ValidateSlot(r Reservation, now time.Time) error {
+	if !r.StartsAt.After(now) {
+		return errs.Invalid("invalid_slot", "starts_at must be in the future")
+	}
+	if !r.EndsAt.After(r.StartsAt) {
+		return errs.Invalid("invalid_slot", "ends_at must be after starts_at")
+	}
+
+	return nil
+}
+
+// Assign picks the table for the reservation. When the client asked for a table it is only
+// checked for capacity and overlapping reservations; otherwise the smallest free table that fits
+// the party is chosen, so large tables stay available for large parties.
+func Assign(tables []dining.Table, reservations []Reservation, r Reservation) (string, error) {
+	if len(r.TableID) > 0 {
+		idx := slices.IndexFunc(tables, func(t dining.Table) bool { return t.ID == r.TableID })
+		if idx < 0 {
+			return "", errs.NotFound("table_not_found", "table not found")
+		}
+		if err := fits(tables[idx], r); err != nil {
+			return "", err
+		}
+		if booked(reservations, r.TableID, r) {
+			return "", errs.Conflict("table_already_booked", fmt.Sprintf("table %s is already booked for that slot", r.TableID))
+		}
+
+		return r.TableID, nil
+	}
+
+	candidates := make([]dining.Table, 0, len(tables))
+	for _, t := range tables {
+		if fits(t, r) == nil {
+			candidates = append(candidates, t)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", errs.Invalid("party_exceeds_capacity", fmt.Sprintf("no table seats a party of %d", r.PartySize))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Seats < candidates[j].Seats })
+	for _, t := range candidates {
+		if !booked(reservations, t.ID, r) {
+			return t.ID, nil
+		}
+	}
+
+	return "", errs.Conflict("no_table_available", fmt.Sprintf("no table for %d is free for that slot", r.PartySize))
+}
+
+func fits(table dining.Table, r Reservation) error {
+	if r.PartySize > table.Seats {
+		return errs.Invalid("party_exceeds_capacity",
+			fmt.Sprintf("table %s seats %d, the party is %d", table.ID, table.Seats, r.PartySize))
+	}
+
+	return nil
+}
+
+func booked(reservations []Reservation, tableID string, r Reservation) bool {
+	return slices.ContainsFunc(reservations, func(other Reservation) bool {
+		return other.ID != r.ID && other.TableID == tableID && other.Holds() && other.Overlaps(r)
+	})
+}
+
+// Transition checks a booked reservation can move to the given status. Seated, no-show and
+// canceled reservations are final.
+func Transition(from, to Status) error {
+	if to != Seated && to != NoShow && to != Canceled {
+		return errs.Invalid("invalid_reservation_status",
+			fmt.Sprintf("status must be one of %s, %s, %s, got %q", Seated, NoShow, Canceled, to))
+	}
+	if from != Booked {
+		return errs.Conflict("reservation_not_booked", fmt.Sprintf("reservation is already %s", from))
+	}
+
+	return nil
+}
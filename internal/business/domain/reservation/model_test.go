@@ -0,0 +1,121 @@
+package reservation
+
+import (
+	"challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/errs"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type ReservationTestSuite struct {
+	suite.Suite
+}
+
+func TestReservation(t *testing.T) {
+	suite.Run(t, new(ReservationTestSuite))
+}
+
+func (s *ReservationTestSuite) TestOverlaps() {
+	at := time.Date(2026, 10, 20, 20, 0, 0, 0, time.UTC)
+	slot := Reservation{StartsAt: at, EndsAt: at.Add(90 * time.Minute)}
+
+	s.True(slot.Overlaps(Reservation{StartsAt: at.Add(time.Hour), EndsAt: at.Add(2 * time.Hour)}))
+	s.True(slot.Overlaps(Reservation{StartsAt: at.Add(-time.Hour), EndsAt: at.Add(3 * time.Hour)}))
+	s.False(slot.Overlaps(Reservation{StartsAt: at.Add(90 * time.Minute), EndsAt: at.Add(3 * time.Hour)}))
+	s.False(slot.Overlaps(Reservation{StartsAt: at.Add(-time.Hour), EndsAt: at}))
+}
+
+func (s *ReservationTestSuite) TestAssign() {
+	at := time.Date(2026, 10, 20, 20, 0, 0, 0, time.UTC)
+	tables := []dining.Table{{ID: "1", Seats: 6}, {ID: "2", Seats: 2}, {ID: "3", Seats: 4}}
+	reservations := []Reservation{
+		{ID: "r1", TableID: "3", StartsAt: at, EndsAt: at.Add(time.Hour), Status: Booked},
+		{ID: "r2", TableID: "2", StartsAt: at, EndsAt: at.Add(time.Hour), Status: NoShow},
+	}
+
+	var tests = []struct {
+		name            string
+		reservation     Reservation
+		expectedTableID string
+		expectedError   error
+	}{
+		{
+			name:            "smallest_free_table",
+			reservation:     Reservation{PartySize: 2, StartsAt: at, EndsAt: at.Add(time.Hour)},
+			expectedTableID: "2",
+		},
+		{
+			name:            "skips_booked_table",
+			reservation:     Reservation{PartySize: 3, StartsAt: at.Add(30 * time.Minute), EndsAt: at.Add(2 * time.Hour)},
+			expectedTableID: "1",
+		},
+		{
+			name:            "free_after_previous_slot",
+			reservation:     Reservation{PartySize: 3, StartsAt: at.Add(time.Hour), EndsAt: at.Add(2 * time.Hour)},
+			expectedTableID: "3",
+		},
+		{
+			name:            "requested_table",
+			reservation:     Reservation{TableID: "1", PartySize: 2, StartsAt: at, EndsAt: at.Add(time.Hour)},
+			expectedTableID: "1",
+		},
+		{
+			name:          "error_requested_table_booked",
+			reservation:   Reservation{TableID: "3", PartySize: 2, StartsAt: at, EndsAt: at.Add(time.Hour)},
+			expectedError: errs.ErrConflict,
+		},
+		{
+			name:          "error_requested_table_too_small",
+			reservation:   Reservation{TableID: "2", PartySize: 4, StartsAt: at, EndsAt: at.Add(time.Hour)},
+			expectedError: errs.ErrInvalid,
+		},
+		{
+			name:          "error_unknown_table",
+			reservation:   Reservation{TableID: "9", PartySize: 2, StartsAt: at, EndsAt: at.Add(time.Hour)},
+			expectedError: errs.ErrNotFound,
+		},
+		{
+			name:          "error_party_too_large",
+			reservation:   Reservation{PartySize: 8, StartsAt: at, EndsAt: at.Add(time.Hour)},
+			expectedError: errs.ErrInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			tableID, err := Assign(tables, reservations, tt.reservation)
+
+			if tt.expectedError != nil {
+				s.ErrorIs(err, tt.expectedError)
+				return
+			}
+
+			s.Require().NoError(err)
+			s.Equal(tt.expectedTableID, tableID)
+		})
+	}
+
+	s.Run("error_no_table_available", func() {
+		full := append(reservations, Reservation{ID: "r3", TableID: "1", StartsAt: at, EndsAt: at.Add(time.Hour), Status: Seated})
+		_, err := Assign(tables, full, Reservation{PartySize: 4, StartsAt: at, EndsAt: at.Add(time.Hour)})
+		s.ErrorIs(err, errs.ErrConflict)
+	})
+}
+
+func (s *ReservationTestSuite) TestValidateSlot() {
+	now := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	s.NoError(ValidateSlot(Reservation{StartsAt: now.Add(time.Hour), EndsAt: now.Add(2 * time.Hour)}, now))
+	s.ErrorIs(ValidateSlot(Reservation{StartsAt: now.Add(-time.Hour), EndsAt: now.Add(time.Hour)}, now), errs.ErrInvalid)
+	s.ErrorIs(ValidateSlot(Reservation{StartsAt: now.Add(time.Hour), EndsAt: now.Add(time.Hour)}, now), errs.ErrInvalid)
+}
+
+func (s *ReservationTestSuite) TestTransition() {
+	s.NoError(Transition(Booked, Seated))
+	s.NoError(Transition(Booked, NoShow))
+	s.NoError(Transition(Booked, Canceled))
+	s.ErrorIs(Transition(Booked, Booked), errs.ErrInvalid)
+	s.ErrorIs(Transition(Seated, NoShow), errs.ErrConflict)
+	s.ErrorIs(Transition(Canceled, Seated), errs.ErrConflict)
+}
@@ -0,0 +1,24 @@
+package tenant
+
+import "time"
+
+// Branch identifies the restaurant branch a request is scoped to. Every order read or write is
+// done on behalf of exactly one branch.
+type Branch struct {
+	ID       string
+	Location *time.Location
+}
+
+// StartOfDay returns the midnight of the branch's local day that contains t.
+func (b Branch) StartOfDay(t time.Time) time.Time {
+	local := t.In(b.location())
+	year, month, day := local.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, b.location())
+}
+
+func (b Branch) location() *time.Location {
+	if b.Location == nil {
+		return time.UTC
+	}
+	return b.Location
+}
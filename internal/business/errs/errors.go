@@ -0,0 +1,69 @@
+package errs
+
+import "errors"
+
+// Kinds of failure the business layer reports. Check them with errors.Is, e.g.
+// errors.Is(err, errs.ErrNotFound); the transport decides how each one is presented.
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrConflict    = errors.New("conflict")
+	ErrInvalid     = errors.New("invalid")
+	ErrUnavailable = errors.New("unavailable")
+	// ErrStale reports a write based on a version of a resource that is no longer current.
+	ErrStale = errors.New("stale")
+)
+
+// Error is a failure of a known kind. Code is a stable identifier clients can rely on, e.g.
+// "order_not_found", while Message is meant for humans and may change.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func NotFound(code, message string) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func Invalid(code, message string) *Error {
+	return &Error{Kind: ErrInvalid, Code: code, Message: message}
+}
+
+func Stale(code, message string) *Error {
+	return &Error{Kind: ErrStale, Code: code, Message: message}
+}
+
+// Unavailable reports a dependency that couldn't answer in time or at all; cause is kept so
+// callers can still inspect it, e.g. errors.Is(err, context.DeadlineExceeded).
+func Unavailable(code, message string, cause error) *Error {
+	return &Error{Kind: ErrUnavailable, Code: code, Message: message, Err: cause}
+}
+
+// As returns the *Error in err's chain, if any.
+func As(err error) (*Error, bool) {
+	var e *Error
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
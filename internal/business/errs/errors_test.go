@@ -0,0 +1,44 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrors(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (s *ErrorsTestSuite) TestIs() {
+	err := fmt.Errorf("getting order: %w", NotFound("order_not_found", "order not found"))
+
+	s.True(errors.Is(err, ErrNotFound))
+	s.False(errors.Is(err, ErrConflict))
+	s.False(errors.Is(err, ErrInvalid))
+	s.False(errors.Is(err, ErrUnavailable))
+	s.False(errors.Is(err, ErrStale))
+}
+
+func (s *ErrorsTestSuite) TestUnavailableKeepsCause() {
+	err := Unavailable("database_timeout", "order wasn't created: database timeout", context.DeadlineExceeded)
+
+	s.True(errors.Is(err, ErrUnavailable))
+	s.True(errors.Is(err, context.DeadlineExceeded))
+	s.Equal("order wasn't created: database timeout: context deadline exceeded", err.Error())
+}
+
+func (s *ErrorsTestSuite) TestAs() {
+	e, ok := As(fmt.Errorf("wrapped: %w", Conflict("order_already_exists", "order already added")))
+	s.Require().True(ok)
+	s.Equal("order_already_exists", e.Code)
+
+	_, ok = As(errors.New("plain"))
+	s.False(ok)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"time"
+)
+
+type OrderMetrics interface {
+	OrderCreated(order *model.Order)
+	OrderPrepared(order *model.Order, duration time.Duration)
+	OverdueOrders(status model.Status, countByBranch map[string]int)
+}
@@ -1,7 +1,10 @@
 package interfaces
 
-import "challenge-yuno/internal/business/domain/order"
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"context"
+)
 
 type INotificationService interface {
-	SendNotification(order *order.Order)
+	SendNotification(ctx context.Context, order *order.Order) error
 }
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"context"
+)
+
+// QueueBroadcaster shares the kitchen queue of a branch, keyed by branch ID, with the screens
+// following it.
+type QueueBroadcaster interface {
+	Publish(branchID string, queue []model.Order)
+	Subscribe(ctx context.Context, branchID string) <-chan []model.Order
+}
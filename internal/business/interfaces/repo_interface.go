@@ -1,19 +1,118 @@
 package interfaces
 
-import model "challenge-yuno/internal/business/domain/order"
+import (
+	"challenge-yuno/internal/business/domain/audit"
+	"challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/domain/inventory"
+	"challenge-yuno/internal/business/domain/menu"
+	"challenge-yuno/internal/business/domain/notification"
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/report"
+	"challenge-yuno/internal/business/domain/reservation"
+	"challenge-yuno/internal/business/domain/tenant"
+	"context"
+	"time"
+)
 
 type KVSOrderRepository interface {
-	AddOrder(order model.Order) (*model.Order, error)
-	GetOrder(orderID string) (*model.Order, error)
-	ListActiveOrders() ([]model.Order, error)
-	UpdateOrderStatus(orderID string, status model.Status) (*model.Order, error)
-	GetAllOrders() []model.Order
+	AddOrder(ctx context.Context, branch tenant.Branch, order model.Order) (*model.Order, error)
+	GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (*model.Order, error)
+	ListActiveOrders(ctx context.Context, branch tenant.Branch) ([]model.Order, error)
+	UpdateOrderStatus(ctx context.Context, branch tenant.Branch, update model.StatusUpdate) (*model.Order, error)
+	GetAllOrders(ctx context.Context, branch tenant.Branch) []model.Order
 }
 
 type SQLOrderRepository interface {
-	AddOrder(order model.Order) (*model.Order, error)
-	GetOrder(orderID string) (*model.Order, error)
-	ListActiveOrders() ([]model.Order, error)
-	UpdateOrder(orderID string, status model.Status, priority *int) (*model.Order, error)
-	GetAllOrders() ([]model.Order, error)
+	AddOrder(ctx context.Context, branch tenant.Branch, order model.Order) (*model.Order, error)
+	GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (*model.Order, error)
+	ListActiveOrders(ctx context.Context, branch tenant.Branch) ([]model.Order, error)
+	UpdateOrder(ctx context.Context, branch tenant.Branch, update model.StatusUpdate) (*model.Order, error)
+	GetAllOrders(ctx context.Context, branch tenant.Branch, filter model.Filter) ([]model.Order, error)
+	StreamOrders(ctx context.Context, branch tenant.Branch, filter model.Filter, fn func(model.Order) error) error
+	ListOverdueOrders(ctx context.Context, status model.Status, updatedBefore time.Time) ([]model.Order, error)
+	ArchiveOrders(ctx context.Context, statuses []model.Status, closedBefore time.Time) (int64, error)
+	ImportOrder(ctx context.Context, branch tenant.Branch, order model.Order) (*model.Order, error)
+	AddOrders(ctx context.Context, branch tenant.Branch, orders []model.Order, mode model.BatchMode) ([]model.BatchResult, error)
+	ImportOrders(ctx context.Context, branch tenant.Branch, orders []model.Order, mode model.BatchMode) ([]model.BatchResult, error)
+	UpdateOrders(ctx context.Context, branch tenant.Branch, updates []model.StatusUpdate, mode model.BatchMode) ([]model.BatchResult, error)
+	MoveOrder(ctx context.Context, branch tenant.Branch, move model.Move) ([]model.Order, error)
+	EditOrder(ctx context.Context, branch tenant.Branch, order model.Order, revision model.Revision) (*model.Order, error)
+	ListRevisions(ctx context.Context, branch tenant.Branch, orderID string) ([]model.Revision, error)
+	ListScheduledOrders(ctx context.Context, branch tenant.Branch) ([]model.Order, error)
+	PromoteScheduledOrders(ctx context.Context, orderType model.OrderType, dueBefore time.Time) ([]model.Order, error)
+}
+
+// Repositories are the repositories a UnitOfWork gives to its function, bound to its transaction.
+type Repositories struct {
+	Orders       SQLOrderRepository
+	Inventory    InventoryRepository
+	Outbox       NotificationOutbox
+	Dining       DiningRepository
+	Reservations ReservationRepository
+}
+
+// UnitOfWork runs multi-step changes atomically: what fn writes through repos is committed when
+// it returns nil and rolled back when it returns an error, which Do returns.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(repos Repositories) error) error
+}
+
+type BranchRepository interface {
+	GetBranch(branchID string) (*tenant.Branch, error)
+	ListBranches() []tenant.Branch
+}
+
+type MenuRepository interface {
+	ListItems() []menu.Item
+}
+
+type DiningRepository interface {
+	AddTable(ctx context.Context, branch tenant.Branch, table dining.Table) (*dining.Table, error)
+	GetTable(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Table, error)
+	ListTables(ctx context.Context, branch tenant.Branch) ([]dining.Table, error)
+	OpenSession(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Session, error)
+	GetOpenSession(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Session, error)
+	AttachOrder(ctx context.Context, branch tenant.Branch, sessionID, orderID string) error
+	ListSessionOrders(ctx context.Context, branch tenant.Branch, sessionID string) ([]model.Order, error)
+	CloseSession(ctx context.Context, branch tenant.Branch, sessionID string) (*dining.Session, error)
+}
+
+type InventoryRepository interface {
+	SetIngredient(ctx context.Context, branch tenant.Branch, ingredient inventory.Ingredient) (*inventory.Ingredient, error)
+	ListIngredients(ctx context.Context, branch tenant.Branch) ([]inventory.Ingredient, error)
+	Stock(ctx context.Context, branch tenant.Branch, ingredients ...string) (map[string]int64, error)
+	SetRecipe(ctx context.Context, recipe inventory.Recipe) (*inventory.Recipe, error)
+	ListRecipes(ctx context.Context, items ...string) ([]inventory.Recipe, error)
+	Consume(ctx context.Context, branch tenant.Branch, required map[string]int64) error
+	Restore(ctx context.Context, branch tenant.Branch, required map[string]int64) error
+}
+
+type ReservationRepository interface {
+	AddReservation(ctx context.Context, branch tenant.Branch, reservation reservation.Reservation) (*reservation.Reservation, error)
+	GetReservation(ctx context.Context, branch tenant.Branch, reservationID string) (*reservation.Reservation, error)
+	ListReservations(ctx context.Context, branch tenant.Branch, from, to time.Time) ([]reservation.Reservation, error)
+	UpdateReservationStatus(ctx context.Context, branch tenant.Branch, reservationID string, from, to reservation.Status) (*reservation.Reservation, error)
+	AttachOrder(ctx context.Context, branch tenant.Branch, reservationID, orderID string) error
+}
+
+type ReportRepository interface {
+	CountOrders(ctx context.Context, branch tenant.Branch, rng report.Range, dimension report.Dimension) ([]report.Count, error)
+	Totals(ctx context.Context, branch tenant.Branch, rng report.Range) (*report.Totals, error)
+	ItemSales(ctx context.Context, branch tenant.Branch, rng report.Range) ([]report.ItemSales, error)
+	SaveDailySummary(ctx context.Context, summary report.DailySummary) error
+	ListDailySummaries(ctx context.Context, branch tenant.Branch, rng report.Range) ([]report.DailySummary, error)
+}
+
+type AuditRepository interface {
+	AddEntry(ctx context.Context, branch tenant.Branch, entry audit.Entry) (*audit.Entry, error)
+	ListEntries(ctx context.Context, branch tenant.Branch, filter audit.Filter) ([]audit.Entry, error)
+	StreamEntries(ctx context.Context, branch tenant.Branch, filter audit.Filter, fn func(audit.Entry) error) error
+}
+
+type NotificationOutbox interface {
+	Enqueue(ctx context.Context, branch tenant.Branch, order *model.Order) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]notification.Message, error)
+	MarkSent(ctx context.Context, messageID string) error
+	MarkFailed(ctx context.Context, messageID string, attempts int, nextAttemptAt *time.Time, cause error) error
+	Backlog(ctx context.Context) (int, error)
 }
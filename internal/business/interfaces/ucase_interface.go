@@ -1,11 +1,73 @@
 package interfaces
 
-import model "challenge-yuno/internal/business/domain/order"
+import (
+	"challenge-yuno/internal/business/domain/audit"
+	"challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/domain/inventory"
+	"challenge-yuno/internal/business/domain/menu"
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/report"
+	"challenge-yuno/internal/business/domain/reservation"
+	"challenge-yuno/internal/business/domain/tenant"
+	"context"
+	"time"
+)
 
 type OrderUsecase interface {
-	AddOrder(order model.Order) (*model.Order, error)
-	GetOrder(orderID string) (*model.Order, error)
-	ListActiveOrders() ([]model.Order, error)
-	UpdateOrder(orderID string, status model.Status, priority *int) (*model.Order, error)
-	GetAllOrders() ([]model.Order, error)
+	AddOrder(ctx context.Context, branch tenant.Branch, order model.Order) (*model.Order, error)
+	AddOrderWith(ctx context.Context, branch tenant.Branch, order model.Order, fn func(repos Repositories, created *model.Order) error) (*model.Order, error)
+	GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (*model.Order, error)
+	ListActiveOrders(ctx context.Context, branch tenant.Branch) ([]model.Order, error)
+	UpdateOrder(ctx context.Context, branch tenant.Branch, update model.StatusUpdate) (*model.Order, error)
+	GetAllOrders(ctx context.Context, branch tenant.Branch, filter model.Filter) ([]model.Order, error)
+	ExportOrders(ctx context.Context, branch tenant.Branch, filter model.Filter, fn func(model.Order) error) error
+	CheckImport(order model.Order) error
+	ImportOrder(ctx context.Context, branch tenant.Branch, order model.Order) (*model.Order, error)
+	AddOrders(ctx context.Context, branch tenant.Branch, orders []model.Order, mode model.BatchMode) ([]model.BatchResult, error)
+	ImportOrders(ctx context.Context, branch tenant.Branch, orders []model.Order, mode model.BatchMode) ([]model.BatchResult, error)
+	UpdateOrders(ctx context.Context, branch tenant.Branch, updates []model.StatusUpdate, mode model.BatchMode) ([]model.BatchResult, error)
+	ListScheduledOrders(ctx context.Context, branch tenant.Branch) ([]model.Order, error)
+	MoveOrder(ctx context.Context, branch tenant.Branch, move model.Move) ([]model.Order, error)
+	WatchQueue(ctx context.Context, branch tenant.Branch) <-chan []model.Order
+	EditOrder(ctx context.Context, branch tenant.Branch, edit model.Edit) (*model.Order, *model.Quote, error)
+	ListRevisions(ctx context.Context, branch tenant.Branch, orderID string) ([]model.Revision, error)
+}
+
+type AuditUsecase interface {
+	Record(ctx context.Context, branch tenant.Branch, entry audit.Entry, before, after *model.Order) error
+	ListEntries(ctx context.Context, branch tenant.Branch, filter audit.Filter) ([]audit.Entry, error)
+	ExportEntries(ctx context.Context, branch tenant.Branch, filter audit.Filter, fn func(audit.Entry) error) error
+}
+
+type DiningUsecase interface {
+	AddTable(ctx context.Context, branch tenant.Branch, table dining.Table) (*dining.Table, error)
+	ListTables(ctx context.Context, branch tenant.Branch) ([]dining.Table, error)
+	OpenSession(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Session, error)
+	AttachOrder(ctx context.Context, branch tenant.Branch, tableID, orderID string) (*dining.Session, error)
+	ListTableOrders(ctx context.Context, branch tenant.Branch, tableID string) ([]model.Order, error)
+	GetBill(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Bill, error)
+	CloseSession(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Bill, error)
+}
+
+type ReservationUsecase interface {
+	AddReservation(ctx context.Context, branch tenant.Branch, reservation reservation.Reservation) (*reservation.Reservation, error)
+	GetReservation(ctx context.Context, branch tenant.Branch, reservationID string) (*reservation.Reservation, error)
+	ListReservations(ctx context.Context, branch tenant.Branch, from, to time.Time) ([]reservation.Reservation, error)
+	UpdateStatus(ctx context.Context, branch tenant.Branch, reservationID string, status reservation.Status) (*reservation.Reservation, error)
+	AddOrder(ctx context.Context, branch tenant.Branch, reservationID string, order model.Order) (*model.Order, error)
+}
+
+type InventoryUsecase interface {
+	SetIngredient(ctx context.Context, branch tenant.Branch, ingredient inventory.Ingredient) (*inventory.Ingredient, error)
+	ListIngredients(ctx context.Context, branch tenant.Branch) ([]inventory.Ingredient, error)
+	SetRecipe(ctx context.Context, recipe inventory.Recipe) (*inventory.Recipe, error)
+	ListRecipes(ctx context.Context) ([]inventory.Recipe, error)
+	ListMenu(ctx context.Context, branch tenant.Branch) ([]menu.Item, error)
+}
+
+type ReportUsecase interface {
+	CountOrders(ctx context.Context, branch tenant.Branch, rng report.Range, dimension report.Dimension) ([]report.Count, error)
+	Summary(ctx context.Context, branch tenant.Branch, rng report.Range) (*report.Summary, error)
+	TopItems(ctx context.Context, branch tenant.Branch, rng report.Range, limit int) ([]report.ItemSales, error)
+	ListDailySummaries(ctx context.Context, branch tenant.Branch, rng report.Range) ([]report.DailySummary, error)
 }
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	model "challenge-yuno/internal/business/domain/audit"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/interfaces"
+	"context"
+)
+
+type AuditUsecase struct {
+	AuditRepository interfaces.AuditRepository
+}
+
+func NewAuditUsecase(auditRepository interfaces.AuditRepository) *AuditUsecase {
+	return &AuditUsecase{
+		AuditRepository: auditRepository,
+	}
+}
+
+func (u *AuditUsecase) Record(ctx context.Context, branch tenant.Branch, entry model.Entry, before, after *order.Order) error {
+	entry.Before = before
+	entry.After = after
+	entry.Changes = model.Diff(before, after)
+
+	_, err := u.AuditRepository.AddEntry(ctx, branch, entry)
+	return err
+}
+
+func (u *AuditUsecase) ListEntries(ctx context.Context, branch tenant.Branch, filter model.Filter) ([]model.Entry, error) {
+	return u.AuditRepository.ListEntries(ctx, branch, filter)
+}
+
+func (u *AuditUsecase) ExportEntries(ctx context.Context, branch tenant.Branch, filter model.Filter, fn func(model.Entry) error) error {
+	return u.AuditRepository.StreamEntries(ctx, branch, filter, fn)
+}
@@ -0,0 +1,164 @@
+package dining
+
+import (
+	model "challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/domain/menu"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+)
+
+var tracer = otel.Tracer("challenge-yuno/internal/business/usecases/dining")
+
+type DiningUsecase struct {
+	DiningRepository   interfaces.DiningRepository
+	SQLOrderRepository interfaces.SQLOrderRepository
+	MenuRepository     interfaces.MenuRepository
+	Logger             *slog.Logger
+}
+
+func NewDiningUsecase(diningRepository interfaces.DiningRepository, sqlOrderRepository interfaces.SQLOrderRepository,
+	menuRepository interfaces.MenuRepository, logger *slog.Logger) *DiningUsecase {
+	return &DiningUsecase{
+		DiningRepository:   diningRepository,
+		SQLOrderRepository: sqlOrderRepository,
+		MenuRepository:     menuRepository,
+		Logger:             logging.Named(logger, "usecases"),
+	}
+}
+
+func (u *DiningUsecase) AddTable(ctx context.Context, branch tenant.Branch, table model.Table) (_ *model.Table, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.AddTable", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.DiningRepository.AddTable(ctx, branch, table)
+}
+
+func (u *DiningUsecase) ListTables(ctx context.Context, branch tenant.Branch) (_ []model.Table, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.ListTables", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.DiningRepository.ListTables(ctx, branch)
+}
+
+func (u *DiningUsecase) OpenSession(ctx context.Context, branch tenant.Branch, tableID string) (_ *model.Session, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.OpenSession", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("table.id", tableID)))
+	defer func() { tracing.End(span, err) }()
+
+	if _, err = u.DiningRepository.GetTable(ctx, branch, tableID); err != nil {
+		return nil, err
+	}
+
+	session, err := u.DiningRepository.OpenSession(ctx, branch, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(ctx, "dine-in session opened", slog.String("table_id", tableID), slog.String("session_id", session.ID))
+
+	return session, nil
+}
+
+// AttachOrder adds a round to the session seated at the table. Only IN_PERSON orders belong to a
+// table; phone and delivery orders are rejected.
+func (u *DiningUsecase) AttachOrder(ctx context.Context, branch tenant.Branch, tableID, orderID string) (_ *model.Session, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.AttachOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("table.id", tableID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.WithOrderID(ctx, orderID)
+
+	session, err := u.DiningRepository.GetOpenSession(ctx, branch, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := u.SQLOrderRepository.GetOrder(ctx, branch, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if o.Source != order.InPerson {
+		return nil, errs.Invalid("order_not_in_person", "only IN_PERSON orders can be attached to a table")
+	}
+
+	if err = u.DiningRepository.AttachOrder(ctx, branch, session.ID, orderID); err != nil {
+		return nil, err
+	}
+
+	session.OrderIDs = append(session.OrderIDs, orderID)
+	u.Logger.InfoContext(ctx, "order attached to session", slog.String("table_id", tableID), slog.String("session_id", session.ID))
+
+	return session, nil
+}
+
+func (u *DiningUsecase) ListTableOrders(ctx context.Context, branch tenant.Branch, tableID string) (_ []order.Order, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.ListTableOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("table.id", tableID)))
+	defer func() { tracing.End(span, err) }()
+
+	session, err := u.DiningRepository.GetOpenSession(ctx, branch, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.DiningRepository.ListSessionOrders(ctx, branch, session.ID)
+}
+
+func (u *DiningUsecase) GetBill(ctx context.Context, branch tenant.Branch, tableID string) (_ *model.Bill, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.GetBill", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("table.id", tableID)))
+	defer func() { tracing.End(span, err) }()
+
+	session, err := u.DiningRepository.GetOpenSession(ctx, branch, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := u.DiningRepository.ListSessionOrders(ctx, branch, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bill := model.NewBill(*session, orders, menu.Prices(u.MenuRepository.ListItems()))
+	return &bill, nil
+}
+
+// CloseSession frees the table once every order of the session was delivered or canceled, and
+// returns the final bill. The repository checks the orders with the session row locked, the lock
+// attaching an order takes too, and the bill is read afterwards, when no more orders can be
+// attached.
+func (u *DiningUsecase) CloseSession(ctx context.Context, branch tenant.Branch, tableID string) (_ *model.Bill, err error) {
+	ctx, span := tracer.Start(ctx, "DiningUsecase.CloseSession", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("table.id", tableID)))
+	defer func() { tracing.End(span, err) }()
+
+	session, err := u.DiningRepository.GetOpenSession(ctx, branch, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	closed, err := u.DiningRepository.CloseSession(ctx, branch, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := u.DiningRepository.ListSessionOrders(ctx, branch, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	bill := model.NewBill(*closed, orders, menu.Prices(u.MenuRepository.ListItems()))
+	u.Logger.InfoContext(ctx, "dine-in session closed", slog.String("table_id", tableID),
+		slog.String("session_id", session.ID), slog.Int64("total", bill.Total))
+
+	return &bill, nil
+}
@@ -0,0 +1,113 @@
+package inventory
+
+import (
+	model "challenge-yuno/internal/business/domain/inventory"
+	"challenge-yuno/internal/business/domain/menu"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+)
+
+var tracer = otel.Tracer("challenge-yuno/internal/business/usecases/inventory")
+
+type InventoryUsecase struct {
+	InventoryRepository interfaces.InventoryRepository
+	MenuRepository      interfaces.MenuRepository
+	Logger              *slog.Logger
+}
+
+func NewInventoryUsecase(inventoryRepository interfaces.InventoryRepository, menuRepository interfaces.MenuRepository,
+	logger *slog.Logger) *InventoryUsecase {
+	return &InventoryUsecase{
+		InventoryRepository: inventoryRepository,
+		MenuRepository:      menuRepository,
+		Logger:              logging.Named(logger, "usecases"),
+	}
+}
+
+func (u *InventoryUsecase) SetIngredient(ctx context.Context, branch tenant.Branch, ingredient model.Ingredient) (_ *model.Ingredient, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryUsecase.SetIngredient", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	saved, err := u.InventoryRepository.SetIngredient(ctx, branch, ingredient)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(ctx, "ingredient stock set", slog.String("ingredient", saved.Name), slog.Int64("stock", saved.Stock))
+
+	return saved, nil
+}
+
+func (u *InventoryUsecase) ListIngredients(ctx context.Context, branch tenant.Branch) (_ []model.Ingredient, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryUsecase.ListIngredients", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.InventoryRepository.ListIngredients(ctx, branch)
+}
+
+func (u *InventoryUsecase) SetRecipe(ctx context.Context, recipe model.Recipe) (_ *model.Recipe, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryUsecase.SetRecipe", trace.WithAttributes(attribute.String("menu.item", recipe.Item)))
+	defer func() { tracing.End(span, err) }()
+
+	if _, listed := menu.Prices(u.MenuRepository.ListItems())[recipe.Item]; !listed {
+		return nil, errs.NotFound("item_not_found", "item is not on the menu")
+	}
+
+	return u.InventoryRepository.SetRecipe(ctx, recipe)
+}
+
+func (u *InventoryUsecase) ListRecipes(ctx context.Context) (_ []model.Recipe, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryUsecase.ListRecipes")
+	defer func() { tracing.End(span, err) }()
+
+	recipes, err := u.InventoryRepository.ListRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(recipes) == 0 {
+		return nil, errs.NotFound("no_recipes", "there is no recipes")
+	}
+
+	return recipes, nil
+}
+
+// ListMenu returns the menu with each item flagged by whether the branch has the stock to
+// prepare a portion of it. Availability is worked out from the current stock, so an item comes
+// back on its own once the ingredient is restocked.
+func (u *InventoryUsecase) ListMenu(ctx context.Context, branch tenant.Branch) (_ []menu.Item, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryUsecase.ListMenu", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	items := u.MenuRepository.ListItems()
+	if len(items) == 0 {
+		return nil, errs.NotFound("no_menu", "there is no menu items")
+	}
+
+	recipes, err := u.InventoryRepository.ListRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := u.InventoryRepository.Stock(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	byItem := make(map[string]model.Recipe, len(recipes))
+	for _, recipe := range recipes {
+		byItem[recipe.Item] = recipe
+	}
+
+	for i := range items {
+		items[i].Available = model.Available(byItem[items[i].Name], stock)
+	}
+
+	return items, nil
+}
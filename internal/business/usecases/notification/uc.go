@@ -0,0 +1,77 @@
+package notification
+
+import (
+	model "challenge-yuno/internal/business/domain/notification"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"go.opentelemetry.io/otel"
+	"log/slog"
+	"time"
+)
+
+const (
+	dispatchBatchSize = 50
+	// claimLease hides claimed messages from other dispatchers while they are being sent.
+	claimLease = time.Minute
+)
+
+var tracer = otel.Tracer("challenge-yuno/internal/business/usecases/notification")
+
+type NotificationUsecase struct {
+	NotificationOutbox  interfaces.NotificationOutbox
+	NotificationService interfaces.INotificationService
+	Logger              *slog.Logger
+}
+
+func NewNotificationUsecase(notificationOutbox interfaces.NotificationOutbox,
+	notificationService interfaces.INotificationService, logger *slog.Logger) *NotificationUsecase {
+	return &NotificationUsecase{
+		NotificationOutbox:  notificationOutbox,
+		NotificationService: notificationService,
+		Logger:              logging.Named(logger, "usecases"),
+	}
+}
+
+// DispatchPending delivers the notifications due in the outbox. Failed deliveries are retried with
+// exponential backoff until model.MaxAttempts is reached.
+func (u *NotificationUsecase) DispatchPending(ctx context.Context) (err error) {
+	ctx, span := tracer.Start(ctx, "NotificationUsecase.DispatchPending")
+	defer func() { tracing.End(span, err) }()
+
+	messages, err := u.NotificationOutbox.Claim(ctx, dispatchBatchSize, claimLease)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		msgCtx := logging.WithOrderID(logging.WithTenant(ctx, message.BranchID), message.OrderID)
+
+		sendErr := u.NotificationService.SendNotification(msgCtx, &message.Order)
+		if sendErr == nil {
+			if err = u.NotificationOutbox.MarkSent(msgCtx, message.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		attempts := message.Attempts + 1
+		var nextAttemptAt *time.Time
+		if attempts < model.MaxAttempts {
+			next := time.Now().Add(model.RetryDelay(attempts))
+			nextAttemptAt = &next
+			u.Logger.WarnContext(msgCtx, "error sending notification, will retry",
+				slog.Int("attempts", attempts), slog.Time("next_attempt_at", next), slog.Any("error", sendErr))
+		} else {
+			u.Logger.ErrorContext(msgCtx, "error sending notification, giving up",
+				slog.Int("attempts", attempts), slog.Any("error", sendErr))
+		}
+
+		if err = u.NotificationOutbox.MarkFailed(msgCtx, message.ID, attempts, nextAttemptAt, sendErr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package notification
+
+import (
+	model "challenge-yuno/internal/business/domain/notification"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/mocks"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+type NotificationUsecaseTestSuite struct {
+	suite.Suite
+	outbox  *mocks.MockNotificationOutbox
+	service *mocks.MockNotificationService
+	usecase *NotificationUsecase
+}
+
+func (s *NotificationUsecaseTestSuite) SetupTest() {
+	s.outbox = new(mocks.MockNotificationOutbox)
+	s.service = new(mocks.MockNotificationService)
+	s.usecase = NewNotificationUsecase(s.outbox, s.service, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+}
+
+func TestNotificationUsecase(t *testing.T) {
+	suite.Run(t, new(NotificationUsecaseTestSuite))
+}
+
+func (s *NotificationUsecaseTestSuite) TestDispatchPending() {
+	sent := model.Message{ID: "1", BranchID: "centro", OrderID: "123", Order: order.Order{ID: "123"}}
+	retried := model.Message{ID: "2", BranchID: "centro", OrderID: "456", Order: order.Order{ID: "456"}, Attempts: 2}
+	exhausted := model.Message{ID: "3", BranchID: "centro", OrderID: "789", Order: order.Order{ID: "789"}, Attempts: model.MaxAttempts - 1}
+
+	s.outbox.On("Claim", mock.Anything, dispatchBatchSize, claimLease).Return([]model.Message{sent, retried, exhausted}, nil)
+	s.service.On("SendNotification", mock.Anything, &sent.Order).Return(nil)
+	s.service.On("SendNotification", mock.Anything, &retried.Order).Return(fmt.Errorf("webhook down"))
+	s.service.On("SendNotification", mock.Anything, &exhausted.Order).Return(fmt.Errorf("webhook down"))
+	s.outbox.On("MarkSent", mock.Anything, "1").Return(nil)
+	s.outbox.On("MarkFailed", mock.Anything, "2", 3, mock.MatchedBy(func(next *time.Time) bool {
+		return next != nil && time.Until(*next) > model.RetryDelay(3)-time.Second
+	}), mock.Anything).Return(nil)
+	s.outbox.On("MarkFailed", mock.Anything, "3", model.MaxAttempts, (*time.Time)(nil), mock.Anything).Return(nil)
+
+	s.Require().NoError(s.usecase.DispatchPending(context.Background()))
+	s.outbox.AssertExpectations(s.T())
+	s.service.AssertExpectations(s.T())
+}
+
+func (s *NotificationUsecaseTestSuite) TestDispatchPendingClaimError() {
+	s.outbox.On("Claim", mock.Anything, dispatchBatchSize, claimLease).Return(nil, fmt.Errorf("mock error"))
+
+	s.Require().Error(s.usecase.DispatchPending(context.Background()))
+	s.service.AssertNotCalled(s.T(), "SendNotification", mock.Anything, mock.Anything)
+}
+
+func (s *NotificationUsecaseTestSuite) TestRetryDelay() {
+	s.Equal(10*time.Second, model.RetryDelay(1))
+	s.Equal(40*time.Second, model.RetryDelay(3))
+	s.Equal(time.Hour, model.RetryDelay(20))
+}
@@ -1,50 +1,733 @@
 package order
 
 import (
+	"challenge-yuno/internal/business/domain/inventory"
+	"challenge-yuno/internal/business/domain/menu"
 	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
 	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
 )
 
+var tracer = otel.Tracer("challenge-yuno/internal/business/usecases/order")
+
 type OrderUsecase struct {
 	KVSOrderRepository  interfaces.KVSOrderRepository
 	SQLOrderRepository  interfaces.SQLOrderRepository
-	NotificationService interfaces.INotificationService
+	NotificationOutbox  interfaces.NotificationOutbox
+	InventoryRepository interfaces.InventoryRepository
+	UnitOfWork          interfaces.UnitOfWork
+	Queue               interfaces.QueueBroadcaster
+	MenuRepository      interfaces.MenuRepository
+	// PrepEstimates is how long each order type takes to prepare, used for the ETA of edits.
+	PrepEstimates map[model.OrderType]time.Duration
+	Metrics       interfaces.OrderMetrics
+	Logger        *slog.Logger
 }
 
 func NewOrderUsecase(kvsOrderRepository interfaces.KVSOrderRepository,
-	sqlOrderRepository interfaces.SQLOrderRepository, notifService interfaces.INotificationService) *OrderUsecase {
+	sqlOrderRepository interfaces.SQLOrderRepository, notificationOutbox interfaces.NotificationOutbox,
+	inventoryRepository interfaces.InventoryRepository, unitOfWork interfaces.UnitOfWork, queue interfaces.QueueBroadcaster,
+	menuRepository interfaces.MenuRepository, prepEstimates map[model.OrderType]time.Duration, metrics interfaces.OrderMetrics,
+	logger *slog.Logger) *OrderUsecase {
 	return &OrderUsecase{
 		KVSOrderRepository:  kvsOrderRepository,
 		SQLOrderRepository:  sqlOrderRepository,
-		NotificationService: notifService,
+		NotificationOutbox:  notificationOutbox,
+		InventoryRepository: inventoryRepository,
+		UnitOfWork:          unitOfWork,
+		Queue:               queue,
+		MenuRepository:      menuRepository,
+		PrepEstimates:       prepEstimates,
+		Metrics:             metrics,
+		Logger:              logging.Named(logger, "usecases"),
+	}
+}
+
+func (u *OrderUsecase) AddOrder(ctx context.Context, branch tenant.Branch, order model.Order) (_ *model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.AddOrder", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	if err = u.checkNewOrder(ctx, branch, &order, time.Now()); err != nil {
+		return nil, err
+	}
+
+	created, err := u.SQLOrderRepository.AddOrder(ctx, branch, order)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Metrics.OrderCreated(created)
+	u.Logger.InfoContext(logging.WithOrderID(ctx, created.ID), "order created", slog.Int("priority", created.Priority))
+	if created.Status == model.Pending {
+		u.publishQueue(ctx, branch)
+	}
+
+	return created, nil
+}
+
+// AddOrderWith creates the order like AddOrder and runs fn with it in the same unit of work, so
+// the order is only kept if whatever fn links it to is saved too.
+func (u *OrderUsecase) AddOrderWith(ctx context.Context, branch tenant.Branch, order model.Order,
+	fn func(repos interfaces.Repositories, created *model.Order) error) (_ *model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.AddOrderWith", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	if err = u.checkNewOrder(ctx, branch, &order, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var created *model.Order
+	err = u.UnitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		var err error
+		if created, err = repos.Orders.AddOrder(ctx, branch, order); err != nil {
+			return err
+		}
+		return fn(repos, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.Metrics.OrderCreated(created)
+	u.Logger.InfoContext(logging.WithOrderID(ctx, created.ID), "order created", slog.Int("priority", created.Priority))
+	if created.Status == model.Pending {
+		u.publishQueue(ctx, branch)
+	}
+
+	return created, nil
+}
+
+// ImportOrder loads an order with the status and timestamps it had in another system, skipping
+// the creation policy. It is meant for privileged callers only.
+func (u *OrderUsecase) ImportOrder(ctx context.Context, branch tenant.Branch, order model.Order) (_ *model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ImportOrder", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	if order.UpdatedAt.IsZero() {
+		order.UpdatedAt = order.CreatedAt
+	}
+	if err = u.CheckImport(order); err != nil {
+		return nil, err
+	}
+
+	imported, err := u.SQLOrderRepository.ImportOrder(ctx, branch, order)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(logging.WithOrderID(ctx, imported.ID), "order imported", slog.String("status", string(imported.Status)))
+	if imported.Status == model.Pending {
+		u.publishQueue(ctx, branch)
+	}
+
+	return imported, nil
+}
+
+// CheckImport tells whether ImportOrder would accept the order, without saving it. It backs the
+// dry run of bulk imports.
+func (u *OrderUsecase) CheckImport(order model.Order) error {
+	if order.UpdatedAt.IsZero() {
+		order.UpdatedAt = order.CreatedAt
 	}
+
+	return model.ValidateHistorical(order, time.Now())
 }
 
-func (u *OrderUsecase) AddOrder(order model.Order) (*model.Order, error) {
-	return u.SQLOrderRepository.AddOrder(order)
+func (u *OrderUsecase) GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (_ *model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.GetOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.SQLOrderRepository.GetOrder(ctx, branch, orderID)
 }
 
-func (u *OrderUsecase) GetOrder(orderID string) (*model.Order, error) {
-	return u.SQLOrderRepository.GetOrder(orderID)
+func (u *OrderUsecase) ListActiveOrders(ctx context.Context, branch tenant.Branch) (_ []model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ListActiveOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.SQLOrderRepository.ListActiveOrders(ctx, branch)
 }
 
-func (u *OrderUsecase) ListActiveOrders() ([]model.Order, error) {
-	return u.SQLOrderRepository.ListActiveOrders()
+func (u *OrderUsecase) ListScheduledOrders(ctx context.Context, branch tenant.Branch) (_ []model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ListScheduledOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.SQLOrderRepository.ListScheduledOrders(ctx, branch)
 }
 
-func (u *OrderUsecase) UpdateOrder(orderID string, status model.Status, priority *int) (*model.Order, error) {
-	order, err := u.SQLOrderRepository.UpdateOrder(orderID, status, priority)
+// UpdateOrder applies a status change. With update.Version set it fails with
+// model.ErrOrderModified if the order changed since the client read it. The change, the stock it
+// takes or gives back and the notification of a finished order are saved together or not at all.
+func (u *OrderUsecase) UpdateOrder(ctx context.Context, branch tenant.Branch, update model.StatusUpdate) (_ *model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.UpdateOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", update.OrderID), attribute.String("order.status", string(update.Status))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.WithOrderID(ctx, update.OrderID)
+
+	var previous, order *model.Order
+	err = u.UnitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		var err error
+		if previous, err = repos.Orders.GetOrder(ctx, branch, update.OrderID); err != nil {
+			return err
+		}
+
+		// checked here too so a stale change doesn't take stock before failing
+		if err = model.CheckVersion(*previous, update.Version); err != nil {
+			return err
+		}
+
+		if update.Status == model.InPreparation && previous.Status != model.InPreparation {
+			if err = u.consumeStock(ctx, repos.Inventory, branch, previous.Menu); err != nil {
+				return err
+			}
+		}
+
+		if order, err = repos.Orders.UpdateOrder(ctx, branch, update); err != nil {
+			return err
+		}
+
+		// an order taken out of preparation gives back what it took, so preparing it again takes it once more
+		if model.ReturnsStock(previous.Status, order.Status) {
+			if err = u.returnStock(ctx, repos.Inventory, branch, previous.Menu); err != nil {
+				return err
+			}
+		}
+
+		if order.Status == model.Finished {
+			return repos.Outbox.Enqueue(ctx, branch, order)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if order.Status == model.Finished {
-		u.NotificationService.SendNotification(order)
+	u.afterUpdate(ctx, previous, order)
+	if queued(previous, order) {
+		u.publishQueue(ctx, branch)
 	}
 
-	return order, err
+	return order, nil
 }
 
-func (u *OrderUsecase) GetAllOrders() ([]model.Order, error) {
-	return u.SQLOrderRepository.GetAllOrders()
+// afterUpdate logs a status change that was saved and records its metrics.
+func (u *OrderUsecase) afterUpdate(ctx context.Context, previous, order *model.Order) {
+	u.Logger.InfoContext(ctx, "order updated",
+		slog.String("previous_status", string(previous.Status)), slog.String("status", string(order.Status)))
+
+	// the previous UpdatedAt marks when the order moved to IN_PREPARATION
+	if order.Status == model.Finished && previous.Status == model.InPreparation {
+		u.Metrics.OrderPrepared(order, order.UpdatedAt.Sub(previous.UpdatedAt))
+	}
+}
+
+// queued tells whether a change from previous to order entered or left the kitchen queue.
+func queued(previous, order *model.Order) bool {
+	return previous.Status == model.Pending || order.Status == model.Pending
+}
+
+// publishQueue shares the current kitchen queue of the branch with the screens following it. It
+// runs after every change to the PENDING orders; a failure only leaves the screens behind until
+// the next one, so it is logged and not returned.
+func (u *OrderUsecase) publishQueue(ctx context.Context, branch tenant.Branch) {
+	queue, err := u.SQLOrderRepository.ListActiveOrders(ctx, branch)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		u.Logger.ErrorContext(ctx, "error reading queue to publish", slog.Any("error", err))
+		return
+	}
+
+	u.Queue.Publish(branch.ID, queue)
+}
+
+// AddOrders creates a batch of orders in a single transaction, applying the same policy and stock
+// check as AddOrder to each. Orders that fail them are reported without reaching the database; in
+// all-or-nothing mode any failure leaves the whole batch unapplied.
+func (u *OrderUsecase) AddOrders(ctx context.Context, branch tenant.Branch, orders []model.Order, mode model.BatchMode) (_ []model.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.AddOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.Int("batch.size", len(orders))))
+	defer func() { tracing.End(span, err) }()
+
+	if err = model.ValidateBatch(mode, len(orders)); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	results := make([]model.BatchResult, len(orders))
+	var valid []model.Order
+	var positions []int
+	for i, order := range orders {
+		if results[i].Err = u.checkNewOrder(ctx, branch, &order, now); results[i].Err != nil {
+			if mode == model.AllOrNothing {
+				return model.Abort(results), nil
+			}
+			continue
+		}
+		valid = append(valid, order)
+		positions = append(positions, i)
+	}
+
+	if len(valid) > 0 {
+		created, addErr := u.SQLOrderRepository.AddOrders(ctx, branch, valid, mode)
+		if addErr != nil {
+			return nil, addErr
+		}
+		mergeBatch(results, created, positions)
+	}
+
+	changed := false
+	for _, result := range results {
+		if result.Applied() {
+			u.Metrics.OrderCreated(result.Order)
+			u.Logger.InfoContext(logging.WithOrderID(ctx, result.Order.ID), "order created", slog.Int("priority", result.Order.Priority))
+			changed = changed || result.Order.Status == model.Pending
+		}
+	}
+	if changed {
+		u.publishQueue(ctx, branch)
+	}
+
+	return results, nil
+}
+
+// checkNewOrder applies the creation policy to order, setting its initial status, and checks the
+// stock of the orders that go straight to the kitchen.
+func (u *OrderUsecase) checkNewOrder(ctx context.Context, branch tenant.Branch, order *model.Order, now time.Time) (err error) {
+	if order.Status, err = model.InitialStatus(*order, now); err != nil {
+		return err
+	}
+	// scheduled orders are checked when they reach the kitchen, the branch may restock before then
+	if order.Status == model.Pending {
+		return u.checkStock(ctx, branch, order.Menu)
+	}
+
+	return nil
+}
+
+// ImportOrders is the batch form of ImportOrder, with the transaction rules of AddOrders.
+func (u *OrderUsecase) ImportOrders(ctx context.Context, branch tenant.Branch, orders []model.Order, mode model.BatchMode) (_ []model.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ImportOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.Int("batch.size", len(orders))))
+	defer func() { tracing.End(span, err) }()
+
+	if err = model.ValidateBatch(mode, len(orders)); err != nil {
+		return nil, err
+	}
+
+	results := make([]model.BatchResult, len(orders))
+	var valid []model.Order
+	var positions []int
+	for i, order := range orders {
+		if order.UpdatedAt.IsZero() {
+			order.UpdatedAt = order.CreatedAt
+		}
+		if results[i].Err = u.CheckImport(order); results[i].Err != nil {
+			if mode == model.AllOrNothing {
+				return model.Abort(results), nil
+			}
+			continue
+		}
+		valid = append(valid, order)
+		positions = append(positions, i)
+	}
+
+	if len(valid) > 0 {
+		imported, importErr := u.SQLOrderRepository.ImportOrders(ctx, branch, valid, mode)
+		if importErr != nil {
+			return nil, importErr
+		}
+		mergeBatch(results, imported, positions)
+	}
+
+	changed := false
+	for _, result := range results {
+		if result.Applied() {
+			u.Logger.InfoContext(logging.WithOrderID(ctx, result.Order.ID), "order imported", slog.String("status", string(result.Order.Status)))
+			changed = changed || result.Order.Status == model.Pending
+		}
+	}
+	if changed {
+		u.publishQueue(ctx, branch)
+	}
+
+	return results, nil
+}
+
+// UpdateOrders changes the status of a batch of orders in a single unit of work, which also takes
+// and gives back their stock and queues the notifications of the orders it finishes. As in
+// UpdateOrder, orders moving to IN_PREPARATION take their stock first; in best-effort mode an
+// item whose change isn't applied gives it back in the same transaction, and an all-or-nothing
+// batch that fails rolls back as a whole.
+func (u *OrderUsecase) UpdateOrders(ctx context.Context, branch tenant.Branch, updates []model.StatusUpdate, mode model.BatchMode) (_ []model.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.UpdateOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.Int("batch.size", len(updates))))
+	defer func() { tracing.End(span, err) }()
+
+	if err = model.ValidateBatch(mode, len(updates)); err != nil {
+		return nil, err
+	}
+
+	results := make([]model.BatchResult, len(updates))
+	err = u.UnitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		consumed := make(map[int][]string)
+		var valid []model.StatusUpdate
+		var positions []int
+		for i, update := range updates {
+			previous, getErr := repos.Orders.GetOrder(ctx, branch, update.OrderID)
+			if getErr == nil && update.Status == model.InPreparation && previous.Status != model.InPreparation {
+				if getErr = u.consumeStock(ctx, repos.Inventory, branch, previous.Menu); getErr == nil {
+					consumed[i] = previous.Menu
+				}
+			}
+			if getErr != nil {
+				results[i].Err = getErr
+				if mode == model.AllOrNothing {
+					return model.ErrBatchAborted
+				}
+				continue
+			}
+			valid = append(valid, update)
+			positions = append(positions, i)
+		}
+
+		if len(valid) > 0 {
+			updated, err := repos.Orders.UpdateOrders(ctx, branch, valid, mode)
+			if err != nil {
+				return err
+			}
+			mergeBatch(results, updated, positions)
+		}
+
+		for i, result := range results {
+			if !result.Applied() {
+				if mode == model.AllOrNothing {
+					return model.ErrBatchAborted
+				}
+				if menu, ok := consumed[i]; ok {
+					if err := u.returnStock(ctx, repos.Inventory, branch, menu); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+
+			// an order taken out of preparation gives back what it took
+			if model.ReturnsStock(result.Previous.Status, result.Order.Status) {
+				if err := u.returnStock(ctx, repos.Inventory, branch, result.Previous.Menu); err != nil {
+					return err
+				}
+			}
+			if result.Order.Status == model.Finished {
+				if err := repos.Outbox.Enqueue(ctx, branch, result.Order); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, model.ErrBatchAborted) {
+		return model.Abort(results), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for _, result := range results {
+		if result.Applied() {
+			u.afterUpdate(logging.WithOrderID(ctx, result.Order.ID), result.Previous, result.Order)
+			changed = changed || queued(result.Previous, result.Order)
+		}
+	}
+	if changed {
+		u.publishQueue(ctx, branch)
+	}
+
+	return results, nil
+}
+
+// mergeBatch puts the results of the items sent to the repository back at their position in
+// the batch.
+func mergeBatch(results, applied []model.BatchResult, positions []int) {
+	for j, i := range positions {
+		results[i] = applied[j]
+	}
+}
+
+// MoveOrder reorders the kitchen queue of the branch and shares the new order with the screens
+// following it.
+func (u *OrderUsecase) MoveOrder(ctx context.Context, branch tenant.Branch, move model.Move) (_ []model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.MoveOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", move.OrderID)))
+	defer func() { tracing.End(span, err) }()
+
+	if err = move.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx = logging.WithOrderID(ctx, move.OrderID)
+
+	// an order missing altogether is reported as such, not as out of the queue
+	if _, err = u.SQLOrderRepository.GetOrder(ctx, branch, move.OrderID); err != nil {
+		return nil, err
+	}
+
+	queue, err := u.SQLOrderRepository.MoveOrder(ctx, branch, move)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(ctx, "order moved", slog.Bool("forced", move.Force))
+	u.Queue.Publish(branch.ID, queue)
+
+	return queue, nil
+}
+
+// WatchQueue returns the queue of the branch every time it changes, until ctx is done.
+func (u *OrderUsecase) WatchQueue(ctx context.Context, branch tenant.Branch) <-chan []model.Order {
+	return u.Queue.Subscribe(ctx, branch.ID)
+}
+
+// EditOrder changes the content of an order that hasn't left the kitchen and returns it with its
+// recalculated quote. Orders IN_PREPARATION already took their stock, so it follows the items
+// added and removed along with the edit; items added to other orders only need to be in stock.
+func (u *OrderUsecase) EditOrder(ctx context.Context, branch tenant.Branch, edit model.Edit) (_ *model.Order, _ *model.Quote, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.EditOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", edit.OrderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx = logging.WithOrderID(ctx, edit.OrderID)
+	now := time.Now()
+
+	var previous, order *model.Order
+	err = u.UnitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		var err error
+		if previous, err = repos.Orders.GetOrder(ctx, branch, edit.OrderID); err != nil {
+			return err
+		}
+		if err = model.CheckVersion(*previous, edit.Version); err != nil {
+			return err
+		}
+
+		edited, revision, err := edit.Apply(*previous, now)
+		if err != nil {
+			return err
+		}
+
+		if previous.Status == model.InPreparation {
+			if err = u.consumeStock(ctx, repos.Inventory, branch, revision.Added); err != nil {
+				return err
+			}
+			if err = u.returnStock(ctx, repos.Inventory, branch, revision.Removed); err != nil {
+				return err
+			}
+		} else if err = u.checkStock(ctx, branch, revision.Added); err != nil {
+			return err
+		}
+
+		order, err = repos.Orders.EditOrder(ctx, branch, edited, revision)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quote := model.NewQuote(*order, menu.Prices(u.MenuRepository.ListItems()), u.PrepEstimates, now)
+	u.Logger.InfoContext(ctx, "order edited", slog.String("status", string(order.Status)),
+		slog.Int("items", len(order.Menu)), slog.Int("previous_items", len(previous.Menu)))
+	// the screens show the content of the queued orders, not just their position
+	if order.Status == model.Pending {
+		u.publishQueue(ctx, branch)
+	}
+
+	return order, &quote, nil
+}
+
+func (u *OrderUsecase) ListRevisions(ctx context.Context, branch tenant.Branch, orderID string) (_ []model.Revision, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ListRevisions", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	// an unknown order is reported as such instead of as an order without edits
+	if _, err = u.SQLOrderRepository.GetOrder(ctx, branch, orderID); err != nil {
+		return nil, err
+	}
+
+	return u.SQLOrderRepository.ListRevisions(ctx, branch, orderID)
+}
+
+// checkStock rejects an order when the branch lacks the ingredients to prepare it right now.
+func (u *OrderUsecase) checkStock(ctx context.Context, branch tenant.Branch, menu []string) error {
+	// without items ListRecipes would return every recipe
+	if len(menu) == 0 {
+		return nil
+	}
+
+	recipes, err := u.InventoryRepository.ListRecipes(ctx, menu...)
+	if err != nil {
+		return err
+	}
+
+	required := inventory.Requirements(menu, recipes)
+	if len(required) == 0 {
+		return nil
+	}
+
+	ingredients := make([]string, 0, len(required))
+	for ingredient := range required {
+		ingredients = append(ingredients, ingredient)
+	}
+	stock, err := u.InventoryRepository.Stock(ctx, branch, ingredients...)
+	if err != nil {
+		return err
+	}
+
+	if missing := inventory.Missing(required, stock); len(missing) > 0 {
+		return inventory.InsufficientStock(missing)
+	}
+
+	return nil
+}
+
+// consumeStock takes the ingredients of the order from stock, which may be bound to a transaction.
+func (u *OrderUsecase) consumeStock(ctx context.Context, stock interfaces.InventoryRepository, branch tenant.Branch, menu []string) error {
+	if len(menu) == 0 {
+		return nil
+	}
+
+	recipes, err := stock.ListRecipes(ctx, menu...)
+	if err != nil {
+		return err
+	}
+
+	required := inventory.Requirements(menu, recipes)
+	if len(required) == 0 {
+		return nil
+	}
+
+	return stock.Consume(ctx, branch, required)
+}
+
+// returnStock puts back the ingredients of the order into stock, which may be bound to a
+// transaction.
+func (u *OrderUsecase) returnStock(ctx context.Context, stock interfaces.InventoryRepository, branch tenant.Branch, menu []string) error {
+	if len(menu) == 0 {
+		return nil
+	}
+
+	recipes, err := stock.ListRecipes(ctx, menu...)
+	if err != nil {
+		return err
+	}
+
+	required := inventory.Requirements(menu, recipes)
+	if len(required) == 0 {
+		return nil
+	}
+
+	return stock.Restore(ctx, branch, required)
+}
+
+func (u *OrderUsecase) GetAllOrders(ctx context.Context, branch tenant.Branch, filter model.Filter) (_ []model.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.GetAllOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.SQLOrderRepository.GetAllOrders(ctx, branch, filter)
+}
+
+// ExportOrders streams the orders GetAllOrders would list to fn, one at a time.
+func (u *OrderUsecase) ExportOrders(ctx context.Context, branch tenant.Branch, filter model.Filter, fn func(model.Order) error) (err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ExportOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.SQLOrderRepository.StreamOrders(ctx, branch, filter, fn)
+}
+
+// CheckSLA reports the orders that stayed in a status longer than its SLA, e.g. PENDING for more
+// than 15 minutes. Overdue orders are logged and counted per branch.
+func (u *OrderUsecase) CheckSLA(ctx context.Context, slas map[model.Status]time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.CheckSLA")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+	var failed []error
+	for status, sla := range slas {
+		orders, listErr := u.SQLOrderRepository.ListOverdueOrders(ctx, status, now.Add(-sla))
+		if listErr != nil {
+			failed = append(failed, listErr)
+			continue
+		}
+
+		countByBranch := make(map[string]int)
+		for _, o := range orders {
+			countByBranch[o.BranchID]++
+			orderCtx := logging.WithOrderID(logging.WithTenant(ctx, o.BranchID), o.ID)
+			u.Logger.WarnContext(orderCtx, "order exceeded its SLA",
+				slog.String("status", string(status)), slog.Duration("sla", sla), slog.Duration("elapsed", now.Sub(o.UpdatedAt)))
+		}
+
+		u.Metrics.OverdueOrders(status, countByBranch)
+	}
+
+	return errors.Join(failed...)
+}
+
+// ArchiveClosed moves the delivered and canceled orders that haven't changed in archiveAfter out
+// of the live table. They can still be read by ID.
+func (u *OrderUsecase) ArchiveClosed(ctx context.Context, archiveAfter time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.ArchiveClosed")
+	defer func() { tracing.End(span, err) }()
+
+	archived, err := u.SQLOrderRepository.ArchiveOrders(ctx, model.ClosedStatuses, time.Now().Add(-archiveAfter))
+	if err != nil {
+		return err
+	}
+	if archived > 0 {
+		u.Logger.InfoContext(ctx, "closed orders archived", slog.Int64("orders", archived))
+	}
+
+	return nil
+}
+
+// PromoteScheduled moves scheduled orders into the kitchen queue once they are due to start: the
+// preparation estimate of their type plus leadTime before scheduled_for. Types without an
+// estimate are promoted leadTime before they are due.
+func (u *OrderUsecase) PromoteScheduled(ctx context.Context, leadTime time.Duration, prepEstimates map[model.OrderType]time.Duration) (err error) {
+	ctx, span := tracer.Start(ctx, "OrderUsecase.PromoteScheduled")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+	var failed []error
+	promotedBranches := make(map[string]bool)
+	for _, orderType := range model.OrderTypes {
+		// an order is promoted once scheduled_for - (estimate + leadTime) <= now
+		dueBefore := now.Add(prepEstimates[orderType] + leadTime)
+		promoted, promoteErr := u.SQLOrderRepository.PromoteScheduledOrders(ctx, orderType, dueBefore)
+		if promoteErr != nil {
+			failed = append(failed, promoteErr)
+			continue
+		}
+
+		for _, o := range promoted {
+			orderCtx := logging.WithOrderID(logging.WithTenant(ctx, o.BranchID), o.ID)
+			u.Logger.InfoContext(orderCtx, "scheduled order promoted", slog.Time("scheduled_for", *o.ScheduledFor))
+			promotedBranches[o.BranchID] = true
+		}
+	}
+
+	for branchID := range promotedBranches {
+		u.publishQueue(logging.WithTenant(ctx, branchID), tenant.Branch{ID: branchID})
+	}
+
+	return errors.Join(failed...)
 }
@@ -0,0 +1,81 @@
+package order
+
+import (
+	"challenge-yuno/internal/business/domain/inventory"
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/mocks"
+	"challenge-yuno/internal/platform/broadcast"
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+// unitOfWork runs fn with the mocked repositories, without a transaction.
+type unitOfWork struct {
+	repos interfaces.Repositories
+}
+
+func (u unitOfWork) Do(_ context.Context, fn func(repos interfaces.Repositories) error) error {
+	return fn(u.repos)
+}
+
+type OrderUsecaseTestSuite struct {
+	suite.Suite
+	orders    *mocks.MockSQLOrderRepository
+	inventory *mocks.MockInventoryRepository
+	usecase   *OrderUsecase
+}
+
+var testBranch = tenant.Branch{ID: "centro"}
+
+func (s *OrderUsecaseTestSuite) SetupTest() {
+	s.orders = new(mocks.MockSQLOrderRepository)
+	s.inventory = new(mocks.MockInventoryRepository)
+	outbox := new(mocks.MockNotificationOutbox)
+	uow := unitOfWork{repos: interfaces.Repositories{Orders: s.orders, Inventory: s.inventory, Outbox: outbox}}
+	s.usecase = NewOrderUsecase(nil, s.orders, outbox, s.inventory, uow, broadcast.NewHub[[]model.Order](),
+		nil, nil, new(mocks.MockOrderMetrics), slog.New(slog.NewJSONHandler(io.Discard, nil)))
+}
+
+func TestOrderUsecase(t *testing.T) {
+	suite.Run(t, new(OrderUsecaseTestSuite))
+}
+
+func (s *OrderUsecaseTestSuite) TestUpdateOrderBackToQueue() {
+	menu := []string{"Hamburguesa"}
+	recipes := []inventory.Recipe{{Item: "Hamburguesa", Lines: []inventory.RecipeLine{{Ingredient: "pan", Quantity: 2}}}}
+	required := map[string]int64{"pan": 2}
+	preparing := &model.Order{ID: "123", Status: model.InPreparation, Menu: menu}
+	pending := &model.Order{ID: "123", Status: model.Pending, Menu: menu}
+
+	s.inventory.On("ListRecipes", mock.Anything, "Hamburguesa").Return(recipes, nil)
+	s.orders.On("ListActiveOrders", mock.Anything, testBranch).Return([]model.Order{*pending}, nil)
+
+	// sent back to the queue, it gives back the stock it took
+	s.orders.On("GetOrder", mock.Anything, testBranch, "123").Return(preparing, nil).Once()
+	s.orders.On("UpdateOrder", mock.Anything, testBranch, model.StatusUpdate{OrderID: "123", Status: model.Pending}).Return(pending, nil).Once()
+	s.inventory.On("Restore", mock.Anything, testBranch, required).Return(nil).Once()
+
+	order, err := s.usecase.UpdateOrder(context.Background(), testBranch, model.StatusUpdate{OrderID: "123", Status: model.Pending})
+	s.Require().NoError(err)
+	s.Equal(model.Pending, order.Status)
+
+	// prepared again, it takes it once more
+	s.orders.On("GetOrder", mock.Anything, testBranch, "123").Return(pending, nil).Once()
+	s.inventory.On("Consume", mock.Anything, testBranch, required).Return(nil).Once()
+	s.orders.On("UpdateOrder", mock.Anything, testBranch, model.StatusUpdate{OrderID: "123", Status: model.InPreparation}).Return(preparing, nil).Once()
+
+	order, err = s.usecase.UpdateOrder(context.Background(), testBranch, model.StatusUpdate{OrderID: "123", Status: model.InPreparation})
+	s.Require().NoError(err)
+	s.Equal(model.InPreparation, order.Status)
+
+	s.inventory.AssertNumberOfCalls(s.T(), "Restore", 1)
+	s.inventory.AssertNumberOfCalls(s.T(), "Consume", 1)
+	s.orders.AssertExpectations(s.T())
+	s.inventory.AssertExpectations(s.T())
+}
@@ -0,0 +1,148 @@
+package report
+
+import (
+	"challenge-yuno/internal/business/domain/menu"
+	model "challenge-yuno/internal/business/domain/report"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
+)
+
+var tracer = otel.Tracer("challenge-yuno/internal/business/usecases/report")
+
+type ReportUsecase struct {
+	ReportRepository interfaces.ReportRepository
+	BranchRepository interfaces.BranchRepository
+	MenuRepository   interfaces.MenuRepository
+	Logger           *slog.Logger
+}
+
+func NewReportUsecase(reportRepository interfaces.ReportRepository, branchRepository interfaces.BranchRepository,
+	menuRepository interfaces.MenuRepository, logger *slog.Logger) *ReportUsecase {
+	return &ReportUsecase{
+		ReportRepository: reportRepository,
+		BranchRepository: branchRepository,
+		MenuRepository:   menuRepository,
+		Logger:           logging.Named(logger, "usecases"),
+	}
+}
+
+func (u *ReportUsecase) CountOrders(ctx context.Context, branch tenant.Branch, rng model.Range, dimension model.Dimension) (_ []model.Count, err error) {
+	ctx, span := tracer.Start(ctx, "ReportUsecase.CountOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("report.dimension", string(dimension))))
+	defer func() { tracing.End(span, err) }()
+
+	counts, err := u.ReportRepository.CountOrders(ctx, branch, rng, dimension)
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) == 0 {
+		return nil, errs.NotFound("no_orders", "there is no orders in the range")
+	}
+
+	return counts, nil
+}
+
+// Summary puts together the order totals of the range with the revenue, which is priced with the
+// current menu.
+func (u *ReportUsecase) Summary(ctx context.Context, branch tenant.Branch, rng model.Range) (_ *model.Summary, err error) {
+	ctx, span := tracer.Start(ctx, "ReportUsecase.Summary", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	totals, err := u.ReportRepository.Totals(ctx, branch, rng)
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.ReportRepository.ItemSales(ctx, branch, rng)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Summary{
+		Range:            rng,
+		Orders:           totals.Orders,
+		Canceled:         totals.Canceled,
+		CancellationRate: model.CancellationRate(totals.Orders, totals.Canceled),
+		Revenue:          model.Price(items, menu.Prices(u.MenuRepository.ListItems())),
+		Wait:             totals.Wait,
+		Prep:             totals.Prep,
+	}, nil
+}
+
+// TopItems returns the best selling items of the range with what they brought in. A limit of 0
+// returns every item sold.
+func (u *ReportUsecase) TopItems(ctx context.Context, branch tenant.Branch, rng model.Range, limit int) (_ []model.ItemSales, err error) {
+	ctx, span := tracer.Start(ctx, "ReportUsecase.TopItems", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	items, err := u.ReportRepository.ItemSales(ctx, branch, rng)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errs.NotFound("no_sales", "there is no sales in the range")
+	}
+
+	model.Price(items, menu.Prices(u.MenuRepository.ListItems()))
+
+	return model.Top(items, limit), nil
+}
+
+func (u *ReportUsecase) ListDailySummaries(ctx context.Context, branch tenant.Branch, rng model.Range) (_ []model.DailySummary, err error) {
+	ctx, span := tracer.Start(ctx, "ReportUsecase.ListDailySummaries", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	summaries, err := u.ReportRepository.ListDailySummaries(ctx, branch, rng)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, errs.NotFound("no_daily_summaries", "there is no closed days in the range")
+	}
+
+	return summaries, nil
+}
+
+// CloseDay snapshots the summary of the local day before now for every branch. Closing a day
+// again replaces its snapshot, so the job can run often and late changes still make it in
+// until the next day is closed.
+func (u *ReportUsecase) CloseDay(ctx context.Context, now time.Time) (err error) {
+	ctx, span := tracer.Start(ctx, "ReportUsecase.CloseDay")
+	defer func() { tracing.End(span, err) }()
+
+	var failed []error
+	for _, branch := range u.BranchRepository.ListBranches() {
+		branchCtx := logging.WithTenant(ctx, branch.ID)
+		day := model.Day(branch.StartOfDay(now).AddDate(0, 0, -1))
+
+		summary, summaryErr := u.Summary(branchCtx, branch, day)
+		if summaryErr != nil {
+			failed = append(failed, summaryErr)
+			continue
+		}
+
+		daily := model.DailySummary{
+			BranchID: branch.ID,
+			Day:      day.From.Format(model.DayFormat),
+			Summary:  *summary,
+			ClosedAt: now,
+		}
+		if saveErr := u.ReportRepository.SaveDailySummary(branchCtx, daily); saveErr != nil {
+			failed = append(failed, saveErr)
+			continue
+		}
+
+		u.Logger.DebugContext(branchCtx, "day closed", slog.String("day", daily.Day), slog.Int64("orders", daily.Orders))
+	}
+
+	return errors.Join(failed...)
+}
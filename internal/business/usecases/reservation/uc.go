@@ -0,0 +1,226 @@
+package reservation
+
+import (
+	"challenge-yuno/internal/business/domain/dining"
+	"challenge-yuno/internal/business/domain/order"
+	model "challenge-yuno/internal/business/domain/reservation"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"time"
+)
+
+var tracer = otel.Tracer("challenge-yuno/internal/business/usecases/reservation")
+
+type ReservationUsecase struct {
+	ReservationRepository interfaces.ReservationRepository
+	DiningRepository      interfaces.DiningRepository
+	// UnitOfWork seats a party in one transaction: session, pre-orders and reservation status.
+	UnitOfWork   interfaces.UnitOfWork
+	OrderUsecase interfaces.OrderUsecase
+	// Duration is how long a table is held when the reservation doesn't say when it ends.
+	Duration time.Duration
+	Logger   *slog.Logger
+}
+
+func NewReservationUsecase(reservationRepository interfaces.ReservationRepository, diningRepository interfaces.DiningRepository,
+	unitOfWork interfaces.UnitOfWork, orderUsecase interfaces.OrderUsecase, duration time.Duration, logger *slog.Logger) *ReservationUsecase {
+	return &ReservationUsecase{
+		ReservationRepository: reservationRepository,
+		DiningRepository:      diningRepository,
+		UnitOfWork:            unitOfWork,
+		OrderUsecase:          orderUsecase,
+		Duration:              duration,
+		Logger:                logging.Named(logger, "usecases"),
+	}
+}
+
+// AddReservation books a table for the party, either the one asked for or the smallest free
+// table that seats them.
+func (u *ReservationUsecase) AddReservation(ctx context.Context, branch tenant.Branch, reservation model.Reservation) (_ *model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationUsecase.AddReservation", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	if reservation.EndsAt.IsZero() {
+		reservation.EndsAt = reservation.StartsAt.Add(u.Duration)
+	}
+	if err = model.ValidateSlot(reservation, time.Now()); err != nil {
+		return nil, err
+	}
+
+	tables, err := u.DiningRepository.ListTables(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	booked, err := u.ReservationRepository.ListReservations(ctx, branch, reservation.StartsAt, reservation.EndsAt)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return nil, err
+	}
+
+	reservation.TableID, err = model.Assign(tables, booked, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.ReservationRepository.AddReservation(ctx, branch, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(ctx, "reservation booked", slog.String("reservation_id", created.ID),
+		slog.String("table_id", created.TableID), slog.Int("party_size", created.PartySize), slog.Time("starts_at", created.StartsAt))
+
+	return created, nil
+}
+
+func (u *ReservationUsecase) GetReservation(ctx context.Context, branch tenant.Branch, reservationID string) (_ *model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationUsecase.GetReservation", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("reservation.id", reservationID)))
+	defer func() { tracing.End(span, err) }()
+
+	return u.ReservationRepository.GetReservation(ctx, branch, reservationID)
+}
+
+func (u *ReservationUsecase) ListReservations(ctx context.Context, branch tenant.Branch, from, to time.Time) (_ []model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationUsecase.ListReservations", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	if !to.After(from) {
+		return nil, errs.Invalid("invalid_filter", "to must be after from")
+	}
+
+	return u.ReservationRepository.ListReservations(ctx, branch, from, to)
+}
+
+// UpdateStatus settles a booked reservation. Seating the party opens a dine-in session at the
+// table with the pre-orders attached; a no-show or a cancellation cancels the pre-orders that
+// haven't reached the kitchen yet.
+func (u *ReservationUsecase) UpdateStatus(ctx context.Context, branch tenant.Branch, reservationID string, status model.Status) (_ *model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationUsecase.UpdateStatus", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("reservation.id", reservationID), attribute.String("reservation.status", string(status))))
+	defer func() { tracing.End(span, err) }()
+
+	current, err := u.ReservationRepository.GetReservation(ctx, branch, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if err = model.Transition(current.Status, status); err != nil {
+		return nil, err
+	}
+
+	var updated *model.Reservation
+	if status == model.Seated {
+		updated, err = u.seat(ctx, branch, current)
+	} else {
+		updated, err = u.ReservationRepository.UpdateReservationStatus(ctx, branch, reservationID, current.Status, status)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(ctx, "reservation updated", slog.String("reservation_id", reservationID),
+		slog.String("previous_status", string(current.Status)), slog.String("status", string(updated.Status)))
+
+	if status == model.NoShow || status == model.Canceled {
+		u.cancelOrders(ctx, branch, updated.OrderIDs)
+	}
+
+	return updated, nil
+}
+
+// AddOrder places a pre-order for the reservation. It is created as a SCHEDULED IN_PERSON order
+// due when the reservation starts, so the scheduler sends it to the kitchen ahead of the party.
+// The order is created and attached in one unit of work: if the reservation stopped being BOOKED
+// meanwhile the order isn't kept, so no pre-order is left without a reservation to cancel it.
+func (u *ReservationUsecase) AddOrder(ctx context.Context, branch tenant.Branch, reservationID string, o order.Order) (_ *order.Order, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationUsecase.AddOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("reservation.id", reservationID)))
+	defer func() { tracing.End(span, err) }()
+
+	reservation, err := u.ReservationRepository.GetReservation(ctx, branch, reservationID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != model.Booked {
+		return nil, errs.Conflict("reservation_not_booked", "pre-orders can only be placed for BOOKED reservations")
+	}
+
+	startsAt := reservation.StartsAt
+	o.Source = order.InPerson
+	o.Status = order.Scheduled
+	o.ScheduledFor = &startsAt
+
+	created, err := u.OrderUsecase.AddOrderWith(ctx, branch, o, func(repos interfaces.Repositories, created *order.Order) error {
+		return repos.Reservations.AttachOrder(ctx, branch, reservationID, created.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(logging.WithOrderID(ctx, created.ID), "pre-order placed", slog.String("reservation_id", reservationID))
+
+	return created, nil
+}
+
+// seat opens a dine-in session at the reserved table, attaches the pre-orders to it and marks the
+// reservation SEATED, in a single unit of work: if any step fails, such as the table still being
+// taken, nothing is saved and the reservation stays booked.
+func (u *ReservationUsecase) seat(ctx context.Context, branch tenant.Branch, reservation *model.Reservation) (*model.Reservation, error) {
+	var (
+		session *dining.Session
+		seated  *model.Reservation
+	)
+	err := u.UnitOfWork.Do(ctx, func(repos interfaces.Repositories) (err error) {
+		session, err = repos.Dining.OpenSession(ctx, branch, reservation.TableID)
+		if err != nil {
+			return err
+		}
+
+		for _, orderID := range reservation.OrderIDs {
+			if err = repos.Dining.AttachOrder(ctx, branch, session.ID, orderID); err != nil {
+				return err
+			}
+		}
+
+		seated, err = repos.Reservations.UpdateReservationStatus(ctx, branch, reservation.ID, reservation.Status, model.Seated)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.Logger.InfoContext(ctx, "reservation seated", slog.String("reservation_id", reservation.ID),
+		slog.String("session_id", session.ID), slog.Int("pre_orders", len(reservation.OrderIDs)))
+
+	return seated, nil
+}
+
+// cancelOrders cancels the pre-orders still waiting for their slot. The reservation is already
+// settled at this point, so failures are logged instead of returned.
+func (u *ReservationUsecase) cancelOrders(ctx context.Context, branch tenant.Branch, orderIDs []string) {
+	for _, orderID := range orderIDs {
+		orderCtx := logging.WithOrderID(ctx, orderID)
+
+		o, err := u.OrderUsecase.GetOrder(orderCtx, branch, orderID)
+		if err != nil {
+			u.Logger.ErrorContext(orderCtx, "error getting pre-order", slog.Any("error", err))
+			continue
+		}
+		if o.Status != order.Scheduled {
+			continue
+		}
+
+		if _, err = u.OrderUsecase.UpdateOrder(orderCtx, branch, order.StatusUpdate{OrderID: orderID, Status: order.Canceled}); err != nil {
+			u.Logger.ErrorContext(orderCtx, "error canceling pre-order", slog.Any("error", err))
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	audit "challenge-yuno/internal/business/domain/audit"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+)
+
+// MockAuditUsecase is an autogenerated mock type for the AuditUsecase type
+type MockAuditUsecase struct {
+	mock.Mock
+}
+
+type MockAuditUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuditUsecase) EXPECT() *MockAuditUsecase_Expecter {
+	return &MockAuditUsecase_Expecter{mock: &_m.Mock}
+}
+
+// ExportEntries provides a mock function with given fields: ctx, branch, filter, fn
+func (_m *MockAuditUsecase) ExportEntries(ctx context.Context, branch tenant.Branch, filter audit.Filter, fn func(audit.Entry) error) error {
+	ret := _m.Called(ctx, branch, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportEntries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, audit.Filter, func(audit.Entry) error) error); ok {
+		r0 = rf(ctx, branch, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditUsecase_ExportEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportEntries'
+type MockAuditUsecase_ExportEntries_Call struct {
+	*mock.Call
+}
+
+// ExportEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - filter audit.Filter
+//   - fn func(audit.Entry) error
+func (_e *MockAuditUsecase_Expecter) ExportEntries(ctx interface{}, branch interface{}, filter interface{}, fn interface{}) *MockAuditUsecase_ExportEntries_Call {
+	return &MockAuditUsecase_ExportEntries_Call{Call: _e.mock.On("ExportEntries", ctx, branch, filter, fn)}
+}
+
+func (_c *MockAuditUsecase_ExportEntries_Call) Run(run func(ctx context.Context, branch tenant.Branch, filter audit.Filter, fn func(audit.Entry) error)) *MockAuditUsecase_ExportEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(audit.Filter), args[3].(func(audit.Entry) error))
+	})
+	return _c
+}
+
+func (_c *MockAuditUsecase_ExportEntries_Call) Return(_a0 error) *MockAuditUsecase_ExportEntries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditUsecase_ExportEntries_Call) RunAndReturn(run func(context.Context, tenant.Branch, audit.Filter, func(audit.Entry) error) error) *MockAuditUsecase_ExportEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEntries provides a mock function with given fields: ctx, branch, filter
+func (_m *MockAuditUsecase) ListEntries(ctx context.Context, branch tenant.Branch, filter audit.Filter) ([]audit.Entry, error) {
+	ret := _m.Called(ctx, branch, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEntries")
+	}
+
+	var r0 []audit.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, audit.Filter) ([]audit.Entry, error)); ok {
+		return rf(ctx, branch, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, audit.Filter) []audit.Entry); ok {
+		r0 = rf(ctx, branch, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]audit.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, audit.Filter) error); ok {
+		r1 = rf(ctx, branch, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuditUsecase_ListEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEntries'
+type MockAuditUsecase_ListEntries_Call struct {
+	*mock.Call
+}
+
+// ListEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - filter audit.Filter
+func (_e *MockAuditUsecase_Expecter) ListEntries(ctx interface{}, branch interface{}, filter interface{}) *MockAuditUsecase_ListEntries_Call {
+	return &MockAuditUsecase_ListEntries_Call{Call: _e.mock.On("ListEntries", ctx, branch, filter)}
+}
+
+func (_c *MockAuditUsecase_ListEntries_Call) Run(run func(ctx context.Context, branch tenant.Branch, filter audit.Filter)) *MockAuditUsecase_ListEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(audit.Filter))
+	})
+	return _c
+}
+
+func (_c *MockAuditUsecase_ListEntries_Call) Return(_a0 []audit.Entry, _a1 error) *MockAuditUsecase_ListEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuditUsecase_ListEntries_Call) RunAndReturn(run func(context.Context, tenant.Branch, audit.Filter) ([]audit.Entry, error)) *MockAuditUsecase_ListEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, branch, entry, before, after
+func (_m *MockAuditUsecase) Record(ctx context.Context, branch tenant.Branch, entry audit.Entry, before *order.Order, after *order.Order) error {
+	ret := _m.Called(ctx, branch, entry, before, after)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, audit.Entry, *order.Order, *order.Order) error); ok {
+		r0 = rf(ctx, branch, entry, before, after)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAuditUsecase_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockAuditUsecase_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - entry audit.Entry
+//   - before *order.Order
+//   - after *order.Order
+func (_e *MockAuditUsecase_Expecter) Record(ctx interface{}, branch interface{}, entry interface{}, before interface{}, after interface{}) *MockAuditUsecase_Record_Call {
+	return &MockAuditUsecase_Record_Call{Call: _e.mock.On("Record", ctx, branch, entry, before, after)}
+}
+
+func (_c *MockAuditUsecase_Record_Call) Run(run func(ctx context.Context, branch tenant.Branch, entry audit.Entry, before *order.Order, after *order.Order)) *MockAuditUsecase_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(audit.Entry), args[3].(*order.Order), args[4].(*order.Order))
+	})
+	return _c
+}
+
+func (_c *MockAuditUsecase_Record_Call) Return(_a0 error) *MockAuditUsecase_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAuditUsecase_Record_Call) RunAndReturn(run func(context.Context, tenant.Branch, audit.Entry, *order.Order, *order.Order) error) *MockAuditUsecase_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuditUsecase creates a new instance of MockAuditUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuditUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuditUsecase {
+	mock := &MockAuditUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
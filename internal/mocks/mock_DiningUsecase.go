@@ -0,0 +1,461 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	dining "challenge-yuno/internal/business/domain/dining"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+)
+
+// MockDiningUsecase is an autogenerated mock type for the DiningUsecase type
+type MockDiningUsecase struct {
+	mock.Mock
+}
+
+type MockDiningUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDiningUsecase) EXPECT() *MockDiningUsecase_Expecter {
+	return &MockDiningUsecase_Expecter{mock: &_m.Mock}
+}
+
+// AddTable provides a mock function with given fields: ctx, branch, table
+func (_m *MockDiningUsecase) AddTable(ctx context.Context, branch tenant.Branch, table dining.Table) (*dining.Table, error) {
+	ret := _m.Called(ctx, branch, table)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTable")
+	}
+
+	var r0 *dining.Table
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, dining.Table) (*dining.Table, error)); ok {
+		return rf(ctx, branch, table)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, dining.Table) *dining.Table); ok {
+		r0 = rf(ctx, branch, table)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dining.Table)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, dining.Table) error); ok {
+		r1 = rf(ctx, branch, table)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_AddTable_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTable'
+type MockDiningUsecase_AddTable_Call struct {
+	*mock.Call
+}
+
+// AddTable is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - table dining.Table
+func (_e *MockDiningUsecase_Expecter) AddTable(ctx interface{}, branch interface{}, table interface{}) *MockDiningUsecase_AddTable_Call {
+	return &MockDiningUsecase_AddTable_Call{Call: _e.mock.On("AddTable", ctx, branch, table)}
+}
+
+func (_c *MockDiningUsecase_AddTable_Call) Run(run func(ctx context.Context, branch tenant.Branch, table dining.Table)) *MockDiningUsecase_AddTable_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(dining.Table))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_AddTable_Call) Return(_a0 *dining.Table, _a1 error) *MockDiningUsecase_AddTable_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_AddTable_Call) RunAndReturn(run func(context.Context, tenant.Branch, dining.Table) (*dining.Table, error)) *MockDiningUsecase_AddTable_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AttachOrder provides a mock function with given fields: ctx, branch, tableID, orderID
+func (_m *MockDiningUsecase) AttachOrder(ctx context.Context, branch tenant.Branch, tableID string, orderID string) (*dining.Session, error) {
+	ret := _m.Called(ctx, branch, tableID, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachOrder")
+	}
+
+	var r0 *dining.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string, string) (*dining.Session, error)); ok {
+		return rf(ctx, branch, tableID, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string, string) *dining.Session); ok {
+		r0 = rf(ctx, branch, tableID, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dining.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string, string) error); ok {
+		r1 = rf(ctx, branch, tableID, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_AttachOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AttachOrder'
+type MockDiningUsecase_AttachOrder_Call struct {
+	*mock.Call
+}
+
+// AttachOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - tableID string
+//   - orderID string
+func (_e *MockDiningUsecase_Expecter) AttachOrder(ctx interface{}, branch interface{}, tableID interface{}, orderID interface{}) *MockDiningUsecase_AttachOrder_Call {
+	return &MockDiningUsecase_AttachOrder_Call{Call: _e.mock.On("AttachOrder", ctx, branch, tableID, orderID)}
+}
+
+func (_c *MockDiningUsecase_AttachOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, tableID string, orderID string)) *MockDiningUsecase_AttachOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_AttachOrder_Call) Return(_a0 *dining.Session, _a1 error) *MockDiningUsecase_AttachOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_AttachOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, string, string) (*dining.Session, error)) *MockDiningUsecase_AttachOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CloseSession provides a mock function with given fields: ctx, branch, tableID
+func (_m *MockDiningUsecase) CloseSession(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Bill, error) {
+	ret := _m.Called(ctx, branch, tableID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSession")
+	}
+
+	var r0 *dining.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*dining.Bill, error)); ok {
+		return rf(ctx, branch, tableID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *dining.Bill); ok {
+		r0 = rf(ctx, branch, tableID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dining.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, tableID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_CloseSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseSession'
+type MockDiningUsecase_CloseSession_Call struct {
+	*mock.Call
+}
+
+// CloseSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - tableID string
+func (_e *MockDiningUsecase_Expecter) CloseSession(ctx interface{}, branch interface{}, tableID interface{}) *MockDiningUsecase_CloseSession_Call {
+	return &MockDiningUsecase_CloseSession_Call{Call: _e.mock.On("CloseSession", ctx, branch, tableID)}
+}
+
+func (_c *MockDiningUsecase_CloseSession_Call) Run(run func(ctx context.Context, branch tenant.Branch, tableID string)) *MockDiningUsecase_CloseSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_CloseSession_Call) Return(_a0 *dining.Bill, _a1 error) *MockDiningUsecase_CloseSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_CloseSession_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*dining.Bill, error)) *MockDiningUsecase_CloseSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBill provides a mock function with given fields: ctx, branch, tableID
+func (_m *MockDiningUsecase) GetBill(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Bill, error) {
+	ret := _m.Called(ctx, branch, tableID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBill")
+	}
+
+	var r0 *dining.Bill
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*dining.Bill, error)); ok {
+		return rf(ctx, branch, tableID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *dining.Bill); ok {
+		r0 = rf(ctx, branch, tableID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dining.Bill)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, tableID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_GetBill_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBill'
+type MockDiningUsecase_GetBill_Call struct {
+	*mock.Call
+}
+
+// GetBill is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - tableID string
+func (_e *MockDiningUsecase_Expecter) GetBill(ctx interface{}, branch interface{}, tableID interface{}) *MockDiningUsecase_GetBill_Call {
+	return &MockDiningUsecase_GetBill_Call{Call: _e.mock.On("GetBill", ctx, branch, tableID)}
+}
+
+func (_c *MockDiningUsecase_GetBill_Call) Run(run func(ctx context.Context, branch tenant.Branch, tableID string)) *MockDiningUsecase_GetBill_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_GetBill_Call) Return(_a0 *dining.Bill, _a1 error) *MockDiningUsecase_GetBill_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_GetBill_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*dining.Bill, error)) *MockDiningUsecase_GetBill_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTableOrders provides a mock function with given fields: ctx, branch, tableID
+func (_m *MockDiningUsecase) ListTableOrders(ctx context.Context, branch tenant.Branch, tableID string) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch, tableID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTableOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) ([]order.Order, error)); ok {
+		return rf(ctx, branch, tableID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) []order.Order); ok {
+		r0 = rf(ctx, branch, tableID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, tableID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_ListTableOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTableOrders'
+type MockDiningUsecase_ListTableOrders_Call struct {
+	*mock.Call
+}
+
+// ListTableOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - tableID string
+func (_e *MockDiningUsecase_Expecter) ListTableOrders(ctx interface{}, branch interface{}, tableID interface{}) *MockDiningUsecase_ListTableOrders_Call {
+	return &MockDiningUsecase_ListTableOrders_Call{Call: _e.mock.On("ListTableOrders", ctx, branch, tableID)}
+}
+
+func (_c *MockDiningUsecase_ListTableOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, tableID string)) *MockDiningUsecase_ListTableOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_ListTableOrders_Call) Return(_a0 []order.Order, _a1 error) *MockDiningUsecase_ListTableOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_ListTableOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) ([]order.Order, error)) *MockDiningUsecase_ListTableOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTables provides a mock function with given fields: ctx, branch
+func (_m *MockDiningUsecase) ListTables(ctx context.Context, branch tenant.Branch) ([]dining.Table, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTables")
+	}
+
+	var r0 []dining.Table
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]dining.Table, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []dining.Table); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dining.Table)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_ListTables_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTables'
+type MockDiningUsecase_ListTables_Call struct {
+	*mock.Call
+}
+
+// ListTables is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockDiningUsecase_Expecter) ListTables(ctx interface{}, branch interface{}) *MockDiningUsecase_ListTables_Call {
+	return &MockDiningUsecase_ListTables_Call{Call: _e.mock.On("ListTables", ctx, branch)}
+}
+
+func (_c *MockDiningUsecase_ListTables_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockDiningUsecase_ListTables_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_ListTables_Call) Return(_a0 []dining.Table, _a1 error) *MockDiningUsecase_ListTables_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_ListTables_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]dining.Table, error)) *MockDiningUsecase_ListTables_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenSession provides a mock function with given fields: ctx, branch, tableID
+func (_m *MockDiningUsecase) OpenSession(ctx context.Context, branch tenant.Branch, tableID string) (*dining.Session, error) {
+	ret := _m.Called(ctx, branch, tableID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenSession")
+	}
+
+	var r0 *dining.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*dining.Session, error)); ok {
+		return rf(ctx, branch, tableID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *dining.Session); ok {
+		r0 = rf(ctx, branch, tableID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dining.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, tableID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDiningUsecase_OpenSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenSession'
+type MockDiningUsecase_OpenSession_Call struct {
+	*mock.Call
+}
+
+// OpenSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - tableID string
+func (_e *MockDiningUsecase_Expecter) OpenSession(ctx interface{}, branch interface{}, tableID interface{}) *MockDiningUsecase_OpenSession_Call {
+	return &MockDiningUsecase_OpenSession_Call{Call: _e.mock.On("OpenSession", ctx, branch, tableID)}
+}
+
+func (_c *MockDiningUsecase_OpenSession_Call) Run(run func(ctx context.Context, branch tenant.Branch, tableID string)) *MockDiningUsecase_OpenSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockDiningUsecase_OpenSession_Call) Return(_a0 *dining.Session, _a1 error) *MockDiningUsecase_OpenSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDiningUsecase_OpenSession_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*dining.Session, error)) *MockDiningUsecase_OpenSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDiningUsecase creates a new instance of MockDiningUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDiningUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDiningUsecase {
+	mock := &MockDiningUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,461 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	inventory "challenge-yuno/internal/business/domain/inventory"
+
+	mock "github.com/stretchr/testify/mock"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+)
+
+// MockInventoryRepository is an autogenerated mock type for the InventoryRepository type
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+type MockInventoryRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInventoryRepository) EXPECT() *MockInventoryRepository_Expecter {
+	return &MockInventoryRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: ctx, branch, required
+func (_m *MockInventoryRepository) Consume(ctx context.Context, branch tenant.Branch, required map[string]int64) error {
+	ret := _m.Called(ctx, branch, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, map[string]int64) error); ok {
+		r0 = rf(ctx, branch, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInventoryRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type MockInventoryRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - required map[string]int64
+func (_e *MockInventoryRepository_Expecter) Consume(ctx interface{}, branch interface{}, required interface{}) *MockInventoryRepository_Consume_Call {
+	return &MockInventoryRepository_Consume_Call{Call: _e.mock.On("Consume", ctx, branch, required)}
+}
+
+func (_c *MockInventoryRepository_Consume_Call) Run(run func(ctx context.Context, branch tenant.Branch, required map[string]int64)) *MockInventoryRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(map[string]int64))
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_Consume_Call) Return(_a0 error) *MockInventoryRepository_Consume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInventoryRepository_Consume_Call) RunAndReturn(run func(context.Context, tenant.Branch, map[string]int64) error) *MockInventoryRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListIngredients provides a mock function with given fields: ctx, branch
+func (_m *MockInventoryRepository) ListIngredients(ctx context.Context, branch tenant.Branch) ([]inventory.Ingredient, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredients")
+	}
+
+	var r0 []inventory.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]inventory.Ingredient, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []inventory.Ingredient); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inventory.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryRepository_ListIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredients'
+type MockInventoryRepository_ListIngredients_Call struct {
+	*mock.Call
+}
+
+// ListIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockInventoryRepository_Expecter) ListIngredients(ctx interface{}, branch interface{}) *MockInventoryRepository_ListIngredients_Call {
+	return &MockInventoryRepository_ListIngredients_Call{Call: _e.mock.On("ListIngredients", ctx, branch)}
+}
+
+func (_c *MockInventoryRepository_ListIngredients_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockInventoryRepository_ListIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_ListIngredients_Call) Return(_a0 []inventory.Ingredient, _a1 error) *MockInventoryRepository_ListIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryRepository_ListIngredients_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]inventory.Ingredient, error)) *MockInventoryRepository_ListIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecipes provides a mock function with given fields: ctx, items
+func (_m *MockInventoryRepository) ListRecipes(ctx context.Context, items ...string) ([]inventory.Recipe, error) {
+	_va := make([]interface{}, len(items))
+	for _i := range items {
+		_va[_i] = items[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecipes")
+	}
+
+	var r0 []inventory.Recipe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) ([]inventory.Recipe, error)); ok {
+		return rf(ctx, items...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) []inventory.Recipe); ok {
+		r0 = rf(ctx, items...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inventory.Recipe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...string) error); ok {
+		r1 = rf(ctx, items...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryRepository_ListRecipes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecipes'
+type MockInventoryRepository_ListRecipes_Call struct {
+	*mock.Call
+}
+
+// ListRecipes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - items ...string
+func (_e *MockInventoryRepository_Expecter) ListRecipes(ctx interface{}, items ...interface{}) *MockInventoryRepository_ListRecipes_Call {
+	return &MockInventoryRepository_ListRecipes_Call{Call: _e.mock.On("ListRecipes",
+		append([]interface{}{ctx}, items...)...)}
+}
+
+func (_c *MockInventoryRepository_ListRecipes_Call) Run(run func(ctx context.Context, items ...string)) *MockInventoryRepository_ListRecipes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_ListRecipes_Call) Return(_a0 []inventory.Recipe, _a1 error) *MockInventoryRepository_ListRecipes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryRepository_ListRecipes_Call) RunAndReturn(run func(context.Context, ...string) ([]inventory.Recipe, error)) *MockInventoryRepository_ListRecipes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function with given fields: ctx, branch, required
+func (_m *MockInventoryRepository) Restore(ctx context.Context, branch tenant.Branch, required map[string]int64) error {
+	ret := _m.Called(ctx, branch, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, map[string]int64) error); ok {
+		r0 = rf(ctx, branch, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInventoryRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockInventoryRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - required map[string]int64
+func (_e *MockInventoryRepository_Expecter) Restore(ctx interface{}, branch interface{}, required interface{}) *MockInventoryRepository_Restore_Call {
+	return &MockInventoryRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, branch, required)}
+}
+
+func (_c *MockInventoryRepository_Restore_Call) Run(run func(ctx context.Context, branch tenant.Branch, required map[string]int64)) *MockInventoryRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(map[string]int64))
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_Restore_Call) Return(_a0 error) *MockInventoryRepository_Restore_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInventoryRepository_Restore_Call) RunAndReturn(run func(context.Context, tenant.Branch, map[string]int64) error) *MockInventoryRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIngredient provides a mock function with given fields: ctx, branch, ingredient
+func (_m *MockInventoryRepository) SetIngredient(ctx context.Context, branch tenant.Branch, ingredient inventory.Ingredient) (*inventory.Ingredient, error) {
+	ret := _m.Called(ctx, branch, ingredient)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIngredient")
+	}
+
+	var r0 *inventory.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, inventory.Ingredient) (*inventory.Ingredient, error)); ok {
+		return rf(ctx, branch, ingredient)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, inventory.Ingredient) *inventory.Ingredient); ok {
+		r0 = rf(ctx, branch, ingredient)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*inventory.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, inventory.Ingredient) error); ok {
+		r1 = rf(ctx, branch, ingredient)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryRepository_SetIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIngredient'
+type MockInventoryRepository_SetIngredient_Call struct {
+	*mock.Call
+}
+
+// SetIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - ingredient inventory.Ingredient
+func (_e *MockInventoryRepository_Expecter) SetIngredient(ctx interface{}, branch interface{}, ingredient interface{}) *MockInventoryRepository_SetIngredient_Call {
+	return &MockInventoryRepository_SetIngredient_Call{Call: _e.mock.On("SetIngredient", ctx, branch, ingredient)}
+}
+
+func (_c *MockInventoryRepository_SetIngredient_Call) Run(run func(ctx context.Context, branch tenant.Branch, ingredient inventory.Ingredient)) *MockInventoryRepository_SetIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(inventory.Ingredient))
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_SetIngredient_Call) Return(_a0 *inventory.Ingredient, _a1 error) *MockInventoryRepository_SetIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryRepository_SetIngredient_Call) RunAndReturn(run func(context.Context, tenant.Branch, inventory.Ingredient) (*inventory.Ingredient, error)) *MockInventoryRepository_SetIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRecipe provides a mock function with given fields: ctx, recipe
+func (_m *MockInventoryRepository) SetRecipe(ctx context.Context, recipe inventory.Recipe) (*inventory.Recipe, error) {
+	ret := _m.Called(ctx, recipe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRecipe")
+	}
+
+	var r0 *inventory.Recipe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, inventory.Recipe) (*inventory.Recipe, error)); ok {
+		return rf(ctx, recipe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, inventory.Recipe) *inventory.Recipe); ok {
+		r0 = rf(ctx, recipe)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*inventory.Recipe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, inventory.Recipe) error); ok {
+		r1 = rf(ctx, recipe)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryRepository_SetRecipe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRecipe'
+type MockInventoryRepository_SetRecipe_Call struct {
+	*mock.Call
+}
+
+// SetRecipe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipe inventory.Recipe
+func (_e *MockInventoryRepository_Expecter) SetRecipe(ctx interface{}, recipe interface{}) *MockInventoryRepository_SetRecipe_Call {
+	return &MockInventoryRepository_SetRecipe_Call{Call: _e.mock.On("SetRecipe", ctx, recipe)}
+}
+
+func (_c *MockInventoryRepository_SetRecipe_Call) Run(run func(ctx context.Context, recipe inventory.Recipe)) *MockInventoryRepository_SetRecipe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(inventory.Recipe))
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_SetRecipe_Call) Return(_a0 *inventory.Recipe, _a1 error) *MockInventoryRepository_SetRecipe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryRepository_SetRecipe_Call) RunAndReturn(run func(context.Context, inventory.Recipe) (*inventory.Recipe, error)) *MockInventoryRepository_SetRecipe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stock provides a mock function with given fields: ctx, branch, ingredients
+func (_m *MockInventoryRepository) Stock(ctx context.Context, branch tenant.Branch, ingredients ...string) (map[string]int64, error) {
+	_va := make([]interface{}, len(ingredients))
+	for _i := range ingredients {
+		_va[_i] = ingredients[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, branch)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stock")
+	}
+
+	var r0 map[string]int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, ...string) (map[string]int64, error)); ok {
+		return rf(ctx, branch, ingredients...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, ...string) map[string]int64); ok {
+		r0 = rf(ctx, branch, ingredients...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, ...string) error); ok {
+		r1 = rf(ctx, branch, ingredients...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryRepository_Stock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stock'
+type MockInventoryRepository_Stock_Call struct {
+	*mock.Call
+}
+
+// Stock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - ingredients ...string
+func (_e *MockInventoryRepository_Expecter) Stock(ctx interface{}, branch interface{}, ingredients ...interface{}) *MockInventoryRepository_Stock_Call {
+	return &MockInventoryRepository_Stock_Call{Call: _e.mock.On("Stock",
+		append([]interface{}{ctx, branch}, ingredients...)...)}
+}
+
+func (_c *MockInventoryRepository_Stock_Call) Run(run func(ctx context.Context, branch tenant.Branch, ingredients ...string)) *MockInventoryRepository_Stock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), args[1].(tenant.Branch), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockInventoryRepository_Stock_Call) Return(_a0 map[string]int64, _a1 error) *MockInventoryRepository_Stock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryRepository_Stock_Call) RunAndReturn(run func(context.Context, tenant.Branch, ...string) (map[string]int64, error)) *MockInventoryRepository_Stock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInventoryRepository creates a new instance of MockInventoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInventoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInventoryRepository {
+	mock := &MockInventoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
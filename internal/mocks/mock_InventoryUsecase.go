@@ -0,0 +1,337 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	inventory "challenge-yuno/internal/business/domain/inventory"
+
+	menu "challenge-yuno/internal/business/domain/menu"
+
+	mock "github.com/stretchr/testify/mock"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+)
+
+// MockInventoryUsecase is an autogenerated mock type for the InventoryUsecase type
+type MockInventoryUsecase struct {
+	mock.Mock
+}
+
+type MockInventoryUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInventoryUsecase) EXPECT() *MockInventoryUsecase_Expecter {
+	return &MockInventoryUsecase_Expecter{mock: &_m.Mock}
+}
+
+// ListIngredients provides a mock function with given fields: ctx, branch
+func (_m *MockInventoryUsecase) ListIngredients(ctx context.Context, branch tenant.Branch) ([]inventory.Ingredient, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListIngredients")
+	}
+
+	var r0 []inventory.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]inventory.Ingredient, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []inventory.Ingredient); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inventory.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryUsecase_ListIngredients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListIngredients'
+type MockInventoryUsecase_ListIngredients_Call struct {
+	*mock.Call
+}
+
+// ListIngredients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockInventoryUsecase_Expecter) ListIngredients(ctx interface{}, branch interface{}) *MockInventoryUsecase_ListIngredients_Call {
+	return &MockInventoryUsecase_ListIngredients_Call{Call: _e.mock.On("ListIngredients", ctx, branch)}
+}
+
+func (_c *MockInventoryUsecase_ListIngredients_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockInventoryUsecase_ListIngredients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockInventoryUsecase_ListIngredients_Call) Return(_a0 []inventory.Ingredient, _a1 error) *MockInventoryUsecase_ListIngredients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryUsecase_ListIngredients_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]inventory.Ingredient, error)) *MockInventoryUsecase_ListIngredients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMenu provides a mock function with given fields: ctx, branch
+func (_m *MockInventoryUsecase) ListMenu(ctx context.Context, branch tenant.Branch) ([]menu.Item, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMenu")
+	}
+
+	var r0 []menu.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]menu.Item, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []menu.Item); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]menu.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryUsecase_ListMenu_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMenu'
+type MockInventoryUsecase_ListMenu_Call struct {
+	*mock.Call
+}
+
+// ListMenu is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockInventoryUsecase_Expecter) ListMenu(ctx interface{}, branch interface{}) *MockInventoryUsecase_ListMenu_Call {
+	return &MockInventoryUsecase_ListMenu_Call{Call: _e.mock.On("ListMenu", ctx, branch)}
+}
+
+func (_c *MockInventoryUsecase_ListMenu_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockInventoryUsecase_ListMenu_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockInventoryUsecase_ListMenu_Call) Return(_a0 []menu.Item, _a1 error) *MockInventoryUsecase_ListMenu_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryUsecase_ListMenu_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]menu.Item, error)) *MockInventoryUsecase_ListMenu_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecipes provides a mock function with given fields: ctx
+func (_m *MockInventoryUsecase) ListRecipes(ctx context.Context) ([]inventory.Recipe, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecipes")
+	}
+
+	var r0 []inventory.Recipe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]inventory.Recipe, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []inventory.Recipe); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]inventory.Recipe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryUsecase_ListRecipes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecipes'
+type MockInventoryUsecase_ListRecipes_Call struct {
+	*mock.Call
+}
+
+// ListRecipes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockInventoryUsecase_Expecter) ListRecipes(ctx interface{}) *MockInventoryUsecase_ListRecipes_Call {
+	return &MockInventoryUsecase_ListRecipes_Call{Call: _e.mock.On("ListRecipes", ctx)}
+}
+
+func (_c *MockInventoryUsecase_ListRecipes_Call) Run(run func(ctx context.Context)) *MockInventoryUsecase_ListRecipes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockInventoryUsecase_ListRecipes_Call) Return(_a0 []inventory.Recipe, _a1 error) *MockInventoryUsecase_ListRecipes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryUsecase_ListRecipes_Call) RunAndReturn(run func(context.Context) ([]inventory.Recipe, error)) *MockInventoryUsecase_ListRecipes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetIngredient provides a mock function with given fields: ctx, branch, ingredient
+func (_m *MockInventoryUsecase) SetIngredient(ctx context.Context, branch tenant.Branch, ingredient inventory.Ingredient) (*inventory.Ingredient, error) {
+	ret := _m.Called(ctx, branch, ingredient)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetIngredient")
+	}
+
+	var r0 *inventory.Ingredient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, inventory.Ingredient) (*inventory.Ingredient, error)); ok {
+		return rf(ctx, branch, ingredient)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, inventory.Ingredient) *inventory.Ingredient); ok {
+		r0 = rf(ctx, branch, ingredient)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*inventory.Ingredient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, inventory.Ingredient) error); ok {
+		r1 = rf(ctx, branch, ingredient)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryUsecase_SetIngredient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetIngredient'
+type MockInventoryUsecase_SetIngredient_Call struct {
+	*mock.Call
+}
+
+// SetIngredient is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - ingredient inventory.Ingredient
+func (_e *MockInventoryUsecase_Expecter) SetIngredient(ctx interface{}, branch interface{}, ingredient interface{}) *MockInventoryUsecase_SetIngredient_Call {
+	return &MockInventoryUsecase_SetIngredient_Call{Call: _e.mock.On("SetIngredient", ctx, branch, ingredient)}
+}
+
+func (_c *MockInventoryUsecase_SetIngredient_Call) Run(run func(ctx context.Context, branch tenant.Branch, ingredient inventory.Ingredient)) *MockInventoryUsecase_SetIngredient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(inventory.Ingredient))
+	})
+	return _c
+}
+
+func (_c *MockInventoryUsecase_SetIngredient_Call) Return(_a0 *inventory.Ingredient, _a1 error) *MockInventoryUsecase_SetIngredient_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryUsecase_SetIngredient_Call) RunAndReturn(run func(context.Context, tenant.Branch, inventory.Ingredient) (*inventory.Ingredient, error)) *MockInventoryUsecase_SetIngredient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRecipe provides a mock function with given fields: ctx, recipe
+func (_m *MockInventoryUsecase) SetRecipe(ctx context.Context, recipe inventory.Recipe) (*inventory.Recipe, error) {
+	ret := _m.Called(ctx, recipe)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRecipe")
+	}
+
+	var r0 *inventory.Recipe
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, inventory.Recipe) (*inventory.Recipe, error)); ok {
+		return rf(ctx, recipe)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, inventory.Recipe) *inventory.Recipe); ok {
+		r0 = rf(ctx, recipe)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*inventory.Recipe)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, inventory.Recipe) error); ok {
+		r1 = rf(ctx, recipe)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInventoryUsecase_SetRecipe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRecipe'
+type MockInventoryUsecase_SetRecipe_Call struct {
+	*mock.Call
+}
+
+// SetRecipe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recipe inventory.Recipe
+func (_e *MockInventoryUsecase_Expecter) SetRecipe(ctx interface{}, recipe interface{}) *MockInventoryUsecase_SetRecipe_Call {
+	return &MockInventoryUsecase_SetRecipe_Call{Call: _e.mock.On("SetRecipe", ctx, recipe)}
+}
+
+func (_c *MockInventoryUsecase_SetRecipe_Call) Run(run func(ctx context.Context, recipe inventory.Recipe)) *MockInventoryUsecase_SetRecipe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(inventory.Recipe))
+	})
+	return _c
+}
+
+func (_c *MockInventoryUsecase_SetRecipe_Call) Return(_a0 *inventory.Recipe, _a1 error) *MockInventoryUsecase_SetRecipe_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInventoryUsecase_SetRecipe_Call) RunAndReturn(run func(context.Context, inventory.Recipe) (*inventory.Recipe, error)) *MockInventoryUsecase_SetRecipe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInventoryUsecase creates a new instance of MockInventoryUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInventoryUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInventoryUsecase {
+	mock := &MockInventoryUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
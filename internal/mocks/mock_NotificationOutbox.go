@@ -0,0 +1,305 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	notification "challenge-yuno/internal/business/domain/notification"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+
+	time "time"
+)
+
+// MockNotificationOutbox is an autogenerated mock type for the NotificationOutbox type
+type MockNotificationOutbox struct {
+	mock.Mock
+}
+
+type MockNotificationOutbox_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationOutbox) EXPECT() *MockNotificationOutbox_Expecter {
+	return &MockNotificationOutbox_Expecter{mock: &_m.Mock}
+}
+
+// Backlog provides a mock function with given fields: ctx
+func (_m *MockNotificationOutbox) Backlog(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Backlog")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationOutbox_Backlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Backlog'
+type MockNotificationOutbox_Backlog_Call struct {
+	*mock.Call
+}
+
+// Backlog is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockNotificationOutbox_Expecter) Backlog(ctx interface{}) *MockNotificationOutbox_Backlog_Call {
+	return &MockNotificationOutbox_Backlog_Call{Call: _e.mock.On("Backlog", ctx)}
+}
+
+func (_c *MockNotificationOutbox_Backlog_Call) Run(run func(ctx context.Context)) *MockNotificationOutbox_Backlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockNotificationOutbox_Backlog_Call) Return(_a0 int, _a1 error) *MockNotificationOutbox_Backlog_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationOutbox_Backlog_Call) RunAndReturn(run func(context.Context) (int, error)) *MockNotificationOutbox_Backlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Claim provides a mock function with given fields: ctx, limit, lease
+func (_m *MockNotificationOutbox) Claim(ctx context.Context, limit int, lease time.Duration) ([]notification.Message, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 []notification.Message
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]notification.Message, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []notification.Message); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]notification.Message)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotificationOutbox_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockNotificationOutbox_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *MockNotificationOutbox_Expecter) Claim(ctx interface{}, limit interface{}, lease interface{}) *MockNotificationOutbox_Claim_Call {
+	return &MockNotificationOutbox_Claim_Call{Call: _e.mock.On("Claim", ctx, limit, lease)}
+}
+
+func (_c *MockNotificationOutbox_Claim_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *MockNotificationOutbox_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockNotificationOutbox_Claim_Call) Return(_a0 []notification.Message, _a1 error) *MockNotificationOutbox_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotificationOutbox_Claim_Call) RunAndReturn(run func(context.Context, int, time.Duration) ([]notification.Message, error)) *MockNotificationOutbox_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockNotificationOutbox) Enqueue(ctx context.Context, branch tenant.Branch, _a2 *order.Order) error {
+	ret := _m.Called(ctx, branch, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, *order.Order) error); ok {
+		r0 = rf(ctx, branch, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationOutbox_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockNotificationOutbox_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 *order.Order
+func (_e *MockNotificationOutbox_Expecter) Enqueue(ctx interface{}, branch interface{}, _a2 interface{}) *MockNotificationOutbox_Enqueue_Call {
+	return &MockNotificationOutbox_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, branch, _a2)}
+}
+
+func (_c *MockNotificationOutbox_Enqueue_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 *order.Order)) *MockNotificationOutbox_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(*order.Order))
+	})
+	return _c
+}
+
+func (_c *MockNotificationOutbox_Enqueue_Call) Return(_a0 error) *MockNotificationOutbox_Enqueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationOutbox_Enqueue_Call) RunAndReturn(run func(context.Context, tenant.Branch, *order.Order) error) *MockNotificationOutbox_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, messageID, attempts, nextAttemptAt, cause
+func (_m *MockNotificationOutbox) MarkFailed(ctx context.Context, messageID string, attempts int, nextAttemptAt *time.Time, cause error) error {
+	ret := _m.Called(ctx, messageID, attempts, nextAttemptAt, cause)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *time.Time, error) error); ok {
+		r0 = rf(ctx, messageID, attempts, nextAttemptAt, cause)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationOutbox_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockNotificationOutbox_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+//   - attempts int
+//   - nextAttemptAt *time.Time
+//   - cause error
+func (_e *MockNotificationOutbox_Expecter) MarkFailed(ctx interface{}, messageID interface{}, attempts interface{}, nextAttemptAt interface{}, cause interface{}) *MockNotificationOutbox_MarkFailed_Call {
+	return &MockNotificationOutbox_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, messageID, attempts, nextAttemptAt, cause)}
+}
+
+func (_c *MockNotificationOutbox_MarkFailed_Call) Run(run func(ctx context.Context, messageID string, attempts int, nextAttemptAt *time.Time, cause error)) *MockNotificationOutbox_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(*time.Time), args[4].(error))
+	})
+	return _c
+}
+
+func (_c *MockNotificationOutbox_MarkFailed_Call) Return(_a0 error) *MockNotificationOutbox_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationOutbox_MarkFailed_Call) RunAndReturn(run func(context.Context, string, int, *time.Time, error) error) *MockNotificationOutbox_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSent provides a mock function with given fields: ctx, messageID
+func (_m *MockNotificationOutbox) MarkSent(ctx context.Context, messageID string) error {
+	ret := _m.Called(ctx, messageID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, messageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationOutbox_MarkSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSent'
+type MockNotificationOutbox_MarkSent_Call struct {
+	*mock.Call
+}
+
+// MarkSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - messageID string
+func (_e *MockNotificationOutbox_Expecter) MarkSent(ctx interface{}, messageID interface{}) *MockNotificationOutbox_MarkSent_Call {
+	return &MockNotificationOutbox_MarkSent_Call{Call: _e.mock.On("MarkSent", ctx, messageID)}
+}
+
+func (_c *MockNotificationOutbox_MarkSent_Call) Run(run func(ctx context.Context, messageID string)) *MockNotificationOutbox_MarkSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockNotificationOutbox_MarkSent_Call) Return(_a0 error) *MockNotificationOutbox_MarkSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationOutbox_MarkSent_Call) RunAndReturn(run func(context.Context, string) error) *MockNotificationOutbox_MarkSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationOutbox creates a new instance of MockNotificationOutbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationOutbox {
+	mock := &MockNotificationOutbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
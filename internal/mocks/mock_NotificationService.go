@@ -0,0 +1,85 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+)
+
+// MockNotificationService is an autogenerated mock type for the INotificationService type
+type MockNotificationService struct {
+	mock.Mock
+}
+
+type MockNotificationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationService) EXPECT() *MockNotificationService_Expecter {
+	return &MockNotificationService_Expecter{mock: &_m.Mock}
+}
+
+// SendNotification provides a mock function with given fields: ctx, _a1
+func (_m *MockNotificationService) SendNotification(ctx context.Context, _a1 *order.Order) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *order.Order) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotificationService_SendNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendNotification'
+type MockNotificationService_SendNotification_Call struct {
+	*mock.Call
+}
+
+// SendNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *order.Order
+func (_e *MockNotificationService_Expecter) SendNotification(ctx interface{}, _a1 interface{}) *MockNotificationService_SendNotification_Call {
+	return &MockNotificationService_SendNotification_Call{Call: _e.mock.On("SendNotification", ctx, _a1)}
+}
+
+func (_c *MockNotificationService_SendNotification_Call) Run(run func(ctx context.Context, _a1 *order.Order)) *MockNotificationService_SendNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*order.Order))
+	})
+	return _c
+}
+
+func (_c *MockNotificationService_SendNotification_Call) Return(_a0 error) *MockNotificationService_SendNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotificationService_SendNotification_Call) RunAndReturn(run func(context.Context, *order.Order) error) *MockNotificationService_SendNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationService creates a new instance of MockNotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationService {
+	mock := &MockNotificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
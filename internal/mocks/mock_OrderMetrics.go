@@ -0,0 +1,138 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	order "challenge-yuno/internal/business/domain/order"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOrderMetrics is an autogenerated mock type for the OrderMetrics type
+type MockOrderMetrics struct {
+	mock.Mock
+}
+
+type MockOrderMetrics_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrderMetrics) EXPECT() *MockOrderMetrics_Expecter {
+	return &MockOrderMetrics_Expecter{mock: &_m.Mock}
+}
+
+// OrderCreated provides a mock function with given fields: _a0
+func (_m *MockOrderMetrics) OrderCreated(_a0 *order.Order) {
+	_m.Called(_a0)
+}
+
+// MockOrderMetrics_OrderCreated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderCreated'
+type MockOrderMetrics_OrderCreated_Call struct {
+	*mock.Call
+}
+
+// OrderCreated is a helper method to define mock.On call
+//   - _a0 *order.Order
+func (_e *MockOrderMetrics_Expecter) OrderCreated(_a0 interface{}) *MockOrderMetrics_OrderCreated_Call {
+	return &MockOrderMetrics_OrderCreated_Call{Call: _e.mock.On("OrderCreated", _a0)}
+}
+
+func (_c *MockOrderMetrics_OrderCreated_Call) Run(run func(_a0 *order.Order)) *MockOrderMetrics_OrderCreated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*order.Order))
+	})
+	return _c
+}
+
+func (_c *MockOrderMetrics_OrderCreated_Call) Return() *MockOrderMetrics_OrderCreated_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockOrderMetrics_OrderCreated_Call) RunAndReturn(run func(*order.Order)) *MockOrderMetrics_OrderCreated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OrderPrepared provides a mock function with given fields: _a0, duration
+func (_m *MockOrderMetrics) OrderPrepared(_a0 *order.Order, duration time.Duration) {
+	_m.Called(_a0, duration)
+}
+
+// MockOrderMetrics_OrderPrepared_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OrderPrepared'
+type MockOrderMetrics_OrderPrepared_Call struct {
+	*mock.Call
+}
+
+// OrderPrepared is a helper method to define mock.On call
+//   - _a0 *order.Order
+//   - duration time.Duration
+func (_e *MockOrderMetrics_Expecter) OrderPrepared(_a0 interface{}, duration interface{}) *MockOrderMetrics_OrderPrepared_Call {
+	return &MockOrderMetrics_OrderPrepared_Call{Call: _e.mock.On("OrderPrepared", _a0, duration)}
+}
+
+func (_c *MockOrderMetrics_OrderPrepared_Call) Run(run func(_a0 *order.Order, duration time.Duration)) *MockOrderMetrics_OrderPrepared_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*order.Order), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockOrderMetrics_OrderPrepared_Call) Return() *MockOrderMetrics_OrderPrepared_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockOrderMetrics_OrderPrepared_Call) RunAndReturn(run func(*order.Order, time.Duration)) *MockOrderMetrics_OrderPrepared_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OverdueOrders provides a mock function with given fields: status, countByBranch
+func (_m *MockOrderMetrics) OverdueOrders(status order.Status, countByBranch map[string]int) {
+	_m.Called(status, countByBranch)
+}
+
+// MockOrderMetrics_OverdueOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OverdueOrders'
+type MockOrderMetrics_OverdueOrders_Call struct {
+	*mock.Call
+}
+
+// OverdueOrders is a helper method to define mock.On call
+//   - status order.Status
+//   - countByBranch map[string]int
+func (_e *MockOrderMetrics_Expecter) OverdueOrders(status interface{}, countByBranch interface{}) *MockOrderMetrics_OverdueOrders_Call {
+	return &MockOrderMetrics_OverdueOrders_Call{Call: _e.mock.On("OverdueOrders", status, countByBranch)}
+}
+
+func (_c *MockOrderMetrics_OverdueOrders_Call) Run(run func(status order.Status, countByBranch map[string]int)) *MockOrderMetrics_OverdueOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(order.Status), args[1].(map[string]int))
+	})
+	return _c
+}
+
+func (_c *MockOrderMetrics_OverdueOrders_Call) Return() *MockOrderMetrics_OverdueOrders_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockOrderMetrics_OverdueOrders_Call) RunAndReturn(run func(order.Status, map[string]int)) *MockOrderMetrics_OverdueOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOrderMetrics creates a new instance of MockOrderMetrics. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderMetrics(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderMetrics {
+	mock := &MockOrderMetrics{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
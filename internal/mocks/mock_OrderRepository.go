@@ -3,9 +3,13 @@
 package mocks
 
 import (
-	order "challenge-yuno/internal/business/domain/order"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
 )
 
 // MockOrderRepository is an autogenerated mock type for the KVSOrderRepository type
@@ -21,9 +25,9 @@ func (_m *MockOrderRepository) EXPECT() *MockOrderRepository_Expecter {
 	return &MockOrderRepository_Expecter{mock: &_m.Mock}
 }
 
-// AddOrder provides a mock function with given fields: _a0
-func (_m *MockOrderRepository) AddOrder(_a0 order.Order) (*order.Order, error) {
-	ret := _m.Called(_a0)
+// AddOrder provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockOrderRepository) AddOrder(ctx context.Context, branch tenant.Branch, _a2 order.Order) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddOrder")
@@ -31,19 +35,19 @@ func (_m *MockOrderRepository) AddOrder(_a0 order.Order) (*order.Order, error) {
 
 	var r0 *order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(order.Order) (*order.Order, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(order.Order) *order.Order); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(order.Order) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order) error); ok {
+		r1 = rf(ctx, branch, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +61,16 @@ type MockOrderRepository_AddOrder_Call struct {
 }
 
 // AddOrder is a helper method to define mock.On call
-//   - _a0 order.Order
-func (_e *MockOrderRepository_Expecter) AddOrder(_a0 interface{}) *MockOrderRepository_AddOrder_Call {
-	return &MockOrderRepository_AddOrder_Call{Call: _e.mock.On("AddOrder", _a0)}
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+func (_e *MockOrderRepository_Expecter) AddOrder(ctx interface{}, branch interface{}, _a2 interface{}) *MockOrderRepository_AddOrder_Call {
+	return &MockOrderRepository_AddOrder_Call{Call: _e.mock.On("AddOrder", ctx, branch, _a2)}
 }
 
-func (_c *MockOrderRepository_AddOrder_Call) Run(run func(_a0 order.Order)) *MockOrderRepository_AddOrder_Call {
+func (_c *MockOrderRepository_AddOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order)) *MockOrderRepository_AddOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(order.Order))
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order))
 	})
 	return _c
 }
@@ -74,14 +80,63 @@ func (_c *MockOrderRepository_AddOrder_Call) Return(_a0 *order.Order, _a1 error)
 	return _c
 }
 
-func (_c *MockOrderRepository_AddOrder_Call) RunAndReturn(run func(order.Order) (*order.Order, error)) *MockOrderRepository_AddOrder_Call {
+func (_c *MockOrderRepository_AddOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order) (*order.Order, error)) *MockOrderRepository_AddOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOrder provides a mock function with given fields: orderID
-func (_m *MockOrderRepository) GetOrder(orderID string) (*order.Order, error) {
-	ret := _m.Called(orderID)
+// GetAllOrders provides a mock function with given fields: ctx, branch
+func (_m *MockOrderRepository) GetAllOrders(ctx context.Context, branch tenant.Branch) []order.Order {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllOrders")
+	}
+
+	var r0 []order.Order
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []order.Order); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	return r0
+}
+
+// MockOrderRepository_GetAllOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllOrders'
+type MockOrderRepository_GetAllOrders_Call struct {
+	*mock.Call
+}
+
+// GetAllOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockOrderRepository_Expecter) GetAllOrders(ctx interface{}, branch interface{}) *MockOrderRepository_GetAllOrders_Call {
+	return &MockOrderRepository_GetAllOrders_Call{Call: _e.mock.On("GetAllOrders", ctx, branch)}
+}
+
+func (_c *MockOrderRepository_GetAllOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockOrderRepository_GetAllOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockOrderRepository_GetAllOrders_Call) Return(_a0 []order.Order) *MockOrderRepository_GetAllOrders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOrderRepository_GetAllOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch) []order.Order) *MockOrderRepository_GetAllOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrder provides a mock function with given fields: ctx, branch, orderID
+func (_m *MockOrderRepository) GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, orderID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOrder")
@@ -89,19 +144,19 @@ func (_m *MockOrderRepository) GetOrder(orderID string) (*order.Order, error) {
 
 	var r0 *order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*order.Order, error)); ok {
-		return rf(orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*order.Order, error)); ok {
+		return rf(ctx, branch, orderID)
 	}
-	if rf, ok := ret.Get(0).(func(string) *order.Order); ok {
-		r0 = rf(orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *order.Order); ok {
+		r0 = rf(ctx, branch, orderID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(orderID)
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,14 +170,16 @@ type MockOrderRepository_GetOrder_Call struct {
 }
 
 // GetOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
 //   - orderID string
-func (_e *MockOrderRepository_Expecter) GetOrder(orderID interface{}) *MockOrderRepository_GetOrder_Call {
-	return &MockOrderRepository_GetOrder_Call{Call: _e.mock.On("GetOrder", orderID)}
+func (_e *MockOrderRepository_Expecter) GetOrder(ctx interface{}, branch interface{}, orderID interface{}) *MockOrderRepository_GetOrder_Call {
+	return &MockOrderRepository_GetOrder_Call{Call: _e.mock.On("GetOrder", ctx, branch, orderID)}
 }
 
-func (_c *MockOrderRepository_GetOrder_Call) Run(run func(orderID string)) *MockOrderRepository_GetOrder_Call {
+func (_c *MockOrderRepository_GetOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, orderID string)) *MockOrderRepository_GetOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
 	})
 	return _c
 }
@@ -132,14 +189,14 @@ func (_c *MockOrderRepository_GetOrder_Call) Return(_a0 *order.Order, _a1 error)
 	return _c
 }
 
-func (_c *MockOrderRepository_GetOrder_Call) RunAndReturn(run func(string) (*order.Order, error)) *MockOrderRepository_GetOrder_Call {
+func (_c *MockOrderRepository_GetOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*order.Order, error)) *MockOrderRepository_GetOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListActiveOrders provides a mock function with given fields:
-func (_m *MockOrderRepository) ListActiveOrders() ([]order.Order, error) {
-	ret := _m.Called()
+// ListActiveOrders provides a mock function with given fields: ctx, branch
+func (_m *MockOrderRepository) ListActiveOrders(ctx context.Context, branch tenant.Branch) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListActiveOrders")
@@ -147,19 +204,19 @@ func (_m *MockOrderRepository) ListActiveOrders() ([]order.Order, error) {
 
 	var r0 []order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]order.Order, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]order.Order, error)); ok {
+		return rf(ctx, branch)
 	}
-	if rf, ok := ret.Get(0).(func() []order.Order); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []order.Order); ok {
+		r0 = rf(ctx, branch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -173,13 +230,15 @@ type MockOrderRepository_ListActiveOrders_Call struct {
 }
 
 // ListActiveOrders is a helper method to define mock.On call
-func (_e *MockOrderRepository_Expecter) ListActiveOrders() *MockOrderRepository_ListActiveOrders_Call {
-	return &MockOrderRepository_ListActiveOrders_Call{Call: _e.mock.On("ListActiveOrders")}
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockOrderRepository_Expecter) ListActiveOrders(ctx interface{}, branch interface{}) *MockOrderRepository_ListActiveOrders_Call {
+	return &MockOrderRepository_ListActiveOrders_Call{Call: _e.mock.On("ListActiveOrders", ctx, branch)}
 }
 
-func (_c *MockOrderRepository_ListActiveOrders_Call) Run(run func()) *MockOrderRepository_ListActiveOrders_Call {
+func (_c *MockOrderRepository_ListActiveOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockOrderRepository_ListActiveOrders_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(tenant.Branch))
 	})
 	return _c
 }
@@ -189,14 +248,14 @@ func (_c *MockOrderRepository_ListActiveOrders_Call) Return(_a0 []order.Order, _
 	return _c
 }
 
-func (_c *MockOrderRepository_ListActiveOrders_Call) RunAndReturn(run func() ([]order.Order, error)) *MockOrderRepository_ListActiveOrders_Call {
+func (_c *MockOrderRepository_ListActiveOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]order.Order, error)) *MockOrderRepository_ListActiveOrders_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateOrderStatus provides a mock function with given fields: orderID, status
-func (_m *MockOrderRepository) UpdateOrderStatus(orderID string, status order.Status) (*order.Order, error) {
-	ret := _m.Called(orderID, status)
+// UpdateOrderStatus provides a mock function with given fields: ctx, branch, update
+func (_m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, branch tenant.Branch, update order.StatusUpdate) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateOrderStatus")
@@ -204,19 +263,19 @@ func (_m *MockOrderRepository) UpdateOrderStatus(orderID string, status order.St
 
 	var r0 *order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, order.Status) (*order.Order, error)); ok {
-		return rf(orderID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.StatusUpdate) (*order.Order, error)); ok {
+		return rf(ctx, branch, update)
 	}
-	if rf, ok := ret.Get(0).(func(string, order.Status) *order.Order); ok {
-		r0 = rf(orderID, status)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.StatusUpdate) *order.Order); ok {
+		r0 = rf(ctx, branch, update)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, order.Status) error); ok {
-		r1 = rf(orderID, status)
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.StatusUpdate) error); ok {
+		r1 = rf(ctx, branch, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -230,15 +289,16 @@ type MockOrderRepository_UpdateOrderStatus_Call struct {
 }
 
 // UpdateOrderStatus is a helper method to define mock.On call
-//   - orderID string
-//   - status order.Status
-func (_e *MockOrderRepository_Expecter) UpdateOrderStatus(orderID interface{}, status interface{}) *MockOrderRepository_UpdateOrderStatus_Call {
-	return &MockOrderRepository_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", orderID, status)}
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - update order.StatusUpdate
+func (_e *MockOrderRepository_Expecter) UpdateOrderStatus(ctx interface{}, branch interface{}, update interface{}) *MockOrderRepository_UpdateOrderStatus_Call {
+	return &MockOrderRepository_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, branch, update)}
 }
 
-func (_c *MockOrderRepository_UpdateOrderStatus_Call) Run(run func(orderID string, status order.Status)) *MockOrderRepository_UpdateOrderStatus_Call {
+func (_c *MockOrderRepository_UpdateOrderStatus_Call) Run(run func(ctx context.Context, branch tenant.Branch, update order.StatusUpdate)) *MockOrderRepository_UpdateOrderStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(order.Status))
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.StatusUpdate))
 	})
 	return _c
 }
@@ -248,7 +308,7 @@ func (_c *MockOrderRepository_UpdateOrderStatus_Call) Return(_a0 *order.Order, _
 	return _c
 }
 
-func (_c *MockOrderRepository_UpdateOrderStatus_Call) RunAndReturn(run func(string, order.Status) (*order.Order, error)) *MockOrderRepository_UpdateOrderStatus_Call {
+func (_c *MockOrderRepository_UpdateOrderStatus_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.StatusUpdate) (*order.Order, error)) *MockOrderRepository_UpdateOrderStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,9 +3,14 @@
 package mocks
 
 import (
-	order "challenge-yuno/internal/business/domain/order"
+	interfaces "challenge-yuno/internal/business/interfaces"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
 )
 
 // MockOrderUsecase is an autogenerated mock type for the OrderUsecase type
@@ -21,9 +26,9 @@ func (_m *MockOrderUsecase) EXPECT() *MockOrderUsecase_Expecter {
 	return &MockOrderUsecase_Expecter{mock: &_m.Mock}
 }
 
-// AddOrder provides a mock function with given fields: _a0
-func (_m *MockOrderUsecase) AddOrder(_a0 order.Order) (*order.Order, error) {
-	ret := _m.Called(_a0)
+// AddOrder provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockOrderUsecase) AddOrder(ctx context.Context, branch tenant.Branch, _a2 order.Order) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddOrder")
@@ -31,19 +36,19 @@ func (_m *MockOrderUsecase) AddOrder(_a0 order.Order) (*order.Order, error) {
 
 	var r0 *order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(order.Order) (*order.Order, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2)
 	}
-	if rf, ok := ret.Get(0).(func(order.Order) *order.Order); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(order.Order) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order) error); ok {
+		r1 = rf(ctx, branch, _a2)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,14 +62,16 @@ type MockOrderUsecase_AddOrder_Call struct {
 }
 
 // AddOrder is a helper method to define mock.On call
-//   - _a0 order.Order
-func (_e *MockOrderUsecase_Expecter) AddOrder(_a0 interface{}) *MockOrderUsecase_AddOrder_Call {
-	return &MockOrderUsecase_AddOrder_Call{Call: _e.mock.On("AddOrder", _a0)}
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+func (_e *MockOrderUsecase_Expecter) AddOrder(ctx interface{}, branch interface{}, _a2 interface{}) *MockOrderUsecase_AddOrder_Call {
+	return &MockOrderUsecase_AddOrder_Call{Call: _e.mock.On("AddOrder", ctx, branch, _a2)}
 }
 
-func (_c *MockOrderUsecase_AddOrder_Call) Run(run func(_a0 order.Order)) *MockOrderUsecase_AddOrder_Call {
+func (_c *MockOrderUsecase_AddOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order)) *MockOrderUsecase_AddOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(order.Order))
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order))
 	})
 	return _c
 }
@@ -74,14 +81,300 @@ func (_c *MockOrderUsecase_AddOrder_Call) Return(_a0 *order.Order, _a1 error) *M
 	return _c
 }
 
-func (_c *MockOrderUsecase_AddOrder_Call) RunAndReturn(run func(order.Order) (*order.Order, error)) *MockOrderUsecase_AddOrder_Call {
+func (_c *MockOrderUsecase_AddOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order) (*order.Order, error)) *MockOrderUsecase_AddOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOrderWith provides a mock function with given fields: ctx, branch, _a2, fn
+func (_m *MockOrderUsecase) AddOrderWith(ctx context.Context, branch tenant.Branch, _a2 order.Order, fn func(interfaces.Repositories, *order.Order) error) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrderWith")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order, func(interfaces.Repositories, *order.Order) error) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2, fn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order, func(interfaces.Repositories, *order.Order) error) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order, func(interfaces.Repositories, *order.Order) error) error); ok {
+		r1 = rf(ctx, branch, _a2, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_AddOrderWith_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOrderWith'
+type MockOrderUsecase_AddOrderWith_Call struct {
+	*mock.Call
+}
+
+// AddOrderWith is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+//   - fn func(interfaces.Repositories , *order.Order) error
+func (_e *MockOrderUsecase_Expecter) AddOrderWith(ctx interface{}, branch interface{}, _a2 interface{}, fn interface{}) *MockOrderUsecase_AddOrderWith_Call {
+	return &MockOrderUsecase_AddOrderWith_Call{Call: _e.mock.On("AddOrderWith", ctx, branch, _a2, fn)}
+}
+
+func (_c *MockOrderUsecase_AddOrderWith_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order, fn func(interfaces.Repositories, *order.Order) error)) *MockOrderUsecase_AddOrderWith_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order), args[3].(func(interfaces.Repositories, *order.Order) error))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_AddOrderWith_Call) Return(_a0 *order.Order, _a1 error) *MockOrderUsecase_AddOrderWith_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_AddOrderWith_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order, func(interfaces.Repositories, *order.Order) error) (*order.Order, error)) *MockOrderUsecase_AddOrderWith_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOrders provides a mock function with given fields: ctx, branch, orders, mode
+func (_m *MockOrderUsecase) AddOrders(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode) ([]order.BatchResult, error) {
+	ret := _m.Called(ctx, branch, orders, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrders")
+	}
+
+	var r0 []order.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)); ok {
+		return rf(ctx, branch, orders, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) []order.BatchResult); ok {
+		r0 = rf(ctx, branch, orders, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) error); ok {
+		r1 = rf(ctx, branch, orders, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_AddOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOrders'
+type MockOrderUsecase_AddOrders_Call struct {
+	*mock.Call
+}
+
+// AddOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orders []order.Order
+//   - mode order.BatchMode
+func (_e *MockOrderUsecase_Expecter) AddOrders(ctx interface{}, branch interface{}, orders interface{}, mode interface{}) *MockOrderUsecase_AddOrders_Call {
+	return &MockOrderUsecase_AddOrders_Call{Call: _e.mock.On("AddOrders", ctx, branch, orders, mode)}
+}
+
+func (_c *MockOrderUsecase_AddOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode)) *MockOrderUsecase_AddOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].([]order.Order), args[3].(order.BatchMode))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_AddOrders_Call) Return(_a0 []order.BatchResult, _a1 error) *MockOrderUsecase_AddOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_AddOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)) *MockOrderUsecase_AddOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckImport provides a mock function with given fields: _a0
+func (_m *MockOrderUsecase) CheckImport(_a0 order.Order) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckImport")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(order.Order) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOrderUsecase_CheckImport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckImport'
+type MockOrderUsecase_CheckImport_Call struct {
+	*mock.Call
+}
+
+// CheckImport is a helper method to define mock.On call
+//   - _a0 order.Order
+func (_e *MockOrderUsecase_Expecter) CheckImport(_a0 interface{}) *MockOrderUsecase_CheckImport_Call {
+	return &MockOrderUsecase_CheckImport_Call{Call: _e.mock.On("CheckImport", _a0)}
+}
+
+func (_c *MockOrderUsecase_CheckImport_Call) Run(run func(_a0 order.Order)) *MockOrderUsecase_CheckImport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(order.Order))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_CheckImport_Call) Return(_a0 error) *MockOrderUsecase_CheckImport_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOrderUsecase_CheckImport_Call) RunAndReturn(run func(order.Order) error) *MockOrderUsecase_CheckImport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditOrder provides a mock function with given fields: ctx, branch, edit
+func (_m *MockOrderUsecase) EditOrder(ctx context.Context, branch tenant.Branch, edit order.Edit) (*order.Order, *order.Quote, error) {
+	ret := _m.Called(ctx, branch, edit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditOrder")
+	}
+
+	var r0 *order.Order
+	var r1 *order.Quote
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Edit) (*order.Order, *order.Quote, error)); ok {
+		return rf(ctx, branch, edit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Edit) *order.Order); ok {
+		r0 = rf(ctx, branch, edit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Edit) *order.Quote); ok {
+		r1 = rf(ctx, branch, edit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*order.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, tenant.Branch, order.Edit) error); ok {
+		r2 = rf(ctx, branch, edit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockOrderUsecase_EditOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditOrder'
+type MockOrderUsecase_EditOrder_Call struct {
+	*mock.Call
+}
+
+// EditOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - edit order.Edit
+func (_e *MockOrderUsecase_Expecter) EditOrder(ctx interface{}, branch interface{}, edit interface{}) *MockOrderUsecase_EditOrder_Call {
+	return &MockOrderUsecase_EditOrder_Call{Call: _e.mock.On("EditOrder", ctx, branch, edit)}
+}
+
+func (_c *MockOrderUsecase_EditOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, edit order.Edit)) *MockOrderUsecase_EditOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Edit))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_EditOrder_Call) Return(_a0 *order.Order, _a1 *order.Quote, _a2 error) *MockOrderUsecase_EditOrder_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockOrderUsecase_EditOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Edit) (*order.Order, *order.Quote, error)) *MockOrderUsecase_EditOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllOrders provides a mock function with given fields:
-func (_m *MockOrderUsecase) GetAllOrders() ([]order.Order, error) {
-	ret := _m.Called()
+// ExportOrders provides a mock function with given fields: ctx, branch, filter, fn
+func (_m *MockOrderUsecase) ExportOrders(ctx context.Context, branch tenant.Branch, filter order.Filter, fn func(order.Order) error) error {
+	ret := _m.Called(ctx, branch, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportOrders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Filter, func(order.Order) error) error); ok {
+		r0 = rf(ctx, branch, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockOrderUsecase_ExportOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportOrders'
+type MockOrderUsecase_ExportOrders_Call struct {
+	*mock.Call
+}
+
+// ExportOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - filter order.Filter
+//   - fn func(order.Order) error
+func (_e *MockOrderUsecase_Expecter) ExportOrders(ctx interface{}, branch interface{}, filter interface{}, fn interface{}) *MockOrderUsecase_ExportOrders_Call {
+	return &MockOrderUsecase_ExportOrders_Call{Call: _e.mock.On("ExportOrders", ctx, branch, filter, fn)}
+}
+
+func (_c *MockOrderUsecase_ExportOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, filter order.Filter, fn func(order.Order) error)) *MockOrderUsecase_ExportOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Filter), args[3].(func(order.Order) error))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_ExportOrders_Call) Return(_a0 error) *MockOrderUsecase_ExportOrders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOrderUsecase_ExportOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Filter, func(order.Order) error) error) *MockOrderUsecase_ExportOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllOrders provides a mock function with given fields: ctx, branch, filter
+func (_m *MockOrderUsecase) GetAllOrders(ctx context.Context, branch tenant.Branch, filter order.Filter) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllOrders")
@@ -89,19 +382,19 @@ func (_m *MockOrderUsecase) GetAllOrders() ([]order.Order, error) {
 
 	var r0 []order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]order.Order, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Filter) ([]order.Order, error)); ok {
+		return rf(ctx, branch, filter)
 	}
-	if rf, ok := ret.Get(0).(func() []order.Order); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Filter) []order.Order); ok {
+		r0 = rf(ctx, branch, filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Filter) error); ok {
+		r1 = rf(ctx, branch, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -115,13 +408,16 @@ type MockOrderUsecase_GetAllOrders_Call struct {
 }
 
 // GetAllOrders is a helper method to define mock.On call
-func (_e *MockOrderUsecase_Expecter) GetAllOrders() *MockOrderUsecase_GetAllOrders_Call {
-	return &MockOrderUsecase_GetAllOrders_Call{Call: _e.mock.On("GetAllOrders")}
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - filter order.Filter
+func (_e *MockOrderUsecase_Expecter) GetAllOrders(ctx interface{}, branch interface{}, filter interface{}) *MockOrderUsecase_GetAllOrders_Call {
+	return &MockOrderUsecase_GetAllOrders_Call{Call: _e.mock.On("GetAllOrders", ctx, branch, filter)}
 }
 
-func (_c *MockOrderUsecase_GetAllOrders_Call) Run(run func()) *MockOrderUsecase_GetAllOrders_Call {
+func (_c *MockOrderUsecase_GetAllOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, filter order.Filter)) *MockOrderUsecase_GetAllOrders_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Filter))
 	})
 	return _c
 }
@@ -131,14 +427,14 @@ func (_c *MockOrderUsecase_GetAllOrders_Call) Return(_a0 []order.Order, _a1 erro
 	return _c
 }
 
-func (_c *MockOrderUsecase_GetAllOrders_Call) RunAndReturn(run func() ([]order.Order, error)) *MockOrderUsecase_GetAllOrders_Call {
+func (_c *MockOrderUsecase_GetAllOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Filter) ([]order.Order, error)) *MockOrderUsecase_GetAllOrders_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOrder provides a mock function with given fields: orderID
-func (_m *MockOrderUsecase) GetOrder(orderID string) (*order.Order, error) {
-	ret := _m.Called(orderID)
+// GetOrder provides a mock function with given fields: ctx, branch, orderID
+func (_m *MockOrderUsecase) GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, orderID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOrder")
@@ -146,19 +442,19 @@ func (_m *MockOrderUsecase) GetOrder(orderID string) (*order.Order, error) {
 
 	var r0 *order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*order.Order, error)); ok {
-		return rf(orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*order.Order, error)); ok {
+		return rf(ctx, branch, orderID)
 	}
-	if rf, ok := ret.Get(0).(func(string) *order.Order); ok {
-		r0 = rf(orderID)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *order.Order); ok {
+		r0 = rf(ctx, branch, orderID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(orderID)
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -172,14 +468,16 @@ type MockOrderUsecase_GetOrder_Call struct {
 }
 
 // GetOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
 //   - orderID string
-func (_e *MockOrderUsecase_Expecter) GetOrder(orderID interface{}) *MockOrderUsecase_GetOrder_Call {
-	return &MockOrderUsecase_GetOrder_Call{Call: _e.mock.On("GetOrder", orderID)}
+func (_e *MockOrderUsecase_Expecter) GetOrder(ctx interface{}, branch interface{}, orderID interface{}) *MockOrderUsecase_GetOrder_Call {
+	return &MockOrderUsecase_GetOrder_Call{Call: _e.mock.On("GetOrder", ctx, branch, orderID)}
 }
 
-func (_c *MockOrderUsecase_GetOrder_Call) Run(run func(orderID string)) *MockOrderUsecase_GetOrder_Call {
+func (_c *MockOrderUsecase_GetOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, orderID string)) *MockOrderUsecase_GetOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
 	})
 	return _c
 }
@@ -189,14 +487,135 @@ func (_c *MockOrderUsecase_GetOrder_Call) Return(_a0 *order.Order, _a1 error) *M
 	return _c
 }
 
-func (_c *MockOrderUsecase_GetOrder_Call) RunAndReturn(run func(string) (*order.Order, error)) *MockOrderUsecase_GetOrder_Call {
+func (_c *MockOrderUsecase_GetOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*order.Order, error)) *MockOrderUsecase_GetOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportOrder provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockOrderUsecase) ImportOrder(ctx context.Context, branch tenant.Branch, _a2 order.Order) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order) error); ok {
+		r1 = rf(ctx, branch, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_ImportOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportOrder'
+type MockOrderUsecase_ImportOrder_Call struct {
+	*mock.Call
+}
+
+// ImportOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+func (_e *MockOrderUsecase_Expecter) ImportOrder(ctx interface{}, branch interface{}, _a2 interface{}) *MockOrderUsecase_ImportOrder_Call {
+	return &MockOrderUsecase_ImportOrder_Call{Call: _e.mock.On("ImportOrder", ctx, branch, _a2)}
+}
+
+func (_c *MockOrderUsecase_ImportOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order)) *MockOrderUsecase_ImportOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_ImportOrder_Call) Return(_a0 *order.Order, _a1 error) *MockOrderUsecase_ImportOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_ImportOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order) (*order.Order, error)) *MockOrderUsecase_ImportOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListActiveOrders provides a mock function with given fields:
-func (_m *MockOrderUsecase) ListActiveOrders() ([]order.Order, error) {
-	ret := _m.Called()
+// ImportOrders provides a mock function with given fields: ctx, branch, orders, mode
+func (_m *MockOrderUsecase) ImportOrders(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode) ([]order.BatchResult, error) {
+	ret := _m.Called(ctx, branch, orders, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportOrders")
+	}
+
+	var r0 []order.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)); ok {
+		return rf(ctx, branch, orders, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) []order.BatchResult); ok {
+		r0 = rf(ctx, branch, orders, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) error); ok {
+		r1 = rf(ctx, branch, orders, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_ImportOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportOrders'
+type MockOrderUsecase_ImportOrders_Call struct {
+	*mock.Call
+}
+
+// ImportOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orders []order.Order
+//   - mode order.BatchMode
+func (_e *MockOrderUsecase_Expecter) ImportOrders(ctx interface{}, branch interface{}, orders interface{}, mode interface{}) *MockOrderUsecase_ImportOrders_Call {
+	return &MockOrderUsecase_ImportOrders_Call{Call: _e.mock.On("ImportOrders", ctx, branch, orders, mode)}
+}
+
+func (_c *MockOrderUsecase_ImportOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode)) *MockOrderUsecase_ImportOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].([]order.Order), args[3].(order.BatchMode))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_ImportOrders_Call) Return(_a0 []order.BatchResult, _a1 error) *MockOrderUsecase_ImportOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_ImportOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)) *MockOrderUsecase_ImportOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveOrders provides a mock function with given fields: ctx, branch
+func (_m *MockOrderUsecase) ListActiveOrders(ctx context.Context, branch tenant.Branch) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListActiveOrders")
@@ -204,19 +623,19 @@ func (_m *MockOrderUsecase) ListActiveOrders() ([]order.Order, error) {
 
 	var r0 []order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]order.Order, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]order.Order, error)); ok {
+		return rf(ctx, branch)
 	}
-	if rf, ok := ret.Get(0).(func() []order.Order); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []order.Order); ok {
+		r0 = rf(ctx, branch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -230,13 +649,15 @@ type MockOrderUsecase_ListActiveOrders_Call struct {
 }
 
 // ListActiveOrders is a helper method to define mock.On call
-func (_e *MockOrderUsecase_Expecter) ListActiveOrders() *MockOrderUsecase_ListActiveOrders_Call {
-	return &MockOrderUsecase_ListActiveOrders_Call{Call: _e.mock.On("ListActiveOrders")}
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockOrderUsecase_Expecter) ListActiveOrders(ctx interface{}, branch interface{}) *MockOrderUsecase_ListActiveOrders_Call {
+	return &MockOrderUsecase_ListActiveOrders_Call{Call: _e.mock.On("ListActiveOrders", ctx, branch)}
 }
 
-func (_c *MockOrderUsecase_ListActiveOrders_Call) Run(run func()) *MockOrderUsecase_ListActiveOrders_Call {
+func (_c *MockOrderUsecase_ListActiveOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockOrderUsecase_ListActiveOrders_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(tenant.Branch))
 	})
 	return _c
 }
@@ -246,14 +667,193 @@ func (_c *MockOrderUsecase_ListActiveOrders_Call) Return(_a0 []order.Order, _a1
 	return _c
 }
 
-func (_c *MockOrderUsecase_ListActiveOrders_Call) RunAndReturn(run func() ([]order.Order, error)) *MockOrderUsecase_ListActiveOrders_Call {
+func (_c *MockOrderUsecase_ListActiveOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]order.Order, error)) *MockOrderUsecase_ListActiveOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRevisions provides a mock function with given fields: ctx, branch, orderID
+func (_m *MockOrderUsecase) ListRevisions(ctx context.Context, branch tenant.Branch, orderID string) ([]order.Revision, error) {
+	ret := _m.Called(ctx, branch, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 []order.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) ([]order.Revision, error)); ok {
+		return rf(ctx, branch, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) []order.Revision); ok {
+		r0 = rf(ctx, branch, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type MockOrderUsecase_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orderID string
+func (_e *MockOrderUsecase_Expecter) ListRevisions(ctx interface{}, branch interface{}, orderID interface{}) *MockOrderUsecase_ListRevisions_Call {
+	return &MockOrderUsecase_ListRevisions_Call{Call: _e.mock.On("ListRevisions", ctx, branch, orderID)}
+}
+
+func (_c *MockOrderUsecase_ListRevisions_Call) Run(run func(ctx context.Context, branch tenant.Branch, orderID string)) *MockOrderUsecase_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_ListRevisions_Call) Return(_a0 []order.Revision, _a1 error) *MockOrderUsecase_ListRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_ListRevisions_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) ([]order.Revision, error)) *MockOrderUsecase_ListRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduledOrders provides a mock function with given fields: ctx, branch
+func (_m *MockOrderUsecase) ListScheduledOrders(ctx context.Context, branch tenant.Branch) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduledOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]order.Order, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []order.Order); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_ListScheduledOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduledOrders'
+type MockOrderUsecase_ListScheduledOrders_Call struct {
+	*mock.Call
+}
+
+// ListScheduledOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockOrderUsecase_Expecter) ListScheduledOrders(ctx interface{}, branch interface{}) *MockOrderUsecase_ListScheduledOrders_Call {
+	return &MockOrderUsecase_ListScheduledOrders_Call{Call: _e.mock.On("ListScheduledOrders", ctx, branch)}
+}
+
+func (_c *MockOrderUsecase_ListScheduledOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockOrderUsecase_ListScheduledOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_ListScheduledOrders_Call) Return(_a0 []order.Order, _a1 error) *MockOrderUsecase_ListScheduledOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_ListScheduledOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]order.Order, error)) *MockOrderUsecase_ListScheduledOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveOrder provides a mock function with given fields: ctx, branch, move
+func (_m *MockOrderUsecase) MoveOrder(ctx context.Context, branch tenant.Branch, move order.Move) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch, move)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveOrder")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Move) ([]order.Order, error)); ok {
+		return rf(ctx, branch, move)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Move) []order.Order); ok {
+		r0 = rf(ctx, branch, move)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Move) error); ok {
+		r1 = rf(ctx, branch, move)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_MoveOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveOrder'
+type MockOrderUsecase_MoveOrder_Call struct {
+	*mock.Call
+}
+
+// MoveOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - move order.Move
+func (_e *MockOrderUsecase_Expecter) MoveOrder(ctx interface{}, branch interface{}, move interface{}) *MockOrderUsecase_MoveOrder_Call {
+	return &MockOrderUsecase_MoveOrder_Call{Call: _e.mock.On("MoveOrder", ctx, branch, move)}
+}
+
+func (_c *MockOrderUsecase_MoveOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, move order.Move)) *MockOrderUsecase_MoveOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Move))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_MoveOrder_Call) Return(_a0 []order.Order, _a1 error) *MockOrderUsecase_MoveOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_MoveOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Move) ([]order.Order, error)) *MockOrderUsecase_MoveOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateOrder provides a mock function with given fields: orderID, status, priority
-func (_m *MockOrderUsecase) UpdateOrder(orderID string, status order.Status, priority *int) (*order.Order, error) {
-	ret := _m.Called(orderID, status, priority)
+// UpdateOrder provides a mock function with given fields: ctx, branch, update
+func (_m *MockOrderUsecase) UpdateOrder(ctx context.Context, branch tenant.Branch, update order.StatusUpdate) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateOrder")
@@ -261,19 +861,19 @@ func (_m *MockOrderUsecase) UpdateOrder(orderID string, status order.Status, pri
 
 	var r0 *order.Order
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, order.Status, *int) (*order.Order, error)); ok {
-		return rf(orderID, status, priority)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.StatusUpdate) (*order.Order, error)); ok {
+		return rf(ctx, branch, update)
 	}
-	if rf, ok := ret.Get(0).(func(string, order.Status, *int) *order.Order); ok {
-		r0 = rf(orderID, status, priority)
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.StatusUpdate) *order.Order); ok {
+		r0 = rf(ctx, branch, update)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*order.Order)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, order.Status, *int) error); ok {
-		r1 = rf(orderID, status, priority)
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.StatusUpdate) error); ok {
+		r1 = rf(ctx, branch, update)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -287,16 +887,16 @@ type MockOrderUsecase_UpdateOrder_Call struct {
 }
 
 // UpdateOrder is a helper method to define mock.On call
-//   - orderID string
-//   - status order.Status
-//   - priority *int
-func (_e *MockOrderUsecase_Expecter) UpdateOrder(orderID interface{}, status interface{}, priority interface{}) *MockOrderUsecase_UpdateOrder_Call {
-	return &MockOrderUsecase_UpdateOrder_Call{Call: _e.mock.On("UpdateOrder", orderID, status, priority)}
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - update order.StatusUpdate
+func (_e *MockOrderUsecase_Expecter) UpdateOrder(ctx interface{}, branch interface{}, update interface{}) *MockOrderUsecase_UpdateOrder_Call {
+	return &MockOrderUsecase_UpdateOrder_Call{Call: _e.mock.On("UpdateOrder", ctx, branch, update)}
 }
 
-func (_c *MockOrderUsecase_UpdateOrder_Call) Run(run func(orderID string, status order.Status, priority *int)) *MockOrderUsecase_UpdateOrder_Call {
+func (_c *MockOrderUsecase_UpdateOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, update order.StatusUpdate)) *MockOrderUsecase_UpdateOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(order.Status), args[2].(*int))
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.StatusUpdate))
 	})
 	return _c
 }
@@ -306,7 +906,117 @@ func (_c *MockOrderUsecase_UpdateOrder_Call) Return(_a0 *order.Order, _a1 error)
 	return _c
 }
 
-func (_c *MockOrderUsecase_UpdateOrder_Call) RunAndReturn(run func(string, order.Status, *int) (*order.Order, error)) *MockOrderUsecase_UpdateOrder_Call {
+func (_c *MockOrderUsecase_UpdateOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.StatusUpdate) (*order.Order, error)) *MockOrderUsecase_UpdateOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrders provides a mock function with given fields: ctx, branch, updates, mode
+func (_m *MockOrderUsecase) UpdateOrders(ctx context.Context, branch tenant.Branch, updates []order.StatusUpdate, mode order.BatchMode) ([]order.BatchResult, error) {
+	ret := _m.Called(ctx, branch, updates, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrders")
+	}
+
+	var r0 []order.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) ([]order.BatchResult, error)); ok {
+		return rf(ctx, branch, updates, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) []order.BatchResult); ok {
+		r0 = rf(ctx, branch, updates, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) error); ok {
+		r1 = rf(ctx, branch, updates, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockOrderUsecase_UpdateOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrders'
+type MockOrderUsecase_UpdateOrders_Call struct {
+	*mock.Call
+}
+
+// UpdateOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - updates []order.StatusUpdate
+//   - mode order.BatchMode
+func (_e *MockOrderUsecase_Expecter) UpdateOrders(ctx interface{}, branch interface{}, updates interface{}, mode interface{}) *MockOrderUsecase_UpdateOrders_Call {
+	return &MockOrderUsecase_UpdateOrders_Call{Call: _e.mock.On("UpdateOrders", ctx, branch, updates, mode)}
+}
+
+func (_c *MockOrderUsecase_UpdateOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, updates []order.StatusUpdate, mode order.BatchMode)) *MockOrderUsecase_UpdateOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].([]order.StatusUpdate), args[3].(order.BatchMode))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_UpdateOrders_Call) Return(_a0 []order.BatchResult, _a1 error) *MockOrderUsecase_UpdateOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockOrderUsecase_UpdateOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) ([]order.BatchResult, error)) *MockOrderUsecase_UpdateOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchQueue provides a mock function with given fields: ctx, branch
+func (_m *MockOrderUsecase) WatchQueue(ctx context.Context, branch tenant.Branch) <-chan []order.Order {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchQueue")
+	}
+
+	var r0 <-chan []order.Order
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) <-chan []order.Order); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan []order.Order)
+		}
+	}
+
+	return r0
+}
+
+// MockOrderUsecase_WatchQueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchQueue'
+type MockOrderUsecase_WatchQueue_Call struct {
+	*mock.Call
+}
+
+// WatchQueue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockOrderUsecase_Expecter) WatchQueue(ctx interface{}, branch interface{}) *MockOrderUsecase_WatchQueue_Call {
+	return &MockOrderUsecase_WatchQueue_Call{Call: _e.mock.On("WatchQueue", ctx, branch)}
+}
+
+func (_c *MockOrderUsecase_WatchQueue_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockOrderUsecase_WatchQueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockOrderUsecase_WatchQueue_Call) Return(_a0 <-chan []order.Order) *MockOrderUsecase_WatchQueue_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOrderUsecase_WatchQueue_Call) RunAndReturn(run func(context.Context, tenant.Branch) <-chan []order.Order) *MockOrderUsecase_WatchQueue_Call {
 	_c.Call.Return(run)
 	return _c
 }
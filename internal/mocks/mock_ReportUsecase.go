@@ -0,0 +1,282 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	report "challenge-yuno/internal/business/domain/report"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+)
+
+// MockReportUsecase is an autogenerated mock type for the ReportUsecase type
+type MockReportUsecase struct {
+	mock.Mock
+}
+
+type MockReportUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReportUsecase) EXPECT() *MockReportUsecase_Expecter {
+	return &MockReportUsecase_Expecter{mock: &_m.Mock}
+}
+
+// CountOrders provides a mock function with given fields: ctx, branch, rng, dimension
+func (_m *MockReportUsecase) CountOrders(ctx context.Context, branch tenant.Branch, rng report.Range, dimension report.Dimension) ([]report.Count, error) {
+	ret := _m.Called(ctx, branch, rng, dimension)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOrders")
+	}
+
+	var r0 []report.Count
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range, report.Dimension) ([]report.Count, error)); ok {
+		return rf(ctx, branch, rng, dimension)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range, report.Dimension) []report.Count); ok {
+		r0 = rf(ctx, branch, rng, dimension)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.Count)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, report.Range, report.Dimension) error); ok {
+		r1 = rf(ctx, branch, rng, dimension)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReportUsecase_CountOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrders'
+type MockReportUsecase_CountOrders_Call struct {
+	*mock.Call
+}
+
+// CountOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - rng report.Range
+//   - dimension report.Dimension
+func (_e *MockReportUsecase_Expecter) CountOrders(ctx interface{}, branch interface{}, rng interface{}, dimension interface{}) *MockReportUsecase_CountOrders_Call {
+	return &MockReportUsecase_CountOrders_Call{Call: _e.mock.On("CountOrders", ctx, branch, rng, dimension)}
+}
+
+func (_c *MockReportUsecase_CountOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, rng report.Range, dimension report.Dimension)) *MockReportUsecase_CountOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(report.Range), args[3].(report.Dimension))
+	})
+	return _c
+}
+
+func (_c *MockReportUsecase_CountOrders_Call) Return(_a0 []report.Count, _a1 error) *MockReportUsecase_CountOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReportUsecase_CountOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, report.Range, report.Dimension) ([]report.Count, error)) *MockReportUsecase_CountOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDailySummaries provides a mock function with given fields: ctx, branch, rng
+func (_m *MockReportUsecase) ListDailySummaries(ctx context.Context, branch tenant.Branch, rng report.Range) ([]report.DailySummary, error) {
+	ret := _m.Called(ctx, branch, rng)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDailySummaries")
+	}
+
+	var r0 []report.DailySummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range) ([]report.DailySummary, error)); ok {
+		return rf(ctx, branch, rng)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range) []report.DailySummary); ok {
+		r0 = rf(ctx, branch, rng)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.DailySummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, report.Range) error); ok {
+		r1 = rf(ctx, branch, rng)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReportUsecase_ListDailySummaries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDailySummaries'
+type MockReportUsecase_ListDailySummaries_Call struct {
+	*mock.Call
+}
+
+// ListDailySummaries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - rng report.Range
+func (_e *MockReportUsecase_Expecter) ListDailySummaries(ctx interface{}, branch interface{}, rng interface{}) *MockReportUsecase_ListDailySummaries_Call {
+	return &MockReportUsecase_ListDailySummaries_Call{Call: _e.mock.On("ListDailySummaries", ctx, branch, rng)}
+}
+
+func (_c *MockReportUsecase_ListDailySummaries_Call) Run(run func(ctx context.Context, branch tenant.Branch, rng report.Range)) *MockReportUsecase_ListDailySummaries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(report.Range))
+	})
+	return _c
+}
+
+func (_c *MockReportUsecase_ListDailySummaries_Call) Return(_a0 []report.DailySummary, _a1 error) *MockReportUsecase_ListDailySummaries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReportUsecase_ListDailySummaries_Call) RunAndReturn(run func(context.Context, tenant.Branch, report.Range) ([]report.DailySummary, error)) *MockReportUsecase_ListDailySummaries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Summary provides a mock function with given fields: ctx, branch, rng
+func (_m *MockReportUsecase) Summary(ctx context.Context, branch tenant.Branch, rng report.Range) (*report.Summary, error) {
+	ret := _m.Called(ctx, branch, rng)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Summary")
+	}
+
+	var r0 *report.Summary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range) (*report.Summary, error)); ok {
+		return rf(ctx, branch, rng)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range) *report.Summary); ok {
+		r0 = rf(ctx, branch, rng)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*report.Summary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, report.Range) error); ok {
+		r1 = rf(ctx, branch, rng)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReportUsecase_Summary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Summary'
+type MockReportUsecase_Summary_Call struct {
+	*mock.Call
+}
+
+// Summary is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - rng report.Range
+func (_e *MockReportUsecase_Expecter) Summary(ctx interface{}, branch interface{}, rng interface{}) *MockReportUsecase_Summary_Call {
+	return &MockReportUsecase_Summary_Call{Call: _e.mock.On("Summary", ctx, branch, rng)}
+}
+
+func (_c *MockReportUsecase_Summary_Call) Run(run func(ctx context.Context, branch tenant.Branch, rng report.Range)) *MockReportUsecase_Summary_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(report.Range))
+	})
+	return _c
+}
+
+func (_c *MockReportUsecase_Summary_Call) Return(_a0 *report.Summary, _a1 error) *MockReportUsecase_Summary_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReportUsecase_Summary_Call) RunAndReturn(run func(context.Context, tenant.Branch, report.Range) (*report.Summary, error)) *MockReportUsecase_Summary_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TopItems provides a mock function with given fields: ctx, branch, rng, limit
+func (_m *MockReportUsecase) TopItems(ctx context.Context, branch tenant.Branch, rng report.Range, limit int) ([]report.ItemSales, error) {
+	ret := _m.Called(ctx, branch, rng, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopItems")
+	}
+
+	var r0 []report.ItemSales
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range, int) ([]report.ItemSales, error)); ok {
+		return rf(ctx, branch, rng, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, report.Range, int) []report.ItemSales); ok {
+		r0 = rf(ctx, branch, rng, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]report.ItemSales)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, report.Range, int) error); ok {
+		r1 = rf(ctx, branch, rng, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReportUsecase_TopItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopItems'
+type MockReportUsecase_TopItems_Call struct {
+	*mock.Call
+}
+
+// TopItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - rng report.Range
+//   - limit int
+func (_e *MockReportUsecase_Expecter) TopItems(ctx interface{}, branch interface{}, rng interface{}, limit interface{}) *MockReportUsecase_TopItems_Call {
+	return &MockReportUsecase_TopItems_Call{Call: _e.mock.On("TopItems", ctx, branch, rng, limit)}
+}
+
+func (_c *MockReportUsecase_TopItems_Call) Run(run func(ctx context.Context, branch tenant.Branch, rng report.Range, limit int)) *MockReportUsecase_TopItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(report.Range), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockReportUsecase_TopItems_Call) Return(_a0 []report.ItemSales, _a1 error) *MockReportUsecase_TopItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReportUsecase_TopItems_Call) RunAndReturn(run func(context.Context, tenant.Branch, report.Range, int) ([]report.ItemSales, error)) *MockReportUsecase_TopItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReportUsecase creates a new instance of MockReportUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReportUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReportUsecase {
+	mock := &MockReportUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
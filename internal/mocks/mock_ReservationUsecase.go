@@ -0,0 +1,347 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	reservation "challenge-yuno/internal/business/domain/reservation"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+
+	time "time"
+)
+
+// MockReservationUsecase is an autogenerated mock type for the ReservationUsecase type
+type MockReservationUsecase struct {
+	mock.Mock
+}
+
+type MockReservationUsecase_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReservationUsecase) EXPECT() *MockReservationUsecase_Expecter {
+	return &MockReservationUsecase_Expecter{mock: &_m.Mock}
+}
+
+// AddOrder provides a mock function with given fields: ctx, branch, reservationID, _a3
+func (_m *MockReservationUsecase) AddOrder(ctx context.Context, branch tenant.Branch, reservationID string, _a3 order.Order) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, reservationID, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string, order.Order) (*order.Order, error)); ok {
+		return rf(ctx, branch, reservationID, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string, order.Order) *order.Order); ok {
+		r0 = rf(ctx, branch, reservationID, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string, order.Order) error); ok {
+		r1 = rf(ctx, branch, reservationID, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReservationUsecase_AddOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOrder'
+type MockReservationUsecase_AddOrder_Call struct {
+	*mock.Call
+}
+
+// AddOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - reservationID string
+//   - _a3 order.Order
+func (_e *MockReservationUsecase_Expecter) AddOrder(ctx interface{}, branch interface{}, reservationID interface{}, _a3 interface{}) *MockReservationUsecase_AddOrder_Call {
+	return &MockReservationUsecase_AddOrder_Call{Call: _e.mock.On("AddOrder", ctx, branch, reservationID, _a3)}
+}
+
+func (_c *MockReservationUsecase_AddOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, reservationID string, _a3 order.Order)) *MockReservationUsecase_AddOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string), args[3].(order.Order))
+	})
+	return _c
+}
+
+func (_c *MockReservationUsecase_AddOrder_Call) Return(_a0 *order.Order, _a1 error) *MockReservationUsecase_AddOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReservationUsecase_AddOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, string, order.Order) (*order.Order, error)) *MockReservationUsecase_AddOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddReservation provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockReservationUsecase) AddReservation(ctx context.Context, branch tenant.Branch, _a2 reservation.Reservation) (*reservation.Reservation, error) {
+	ret := _m.Called(ctx, branch, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReservation")
+	}
+
+	var r0 *reservation.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, reservation.Reservation) (*reservation.Reservation, error)); ok {
+		return rf(ctx, branch, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, reservation.Reservation) *reservation.Reservation); ok {
+		r0 = rf(ctx, branch, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, reservation.Reservation) error); ok {
+		r1 = rf(ctx, branch, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReservationUsecase_AddReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReservation'
+type MockReservationUsecase_AddReservation_Call struct {
+	*mock.Call
+}
+
+// AddReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 reservation.Reservation
+func (_e *MockReservationUsecase_Expecter) AddReservation(ctx interface{}, branch interface{}, _a2 interface{}) *MockReservationUsecase_AddReservation_Call {
+	return &MockReservationUsecase_AddReservation_Call{Call: _e.mock.On("AddReservation", ctx, branch, _a2)}
+}
+
+func (_c *MockReservationUsecase_AddReservation_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 reservation.Reservation)) *MockReservationUsecase_AddReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(reservation.Reservation))
+	})
+	return _c
+}
+
+func (_c *MockReservationUsecase_AddReservation_Call) Return(_a0 *reservation.Reservation, _a1 error) *MockReservationUsecase_AddReservation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReservationUsecase_AddReservation_Call) RunAndReturn(run func(context.Context, tenant.Branch, reservation.Reservation) (*reservation.Reservation, error)) *MockReservationUsecase_AddReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReservation provides a mock function with given fields: ctx, branch, reservationID
+func (_m *MockReservationUsecase) GetReservation(ctx context.Context, branch tenant.Branch, reservationID string) (*reservation.Reservation, error) {
+	ret := _m.Called(ctx, branch, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservation")
+	}
+
+	var r0 *reservation.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*reservation.Reservation, error)); ok {
+		return rf(ctx, branch, reservationID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *reservation.Reservation); ok {
+		r0 = rf(ctx, branch, reservationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, reservationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReservationUsecase_GetReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReservation'
+type MockReservationUsecase_GetReservation_Call struct {
+	*mock.Call
+}
+
+// GetReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - reservationID string
+func (_e *MockReservationUsecase_Expecter) GetReservation(ctx interface{}, branch interface{}, reservationID interface{}) *MockReservationUsecase_GetReservation_Call {
+	return &MockReservationUsecase_GetReservation_Call{Call: _e.mock.On("GetReservation", ctx, branch, reservationID)}
+}
+
+func (_c *MockReservationUsecase_GetReservation_Call) Run(run func(ctx context.Context, branch tenant.Branch, reservationID string)) *MockReservationUsecase_GetReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockReservationUsecase_GetReservation_Call) Return(_a0 *reservation.Reservation, _a1 error) *MockReservationUsecase_GetReservation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReservationUsecase_GetReservation_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*reservation.Reservation, error)) *MockReservationUsecase_GetReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReservations provides a mock function with given fields: ctx, branch, from, to
+func (_m *MockReservationUsecase) ListReservations(ctx context.Context, branch tenant.Branch, from time.Time, to time.Time) ([]reservation.Reservation, error) {
+	ret := _m.Called(ctx, branch, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReservations")
+	}
+
+	var r0 []reservation.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, time.Time, time.Time) ([]reservation.Reservation, error)); ok {
+		return rf(ctx, branch, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, time.Time, time.Time) []reservation.Reservation); ok {
+		r0 = rf(ctx, branch, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]reservation.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, branch, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReservationUsecase_ListReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReservations'
+type MockReservationUsecase_ListReservations_Call struct {
+	*mock.Call
+}
+
+// ListReservations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - from time.Time
+//   - to time.Time
+func (_e *MockReservationUsecase_Expecter) ListReservations(ctx interface{}, branch interface{}, from interface{}, to interface{}) *MockReservationUsecase_ListReservations_Call {
+	return &MockReservationUsecase_ListReservations_Call{Call: _e.mock.On("ListReservations", ctx, branch, from, to)}
+}
+
+func (_c *MockReservationUsecase_ListReservations_Call) Run(run func(ctx context.Context, branch tenant.Branch, from time.Time, to time.Time)) *MockReservationUsecase_ListReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(time.Time), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockReservationUsecase_ListReservations_Call) Return(_a0 []reservation.Reservation, _a1 error) *MockReservationUsecase_ListReservations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReservationUsecase_ListReservations_Call) RunAndReturn(run func(context.Context, tenant.Branch, time.Time, time.Time) ([]reservation.Reservation, error)) *MockReservationUsecase_ListReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, branch, reservationID, status
+func (_m *MockReservationUsecase) UpdateStatus(ctx context.Context, branch tenant.Branch, reservationID string, status reservation.Status) (*reservation.Reservation, error) {
+	ret := _m.Called(ctx, branch, reservationID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 *reservation.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string, reservation.Status) (*reservation.Reservation, error)); ok {
+		return rf(ctx, branch, reservationID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string, reservation.Status) *reservation.Reservation); ok {
+		r0 = rf(ctx, branch, reservationID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*reservation.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string, reservation.Status) error); ok {
+		r1 = rf(ctx, branch, reservationID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReservationUsecase_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type MockReservationUsecase_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - reservationID string
+//   - status reservation.Status
+func (_e *MockReservationUsecase_Expecter) UpdateStatus(ctx interface{}, branch interface{}, reservationID interface{}, status interface{}) *MockReservationUsecase_UpdateStatus_Call {
+	return &MockReservationUsecase_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", ctx, branch, reservationID, status)}
+}
+
+func (_c *MockReservationUsecase_UpdateStatus_Call) Run(run func(ctx context.Context, branch tenant.Branch, reservationID string, status reservation.Status)) *MockReservationUsecase_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string), args[3].(reservation.Status))
+	})
+	return _c
+}
+
+func (_c *MockReservationUsecase_UpdateStatus_Call) Return(_a0 *reservation.Reservation, _a1 error) *MockReservationUsecase_UpdateStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReservationUsecase_UpdateStatus_Call) RunAndReturn(run func(context.Context, tenant.Branch, string, reservation.Status) (*reservation.Reservation, error)) *MockReservationUsecase_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReservationUsecase creates a new instance of MockReservationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReservationUsecase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReservationUsecase {
+	mock := &MockReservationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,1051 @@
+// Code generated by mockery v2.46.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	order "challenge-yuno/internal/business/domain/order"
+
+	tenant "challenge-yuno/internal/business/domain/tenant"
+
+	time "time"
+)
+
+// MockSQLOrderRepository is an autogenerated mock type for the SQLOrderRepository type
+type MockSQLOrderRepository struct {
+	mock.Mock
+}
+
+type MockSQLOrderRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSQLOrderRepository) EXPECT() *MockSQLOrderRepository_Expecter {
+	return &MockSQLOrderRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddOrder provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockSQLOrderRepository) AddOrder(ctx context.Context, branch tenant.Branch, _a2 order.Order) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order) error); ok {
+		r1 = rf(ctx, branch, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_AddOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOrder'
+type MockSQLOrderRepository_AddOrder_Call struct {
+	*mock.Call
+}
+
+// AddOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+func (_e *MockSQLOrderRepository_Expecter) AddOrder(ctx interface{}, branch interface{}, _a2 interface{}) *MockSQLOrderRepository_AddOrder_Call {
+	return &MockSQLOrderRepository_AddOrder_Call{Call: _e.mock.On("AddOrder", ctx, branch, _a2)}
+}
+
+func (_c *MockSQLOrderRepository_AddOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order)) *MockSQLOrderRepository_AddOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_AddOrder_Call) Return(_a0 *order.Order, _a1 error) *MockSQLOrderRepository_AddOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_AddOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order) (*order.Order, error)) *MockSQLOrderRepository_AddOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOrders provides a mock function with given fields: ctx, branch, orders, mode
+func (_m *MockSQLOrderRepository) AddOrders(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode) ([]order.BatchResult, error) {
+	ret := _m.Called(ctx, branch, orders, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddOrders")
+	}
+
+	var r0 []order.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)); ok {
+		return rf(ctx, branch, orders, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) []order.BatchResult); ok {
+		r0 = rf(ctx, branch, orders, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) error); ok {
+		r1 = rf(ctx, branch, orders, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_AddOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOrders'
+type MockSQLOrderRepository_AddOrders_Call struct {
+	*mock.Call
+}
+
+// AddOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orders []order.Order
+//   - mode order.BatchMode
+func (_e *MockSQLOrderRepository_Expecter) AddOrders(ctx interface{}, branch interface{}, orders interface{}, mode interface{}) *MockSQLOrderRepository_AddOrders_Call {
+	return &MockSQLOrderRepository_AddOrders_Call{Call: _e.mock.On("AddOrders", ctx, branch, orders, mode)}
+}
+
+func (_c *MockSQLOrderRepository_AddOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode)) *MockSQLOrderRepository_AddOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].([]order.Order), args[3].(order.BatchMode))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_AddOrders_Call) Return(_a0 []order.BatchResult, _a1 error) *MockSQLOrderRepository_AddOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_AddOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)) *MockSQLOrderRepository_AddOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArchiveOrders provides a mock function with given fields: ctx, statuses, closedBefore
+func (_m *MockSQLOrderRepository) ArchiveOrders(ctx context.Context, statuses []order.Status, closedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, statuses, closedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveOrders")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []order.Status, time.Time) (int64, error)); ok {
+		return rf(ctx, statuses, closedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []order.Status, time.Time) int64); ok {
+		r0 = rf(ctx, statuses, closedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []order.Status, time.Time) error); ok {
+		r1 = rf(ctx, statuses, closedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ArchiveOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveOrders'
+type MockSQLOrderRepository_ArchiveOrders_Call struct {
+	*mock.Call
+}
+
+// ArchiveOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statuses []order.Status
+//   - closedBefore time.Time
+func (_e *MockSQLOrderRepository_Expecter) ArchiveOrders(ctx interface{}, statuses interface{}, closedBefore interface{}) *MockSQLOrderRepository_ArchiveOrders_Call {
+	return &MockSQLOrderRepository_ArchiveOrders_Call{Call: _e.mock.On("ArchiveOrders", ctx, statuses, closedBefore)}
+}
+
+func (_c *MockSQLOrderRepository_ArchiveOrders_Call) Run(run func(ctx context.Context, statuses []order.Status, closedBefore time.Time)) *MockSQLOrderRepository_ArchiveOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]order.Status), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ArchiveOrders_Call) Return(_a0 int64, _a1 error) *MockSQLOrderRepository_ArchiveOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ArchiveOrders_Call) RunAndReturn(run func(context.Context, []order.Status, time.Time) (int64, error)) *MockSQLOrderRepository_ArchiveOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditOrder provides a mock function with given fields: ctx, branch, _a2, revision
+func (_m *MockSQLOrderRepository) EditOrder(ctx context.Context, branch tenant.Branch, _a2 order.Order, revision order.Revision) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order, order.Revision) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order, order.Revision) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order, order.Revision) error); ok {
+		r1 = rf(ctx, branch, _a2, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_EditOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditOrder'
+type MockSQLOrderRepository_EditOrder_Call struct {
+	*mock.Call
+}
+
+// EditOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+//   - revision order.Revision
+func (_e *MockSQLOrderRepository_Expecter) EditOrder(ctx interface{}, branch interface{}, _a2 interface{}, revision interface{}) *MockSQLOrderRepository_EditOrder_Call {
+	return &MockSQLOrderRepository_EditOrder_Call{Call: _e.mock.On("EditOrder", ctx, branch, _a2, revision)}
+}
+
+func (_c *MockSQLOrderRepository_EditOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order, revision order.Revision)) *MockSQLOrderRepository_EditOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order), args[3].(order.Revision))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_EditOrder_Call) Return(_a0 *order.Order, _a1 error) *MockSQLOrderRepository_EditOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_EditOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order, order.Revision) (*order.Order, error)) *MockSQLOrderRepository_EditOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllOrders provides a mock function with given fields: ctx, branch, filter
+func (_m *MockSQLOrderRepository) GetAllOrders(ctx context.Context, branch tenant.Branch, filter order.Filter) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Filter) ([]order.Order, error)); ok {
+		return rf(ctx, branch, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Filter) []order.Order); ok {
+		r0 = rf(ctx, branch, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Filter) error); ok {
+		r1 = rf(ctx, branch, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_GetAllOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllOrders'
+type MockSQLOrderRepository_GetAllOrders_Call struct {
+	*mock.Call
+}
+
+// GetAllOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - filter order.Filter
+func (_e *MockSQLOrderRepository_Expecter) GetAllOrders(ctx interface{}, branch interface{}, filter interface{}) *MockSQLOrderRepository_GetAllOrders_Call {
+	return &MockSQLOrderRepository_GetAllOrders_Call{Call: _e.mock.On("GetAllOrders", ctx, branch, filter)}
+}
+
+func (_c *MockSQLOrderRepository_GetAllOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, filter order.Filter)) *MockSQLOrderRepository_GetAllOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Filter))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_GetAllOrders_Call) Return(_a0 []order.Order, _a1 error) *MockSQLOrderRepository_GetAllOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_GetAllOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Filter) ([]order.Order, error)) *MockSQLOrderRepository_GetAllOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrder provides a mock function with given fields: ctx, branch, orderID
+func (_m *MockSQLOrderRepository) GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) (*order.Order, error)); ok {
+		return rf(ctx, branch, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) *order.Order); ok {
+		r0 = rf(ctx, branch, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_GetOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrder'
+type MockSQLOrderRepository_GetOrder_Call struct {
+	*mock.Call
+}
+
+// GetOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orderID string
+func (_e *MockSQLOrderRepository_Expecter) GetOrder(ctx interface{}, branch interface{}, orderID interface{}) *MockSQLOrderRepository_GetOrder_Call {
+	return &MockSQLOrderRepository_GetOrder_Call{Call: _e.mock.On("GetOrder", ctx, branch, orderID)}
+}
+
+func (_c *MockSQLOrderRepository_GetOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, orderID string)) *MockSQLOrderRepository_GetOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_GetOrder_Call) Return(_a0 *order.Order, _a1 error) *MockSQLOrderRepository_GetOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_GetOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) (*order.Order, error)) *MockSQLOrderRepository_GetOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportOrder provides a mock function with given fields: ctx, branch, _a2
+func (_m *MockSQLOrderRepository) ImportOrder(ctx context.Context, branch tenant.Branch, _a2 order.Order) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) (*order.Order, error)); ok {
+		return rf(ctx, branch, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Order) *order.Order); ok {
+		r0 = rf(ctx, branch, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Order) error); ok {
+		r1 = rf(ctx, branch, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ImportOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportOrder'
+type MockSQLOrderRepository_ImportOrder_Call struct {
+	*mock.Call
+}
+
+// ImportOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - _a2 order.Order
+func (_e *MockSQLOrderRepository_Expecter) ImportOrder(ctx interface{}, branch interface{}, _a2 interface{}) *MockSQLOrderRepository_ImportOrder_Call {
+	return &MockSQLOrderRepository_ImportOrder_Call{Call: _e.mock.On("ImportOrder", ctx, branch, _a2)}
+}
+
+func (_c *MockSQLOrderRepository_ImportOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, _a2 order.Order)) *MockSQLOrderRepository_ImportOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Order))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ImportOrder_Call) Return(_a0 *order.Order, _a1 error) *MockSQLOrderRepository_ImportOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ImportOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Order) (*order.Order, error)) *MockSQLOrderRepository_ImportOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportOrders provides a mock function with given fields: ctx, branch, orders, mode
+func (_m *MockSQLOrderRepository) ImportOrders(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode) ([]order.BatchResult, error) {
+	ret := _m.Called(ctx, branch, orders, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportOrders")
+	}
+
+	var r0 []order.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)); ok {
+		return rf(ctx, branch, orders, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) []order.BatchResult); ok {
+		r0 = rf(ctx, branch, orders, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, []order.Order, order.BatchMode) error); ok {
+		r1 = rf(ctx, branch, orders, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ImportOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportOrders'
+type MockSQLOrderRepository_ImportOrders_Call struct {
+	*mock.Call
+}
+
+// ImportOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orders []order.Order
+//   - mode order.BatchMode
+func (_e *MockSQLOrderRepository_Expecter) ImportOrders(ctx interface{}, branch interface{}, orders interface{}, mode interface{}) *MockSQLOrderRepository_ImportOrders_Call {
+	return &MockSQLOrderRepository_ImportOrders_Call{Call: _e.mock.On("ImportOrders", ctx, branch, orders, mode)}
+}
+
+func (_c *MockSQLOrderRepository_ImportOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, orders []order.Order, mode order.BatchMode)) *MockSQLOrderRepository_ImportOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].([]order.Order), args[3].(order.BatchMode))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ImportOrders_Call) Return(_a0 []order.BatchResult, _a1 error) *MockSQLOrderRepository_ImportOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ImportOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, []order.Order, order.BatchMode) ([]order.BatchResult, error)) *MockSQLOrderRepository_ImportOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveOrders provides a mock function with given fields: ctx, branch
+func (_m *MockSQLOrderRepository) ListActiveOrders(ctx context.Context, branch tenant.Branch) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]order.Order, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []order.Order); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ListActiveOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveOrders'
+type MockSQLOrderRepository_ListActiveOrders_Call struct {
+	*mock.Call
+}
+
+// ListActiveOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockSQLOrderRepository_Expecter) ListActiveOrders(ctx interface{}, branch interface{}) *MockSQLOrderRepository_ListActiveOrders_Call {
+	return &MockSQLOrderRepository_ListActiveOrders_Call{Call: _e.mock.On("ListActiveOrders", ctx, branch)}
+}
+
+func (_c *MockSQLOrderRepository_ListActiveOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockSQLOrderRepository_ListActiveOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListActiveOrders_Call) Return(_a0 []order.Order, _a1 error) *MockSQLOrderRepository_ListActiveOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListActiveOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]order.Order, error)) *MockSQLOrderRepository_ListActiveOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOverdueOrders provides a mock function with given fields: ctx, status, updatedBefore
+func (_m *MockSQLOrderRepository) ListOverdueOrders(ctx context.Context, status order.Status, updatedBefore time.Time) ([]order.Order, error) {
+	ret := _m.Called(ctx, status, updatedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOverdueOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, order.Status, time.Time) ([]order.Order, error)); ok {
+		return rf(ctx, status, updatedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, order.Status, time.Time) []order.Order); ok {
+		r0 = rf(ctx, status, updatedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, order.Status, time.Time) error); ok {
+		r1 = rf(ctx, status, updatedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ListOverdueOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOverdueOrders'
+type MockSQLOrderRepository_ListOverdueOrders_Call struct {
+	*mock.Call
+}
+
+// ListOverdueOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - status order.Status
+//   - updatedBefore time.Time
+func (_e *MockSQLOrderRepository_Expecter) ListOverdueOrders(ctx interface{}, status interface{}, updatedBefore interface{}) *MockSQLOrderRepository_ListOverdueOrders_Call {
+	return &MockSQLOrderRepository_ListOverdueOrders_Call{Call: _e.mock.On("ListOverdueOrders", ctx, status, updatedBefore)}
+}
+
+func (_c *MockSQLOrderRepository_ListOverdueOrders_Call) Run(run func(ctx context.Context, status order.Status, updatedBefore time.Time)) *MockSQLOrderRepository_ListOverdueOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(order.Status), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListOverdueOrders_Call) Return(_a0 []order.Order, _a1 error) *MockSQLOrderRepository_ListOverdueOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListOverdueOrders_Call) RunAndReturn(run func(context.Context, order.Status, time.Time) ([]order.Order, error)) *MockSQLOrderRepository_ListOverdueOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRevisions provides a mock function with given fields: ctx, branch, orderID
+func (_m *MockSQLOrderRepository) ListRevisions(ctx context.Context, branch tenant.Branch, orderID string) ([]order.Revision, error) {
+	ret := _m.Called(ctx, branch, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRevisions")
+	}
+
+	var r0 []order.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) ([]order.Revision, error)); ok {
+		return rf(ctx, branch, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, string) []order.Revision); ok {
+		r0 = rf(ctx, branch, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, string) error); ok {
+		r1 = rf(ctx, branch, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ListRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRevisions'
+type MockSQLOrderRepository_ListRevisions_Call struct {
+	*mock.Call
+}
+
+// ListRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - orderID string
+func (_e *MockSQLOrderRepository_Expecter) ListRevisions(ctx interface{}, branch interface{}, orderID interface{}) *MockSQLOrderRepository_ListRevisions_Call {
+	return &MockSQLOrderRepository_ListRevisions_Call{Call: _e.mock.On("ListRevisions", ctx, branch, orderID)}
+}
+
+func (_c *MockSQLOrderRepository_ListRevisions_Call) Run(run func(ctx context.Context, branch tenant.Branch, orderID string)) *MockSQLOrderRepository_ListRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListRevisions_Call) Return(_a0 []order.Revision, _a1 error) *MockSQLOrderRepository_ListRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListRevisions_Call) RunAndReturn(run func(context.Context, tenant.Branch, string) ([]order.Revision, error)) *MockSQLOrderRepository_ListRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduledOrders provides a mock function with given fields: ctx, branch
+func (_m *MockSQLOrderRepository) ListScheduledOrders(ctx context.Context, branch tenant.Branch) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduledOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) ([]order.Order, error)); ok {
+		return rf(ctx, branch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch) []order.Order); ok {
+		r0 = rf(ctx, branch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch) error); ok {
+		r1 = rf(ctx, branch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_ListScheduledOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduledOrders'
+type MockSQLOrderRepository_ListScheduledOrders_Call struct {
+	*mock.Call
+}
+
+// ListScheduledOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+func (_e *MockSQLOrderRepository_Expecter) ListScheduledOrders(ctx interface{}, branch interface{}) *MockSQLOrderRepository_ListScheduledOrders_Call {
+	return &MockSQLOrderRepository_ListScheduledOrders_Call{Call: _e.mock.On("ListScheduledOrders", ctx, branch)}
+}
+
+func (_c *MockSQLOrderRepository_ListScheduledOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch)) *MockSQLOrderRepository_ListScheduledOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListScheduledOrders_Call) Return(_a0 []order.Order, _a1 error) *MockSQLOrderRepository_ListScheduledOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_ListScheduledOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch) ([]order.Order, error)) *MockSQLOrderRepository_ListScheduledOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveOrder provides a mock function with given fields: ctx, branch, move
+func (_m *MockSQLOrderRepository) MoveOrder(ctx context.Context, branch tenant.Branch, move order.Move) ([]order.Order, error) {
+	ret := _m.Called(ctx, branch, move)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveOrder")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Move) ([]order.Order, error)); ok {
+		return rf(ctx, branch, move)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Move) []order.Order); ok {
+		r0 = rf(ctx, branch, move)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.Move) error); ok {
+		r1 = rf(ctx, branch, move)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_MoveOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveOrder'
+type MockSQLOrderRepository_MoveOrder_Call struct {
+	*mock.Call
+}
+
+// MoveOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - move order.Move
+func (_e *MockSQLOrderRepository_Expecter) MoveOrder(ctx interface{}, branch interface{}, move interface{}) *MockSQLOrderRepository_MoveOrder_Call {
+	return &MockSQLOrderRepository_MoveOrder_Call{Call: _e.mock.On("MoveOrder", ctx, branch, move)}
+}
+
+func (_c *MockSQLOrderRepository_MoveOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, move order.Move)) *MockSQLOrderRepository_MoveOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Move))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_MoveOrder_Call) Return(_a0 []order.Order, _a1 error) *MockSQLOrderRepository_MoveOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_MoveOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Move) ([]order.Order, error)) *MockSQLOrderRepository_MoveOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PromoteScheduledOrders provides a mock function with given fields: ctx, orderType, dueBefore
+func (_m *MockSQLOrderRepository) PromoteScheduledOrders(ctx context.Context, orderType order.OrderType, dueBefore time.Time) ([]order.Order, error) {
+	ret := _m.Called(ctx, orderType, dueBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PromoteScheduledOrders")
+	}
+
+	var r0 []order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, order.OrderType, time.Time) ([]order.Order, error)); ok {
+		return rf(ctx, orderType, dueBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, order.OrderType, time.Time) []order.Order); ok {
+		r0 = rf(ctx, orderType, dueBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, order.OrderType, time.Time) error); ok {
+		r1 = rf(ctx, orderType, dueBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_PromoteScheduledOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PromoteScheduledOrders'
+type MockSQLOrderRepository_PromoteScheduledOrders_Call struct {
+	*mock.Call
+}
+
+// PromoteScheduledOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderType order.OrderType
+//   - dueBefore time.Time
+func (_e *MockSQLOrderRepository_Expecter) PromoteScheduledOrders(ctx interface{}, orderType interface{}, dueBefore interface{}) *MockSQLOrderRepository_PromoteScheduledOrders_Call {
+	return &MockSQLOrderRepository_PromoteScheduledOrders_Call{Call: _e.mock.On("PromoteScheduledOrders", ctx, orderType, dueBefore)}
+}
+
+func (_c *MockSQLOrderRepository_PromoteScheduledOrders_Call) Run(run func(ctx context.Context, orderType order.OrderType, dueBefore time.Time)) *MockSQLOrderRepository_PromoteScheduledOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(order.OrderType), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_PromoteScheduledOrders_Call) Return(_a0 []order.Order, _a1 error) *MockSQLOrderRepository_PromoteScheduledOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_PromoteScheduledOrders_Call) RunAndReturn(run func(context.Context, order.OrderType, time.Time) ([]order.Order, error)) *MockSQLOrderRepository_PromoteScheduledOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamOrders provides a mock function with given fields: ctx, branch, filter, fn
+func (_m *MockSQLOrderRepository) StreamOrders(ctx context.Context, branch tenant.Branch, filter order.Filter, fn func(order.Order) error) error {
+	ret := _m.Called(ctx, branch, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamOrders")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.Filter, func(order.Order) error) error); ok {
+		r0 = rf(ctx, branch, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSQLOrderRepository_StreamOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamOrders'
+type MockSQLOrderRepository_StreamOrders_Call struct {
+	*mock.Call
+}
+
+// StreamOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - filter order.Filter
+//   - fn func(order.Order) error
+func (_e *MockSQLOrderRepository_Expecter) StreamOrders(ctx interface{}, branch interface{}, filter interface{}, fn interface{}) *MockSQLOrderRepository_StreamOrders_Call {
+	return &MockSQLOrderRepository_StreamOrders_Call{Call: _e.mock.On("StreamOrders", ctx, branch, filter, fn)}
+}
+
+func (_c *MockSQLOrderRepository_StreamOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, filter order.Filter, fn func(order.Order) error)) *MockSQLOrderRepository_StreamOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.Filter), args[3].(func(order.Order) error))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_StreamOrders_Call) Return(_a0 error) *MockSQLOrderRepository_StreamOrders_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_StreamOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.Filter, func(order.Order) error) error) *MockSQLOrderRepository_StreamOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrder provides a mock function with given fields: ctx, branch, update
+func (_m *MockSQLOrderRepository) UpdateOrder(ctx context.Context, branch tenant.Branch, update order.StatusUpdate) (*order.Order, error) {
+	ret := _m.Called(ctx, branch, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrder")
+	}
+
+	var r0 *order.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.StatusUpdate) (*order.Order, error)); ok {
+		return rf(ctx, branch, update)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, order.StatusUpdate) *order.Order); ok {
+		r0 = rf(ctx, branch, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*order.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, order.StatusUpdate) error); ok {
+		r1 = rf(ctx, branch, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_UpdateOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrder'
+type MockSQLOrderRepository_UpdateOrder_Call struct {
+	*mock.Call
+}
+
+// UpdateOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - update order.StatusUpdate
+func (_e *MockSQLOrderRepository_Expecter) UpdateOrder(ctx interface{}, branch interface{}, update interface{}) *MockSQLOrderRepository_UpdateOrder_Call {
+	return &MockSQLOrderRepository_UpdateOrder_Call{Call: _e.mock.On("UpdateOrder", ctx, branch, update)}
+}
+
+func (_c *MockSQLOrderRepository_UpdateOrder_Call) Run(run func(ctx context.Context, branch tenant.Branch, update order.StatusUpdate)) *MockSQLOrderRepository_UpdateOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].(order.StatusUpdate))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_UpdateOrder_Call) Return(_a0 *order.Order, _a1 error) *MockSQLOrderRepository_UpdateOrder_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_UpdateOrder_Call) RunAndReturn(run func(context.Context, tenant.Branch, order.StatusUpdate) (*order.Order, error)) *MockSQLOrderRepository_UpdateOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrders provides a mock function with given fields: ctx, branch, updates, mode
+func (_m *MockSQLOrderRepository) UpdateOrders(ctx context.Context, branch tenant.Branch, updates []order.StatusUpdate, mode order.BatchMode) ([]order.BatchResult, error) {
+	ret := _m.Called(ctx, branch, updates, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrders")
+	}
+
+	var r0 []order.BatchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) ([]order.BatchResult, error)); ok {
+		return rf(ctx, branch, updates, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) []order.BatchResult); ok {
+		r0 = rf(ctx, branch, updates, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]order.BatchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) error); ok {
+		r1 = rf(ctx, branch, updates, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSQLOrderRepository_UpdateOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrders'
+type MockSQLOrderRepository_UpdateOrders_Call struct {
+	*mock.Call
+}
+
+// UpdateOrders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - branch tenant.Branch
+//   - updates []order.StatusUpdate
+//   - mode order.BatchMode
+func (_e *MockSQLOrderRepository_Expecter) UpdateOrders(ctx interface{}, branch interface{}, updates interface{}, mode interface{}) *MockSQLOrderRepository_UpdateOrders_Call {
+	return &MockSQLOrderRepository_UpdateOrders_Call{Call: _e.mock.On("UpdateOrders", ctx, branch, updates, mode)}
+}
+
+func (_c *MockSQLOrderRepository_UpdateOrders_Call) Run(run func(ctx context.Context, branch tenant.Branch, updates []order.StatusUpdate, mode order.BatchMode)) *MockSQLOrderRepository_UpdateOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tenant.Branch), args[2].([]order.StatusUpdate), args[3].(order.BatchMode))
+	})
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_UpdateOrders_Call) Return(_a0 []order.BatchResult, _a1 error) *MockSQLOrderRepository_UpdateOrders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSQLOrderRepository_UpdateOrders_Call) RunAndReturn(run func(context.Context, tenant.Branch, []order.StatusUpdate, order.BatchMode) ([]order.BatchResult, error)) *MockSQLOrderRepository_UpdateOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSQLOrderRepository creates a new instance of MockSQLOrderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSQLOrderRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSQLOrderRepository {
+	mock := &MockSQLOrderRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
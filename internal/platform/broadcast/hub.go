@@ -0,0 +1,58 @@
+package broadcast
+
+import (
+	"context"
+	"sync"
+)
+
+// Hub hands the values published on a topic to everyone subscribed to it. It lives in process
+// memory, so subscribers only see what their own replica publishes.
+type Hub[T any] struct {
+	topics map[string]map[chan T]struct{}
+	mu     sync.Mutex
+}
+
+func NewHub[T any]() *Hub[T] {
+	return &Hub[T]{topics: make(map[string]map[chan T]struct{})}
+}
+
+// Subscribe returns a channel with the values published on topic from now on. It is closed once
+// ctx is done. Each value replaces the previous one, so a slow subscriber skips to the latest
+// instead of holding up the publisher.
+func (h *Hub[T]) Subscribe(ctx context.Context, topic string) <-chan T {
+	ch := make(chan T, 1)
+
+	h.mu.Lock()
+	if h.topics[topic] == nil {
+		h.topics[topic] = make(map[chan T]struct{})
+	}
+	h.topics[topic][ch] = struct{}{}
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.topics[topic], ch)
+		if len(h.topics[topic]) == 0 {
+			delete(h.topics, topic)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+func (h *Hub[T]) Publish(topic string, value T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.topics[topic] {
+		// drop the value the subscriber didn't read yet, it is outdated
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- value
+	}
+}
@@ -0,0 +1,61 @@
+package broadcast
+
+import (
+	"context"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type HubTestSuite struct {
+	suite.Suite
+}
+
+func TestHub(t *testing.T) {
+	suite.Run(t, new(HubTestSuite))
+}
+
+func (s *HubTestSuite) TestPublish() {
+	hub := NewHub[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := hub.Subscribe(ctx, "branch-1")
+	second := hub.Subscribe(ctx, "branch-1")
+	other := hub.Subscribe(ctx, "branch-2")
+
+	hub.Publish("branch-1", 1)
+
+	s.Equal(1, <-first)
+	s.Equal(1, <-second)
+	s.Empty(other)
+}
+
+func (s *HubTestSuite) TestKeepsLatest() {
+	hub := NewHub[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := hub.Subscribe(ctx, "branch-1")
+	hub.Publish("branch-1", 1)
+	hub.Publish("branch-1", 2)
+
+	s.Equal(2, <-ch)
+	s.Empty(ch)
+}
+
+func (s *HubTestSuite) TestUnsubscribe() {
+	hub := NewHub[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ch := hub.Subscribe(ctx, "branch-1")
+	cancel()
+
+	_, open := <-ch
+	s.False(open)
+
+	hub.mu.Lock()
+	s.Empty(hub.topics)
+	hub.mu.Unlock()
+
+	hub.Publish("branch-1", 1)
+}
@@ -0,0 +1,401 @@
+package config
+
+import (
+	"challenge-yuno/internal/business/domain/menu"
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/ratelimit"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultBranchID       = "default"
+	defaultBranchTimezone = "America/Argentina/Mendoza"
+
+	defaultOutboxPollInterval  = 2 * time.Second
+	defaultSLACheckInterval    = time.Minute
+	defaultOrderSLAs           = "PENDING=15m,IN_PREPARATION=30m"
+	defaultRateLimits          = "POST /order=60/m,POST /order/test=2/m"
+	defaultSchedulerInterval   = 30 * time.Second
+	defaultScheduleLeadTime    = 5 * time.Minute
+	defaultPrepTimeEstimates   = "NORMAL=20m,VIP=15m"
+	defaultReservationDuration = 90 * time.Minute
+	defaultDayCloseInterval    = time.Hour
+	defaultArchiveAfterDays    = 30
+
+	RateLimitStoreMemory   = "memory"
+	RateLimitStorePostgres = "postgres"
+)
+
+type Config struct {
+	Branches               []tenant.Branch
+	NotificationWebhookURL string
+	TracingEndpoint        string
+	DBTimeout              time.Duration
+	DBOperationTimeouts    map[string]time.Duration
+	Logging                logging.Config
+	ShutdownTimeout        time.Duration
+	DrainDelay             time.Duration
+	OutboxPollInterval     time.Duration
+	SLACheckInterval       time.Duration
+	OrderSLAs              map[order.Status]time.Duration
+	RateLimits             map[string]ratelimit.Limit
+	RateLimitKey           string
+	RateLimitStore         string
+	APIKeys                []string
+	APIKeyBranches         map[string][]string
+	TrustedProxies         []*net.IPNet
+	AdminAPIKey            string
+	SchedulerInterval      time.Duration
+	ScheduleLeadTime       time.Duration
+	PrepTimeEstimates      map[order.OrderType]time.Duration
+	Menu                   []menu.Item
+	ReservationDuration    time.Duration
+	DayCloseInterval       time.Duration
+	ArchiveAfter           time.Duration
+}
+
+// Load reads the application configuration from the environment.
+func Load() (*Config, error) {
+	branches, err := parseBranches(os.Getenv("BRANCHES"))
+	if err != nil {
+		return nil, err
+	}
+
+	dbTimeout, err := parseDuration(os.Getenv("DB_TIMEOUT"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_TIMEOUT: %w", err)
+	}
+
+	dbOperationTimeouts, err := parseDurations(os.Getenv("DB_OPERATION_TIMEOUTS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_OPERATION_TIMEOUTS: %w", err)
+	}
+
+	logLevels, err := logging.ParseLevels(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_LEVELS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LOG_LEVEL or LOG_LEVELS: %w", err)
+	}
+
+	shutdownTimeout, err := durationOr("SHUTDOWN_TIMEOUT", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	drainDelay, err := durationOr("DRAIN_DELAY", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxPollInterval, err := durationOr("OUTBOX_POLL_INTERVAL", defaultOutboxPollInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	slaCheckInterval, err := durationOr("SLA_CHECK_INTERVAL", defaultSLACheckInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	orderSLAs, err := parseOrderSLAs(os.Getenv("ORDER_SLAS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid ORDER_SLAS: %w", err)
+	}
+
+	schedulerInterval, err := durationOr("SCHEDULER_INTERVAL", defaultSchedulerInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleLeadTime, err := durationOr("SCHEDULE_LEAD_TIME", defaultScheduleLeadTime)
+	if err != nil {
+		return nil, err
+	}
+
+	prepTimeEstimates, err := parsePrepTimeEstimates(os.Getenv("PREP_TIME_ESTIMATES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PREP_TIME_ESTIMATES: %w", err)
+	}
+
+	menuItems, err := parseMenu(os.Getenv("MENU_PRICES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MENU_PRICES: %w", err)
+	}
+
+	reservationDuration, err := durationOr("RESERVATION_DURATION", defaultReservationDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	dayCloseInterval, err := durationOr("DAY_CLOSE_INTERVAL", defaultDayCloseInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	archiveAfterDays := defaultArchiveAfterDays
+	if value := strings.TrimSpace(os.Getenv("ARCHIVE_AFTER_DAYS")); value != "" {
+		if archiveAfterDays, err = strconv.Atoi(value); err != nil || archiveAfterDays < 1 {
+			return nil, fmt.Errorf("invalid ARCHIVE_AFTER_DAYS %q, expected a number of days of at least 1", value)
+		}
+	}
+
+	rateLimitsValue := os.Getenv("RATE_LIMITS")
+	if strings.TrimSpace(rateLimitsValue) == "" {
+		rateLimitsValue = defaultRateLimits
+	}
+	rateLimits, err := ratelimit.ParseLimits(rateLimitsValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMITS: %w", err)
+	}
+
+	rateLimitKey := valueOr(os.Getenv("RATE_LIMIT_KEY"), "ip")
+	switch rateLimitKey {
+	case "api_key", "tenant", "ip":
+	default:
+		return nil, fmt.Errorf("invalid RATE_LIMIT_KEY %q, expected api_key, tenant or ip", rateLimitKey)
+	}
+
+	apiKeys := parseList(os.Getenv("API_KEYS"))
+	apiKeyBranches, err := parseKeyBranches(os.Getenv("API_KEY_BRANCHES"), branches)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API_KEY_BRANCHES: %w", err)
+	}
+	// keys limited to some branches are known clients for rate limiting too
+	for key := range apiKeyBranches {
+		if !slices.Contains(apiKeys, key) {
+			apiKeys = append(apiKeys, key)
+		}
+	}
+
+	trustedProxies, err := parseIPRanges(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
+	rateLimitStore := valueOr(os.Getenv("RATE_LIMIT_STORE"), RateLimitStoreMemory)
+	if rateLimitStore != RateLimitStoreMemory && rateLimitStore != RateLimitStorePostgres {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_STORE %q, expected memory or postgres", rateLimitStore)
+	}
+
+	return &Config{
+		Branches:               branches,
+		NotificationWebhookURL: os.Getenv("NOTIFICATION_WEBHOOK_URL"),
+		TracingEndpoint:        os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		DBTimeout:              dbTimeout,
+		DBOperationTimeouts:    dbOperationTimeouts,
+		Logging:                logLevels,
+		ShutdownTimeout:        shutdownTimeout,
+		DrainDelay:             drainDelay,
+		OutboxPollInterval:     outboxPollInterval,
+		SLACheckInterval:       slaCheckInterval,
+		OrderSLAs:              orderSLAs,
+		RateLimits:             rateLimits,
+		RateLimitKey:           rateLimitKey,
+		RateLimitStore:         rateLimitStore,
+		APIKeys:                apiKeys,
+		APIKeyBranches:         apiKeyBranches,
+		TrustedProxies:         trustedProxies,
+		AdminAPIKey:            os.Getenv("ADMIN_API_KEY"),
+		SchedulerInterval:      schedulerInterval,
+		ScheduleLeadTime:       scheduleLeadTime,
+		PrepTimeEstimates:      prepTimeEstimates,
+		Menu:                   menuItems,
+		ReservationDuration:    reservationDuration,
+		DayCloseInterval:       dayCloseInterval,
+		ArchiveAfter:           time.Duration(archiveAfterDays) * 24 * time.Hour,
+	}, nil
+}
+
+// parseOrderSLAs reads how long orders may stay in a status, e.g. "PENDING=15m,IN_PREPARATION=30m".
+func parseOrderSLAs(value string) (map[order.Status]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultOrderSLAs
+	}
+
+	durations, err := parseDurations(value)
+	if err != nil {
+		return nil, err
+	}
+
+	slas := make(map[order.Status]time.Duration, len(durations))
+	for status, d := range durations {
+		slas[order.Status(status)] = d
+	}
+
+	return slas, nil
+}
+
+// parsePrepTimeEstimates reads how long each order type takes to prepare, e.g. "NORMAL=20m,VIP=15m".
+func parsePrepTimeEstimates(value string) (map[order.OrderType]time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultPrepTimeEstimates
+	}
+
+	durations, err := parseDurations(value)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates := make(map[order.OrderType]time.Duration, len(durations))
+	for orderType, d := range durations {
+		if !order.OrderType(orderType).Valid() {
+			return nil, fmt.Errorf("unknown order type %q", orderType)
+		}
+		estimates[order.OrderType(orderType)] = d
+	}
+
+	return estimates, nil
+}
+
+// parseMenu reads a comma separated list of "item=price" pairs with prices in cents, e.g.
+// "Milanesa=2500,Agua=600".
+func parseMenu(value string) ([]menu.Item, error) {
+	var items []menu.Item
+	if strings.TrimSpace(value) == "" {
+		return items, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, raw, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid menu entry %q, expected item=price", entry)
+		}
+
+		price, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || price < 0 {
+			return nil, fmt.Errorf("invalid price for %s, expected a positive amount in cents", name)
+		}
+		items = append(items, menu.Item{Name: name, Price: price})
+	}
+
+	return items, nil
+}
+
+// parseList reads a comma separated list, skipping empty entries.
+func parseList(value string) []string {
+	var values []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); len(entry) > 0 {
+			values = append(values, entry)
+		}
+	}
+	return values
+}
+
+// parseKeyBranches reads the branches each API key may act on as a comma separated list of
+// "key=branch|branch" pairs, e.g. "k1=centro|norte,k2=sur". Every branch must be one of branches.
+func parseKeyBranches(value string, branches []tenant.Branch) (map[string][]string, error) {
+	keyBranches := make(map[string][]string)
+	for _, entry := range parseList(value) {
+		key, raw, found := strings.Cut(entry, "=")
+		if !found || key == "" || raw == "" {
+			// the entry holds a key, so it is left out of the error
+			return nil, errors.New("invalid entry, expected key=branch|branch")
+		}
+
+		for _, branchID := range strings.Split(raw, "|") {
+			if !slices.ContainsFunc(branches, func(b tenant.Branch) bool { return b.ID == branchID }) {
+				return nil, fmt.Errorf("unknown branch %q", branchID)
+			}
+			keyBranches[key] = append(keyBranches[key], branchID)
+		}
+	}
+	return keyBranches, nil
+}
+
+// parseIPRanges reads a comma separated list of CIDR ranges, e.g. "10.0.0.0/8,192.168.1.10/32".
+func parseIPRanges(value string) ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+	for _, entry := range parseList(value) {
+		_, ipRange, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, ipRange)
+	}
+	return ranges, nil
+}
+
+func valueOr(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return strings.TrimSpace(value)
+}
+
+// durationOr reads the duration in the given environment variable, or fallback when it is unset.
+func durationOr(name string, fallback time.Duration) (time.Duration, error) {
+	d, err := parseDuration(os.Getenv(name))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if d == 0 {
+		return fallback, nil
+	}
+	return d, nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	if strings.TrimSpace(value) == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(strings.TrimSpace(value))
+}
+
+// parseDurations reads a comma separated list of "name=duration" pairs, e.g.
+// "GetAllOrders=10s,AddOrder=2s".
+func parseDurations(value string) (map[string]time.Duration, error) {
+	durations := make(map[string]time.Duration)
+	if strings.TrimSpace(value) == "" {
+		return durations, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		name, raw, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected name=duration", entry)
+		}
+
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", name, err)
+		}
+		durations[name] = d
+	}
+
+	return durations, nil
+}
+
+// parseBranches reads a comma separated list of "id=timezone" pairs, e.g.
+// "centro=America/Argentina/Mendoza,norte=America/Argentina/Cordoba".
+func parseBranches(value string) ([]tenant.Branch, error) {
+	if strings.TrimSpace(value) == "" {
+		value = defaultBranchID + "=" + defaultBranchTimezone
+	}
+
+	var branches []tenant.Branch
+	for _, entry := range strings.Split(value, ",") {
+		id, tz, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || id == "" || tz == "" {
+			return nil, fmt.Errorf("invalid branch entry %q, expected id=timezone", entry)
+		}
+
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone for branch %s: %w", id, err)
+		}
+
+		branches = append(branches, tenant.Branch{ID: id, Location: loc})
+	}
+
+	return branches, nil
+}
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	defaultCheckTimeout = 2 * time.Second
+)
+
+// Check reports whether a dependency is usable; a nil error means it is.
+type Check func(ctx context.Context) error
+
+// BacklogSource returns how many notifications are waiting to be delivered.
+type BacklogSource func(ctx context.Context) (int, error)
+
+type CheckResult struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+	// NotificationBacklog is informative only; a large backlog doesn't make the API unready.
+	NotificationBacklog *int `json:"notification_backlog,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Health tracks the readiness of the API: the dependency checks registered with AddCheck and
+// whether the process is draining before shutdown.
+type Health struct {
+	timeout  time.Duration
+	checks   []namedCheck
+	backlog  BacklogSource
+	draining atomic.Bool
+	mu       sync.RWMutex
+}
+
+func New() *Health {
+	return &Health{timeout: defaultCheckTimeout}
+}
+
+func (h *Health) AddCheck(name string, check Check) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+func (h *Health) SetBacklogSource(source BacklogSource) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.backlog = source
+}
+
+// Drain marks the API as not ready so load balancers stop routing new requests to it while the
+// in-flight ones finish.
+func (h *Health) Drain() {
+	h.draining.Store(true)
+}
+
+func (h *Health) Draining() bool {
+	return h.draining.Load()
+}
+
+// Ready runs every check concurrently, each bounded by the check timeout.
+func (h *Health) Ready(ctx context.Context) Report {
+	h.mu.RLock()
+	checks := h.checks
+	backlog := h.backlog
+	h.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks)+1)}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c namedCheck) {
+			defer wg.Done()
+			result := CheckResult{Status: StatusUp}
+			if err := c.check(ctx); err != nil {
+				result = CheckResult{Status: StatusDown, Error: err.Error()}
+			}
+
+			mu.Lock()
+			report.Checks[c.name] = result
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	if h.Draining() {
+		report.Checks["shutdown"] = CheckResult{Status: StatusDown, Error: "draining"}
+	}
+
+	for _, result := range report.Checks {
+		if result.Status == StatusDown {
+			report.Status = StatusDown
+		}
+	}
+
+	if backlog != nil {
+		if pending, err := backlog(ctx); err == nil {
+			report.NotificationBacklog = &pending
+		}
+	}
+
+	return report
+}
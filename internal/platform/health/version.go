@@ -0,0 +1,53 @@
+package health
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version, Commit and BuildTime are set at build time, e.g.
+// go build -ldflags "-X challenge-yuno/internal/platform/health.Version=1.2.0".
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Build returns the build information of the running binary. When the commit wasn't set with
+// ldflags it falls back to the VCS data the Go toolchain embeds.
+func Build() BuildInfo {
+	info := BuildInfo{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if len(info.Commit) == 0 {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if len(info.BuildTime) == 0 {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	}
+
+	if len(info.Commit) == 0 {
+		info.Commit = "unknown"
+	}
+
+	return info
+}
@@ -0,0 +1,125 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// stopper implements the Stop half of components whose Run loop waits on a channel.
+type stopper struct {
+	once sync.Once
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newStopper() stopper {
+	return stopper{stop: make(chan struct{}), done: make(chan struct{})}
+}
+
+func (s *stopper) Stop(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type periodic struct {
+	stopper
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+	logger   *slog.Logger
+}
+
+// Periodic runs fn right away and then every interval. Errors are logged and the next run goes
+// ahead as scheduled. On Stop the run in progress, if any, is allowed to finish.
+func Periodic(name string, interval time.Duration, fn func(ctx context.Context) error, logger *slog.Logger) Component {
+	return &periodic{
+		stopper:  newStopper(),
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		logger:   logger,
+	}
+}
+
+func (p *periodic) Name() string {
+	return p.name
+}
+
+func (p *periodic) Run(ctx context.Context) error {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.fn(ctx); err != nil {
+			p.logger.ErrorContext(ctx, "worker run failed", slog.String("component", p.name), slog.Any("error", err))
+		}
+
+		select {
+		case <-p.stop:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type closer struct {
+	stopper
+	name string
+	fn   func() error
+}
+
+// Closer wraps a resource that only has to be released on shutdown, e.g. the database pool.
+func Closer(name string, fn func() error) Component {
+	return &closer{stopper: newStopper(), name: name, fn: fn}
+}
+
+func (c *closer) Name() string {
+	return c.name
+}
+
+func (c *closer) Run(ctx context.Context) error {
+	<-c.stop
+	defer close(c.done)
+	return c.fn()
+}
+
+type server struct {
+	echo    *echo.Echo
+	address string
+}
+
+// Server runs the HTTP server. Stop stops accepting connections and waits for the in-flight
+// requests to finish.
+func Server(e *echo.Echo, address string) Component {
+	return &server{echo: e, address: address}
+}
+
+func (s *server) Name() string {
+	return "http"
+}
+
+func (s *server) Run(ctx context.Context) error {
+	if err := s.echo.Start(s.address); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *server) Stop(ctx context.Context) error {
+	return s.echo.Shutdown(ctx)
+}
@@ -0,0 +1,139 @@
+package lifecycle
+
+import (
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const DefaultShutdownTimeout = 15 * time.Second
+
+// Component is a part of the application with its own lifetime, e.g. the HTTP server or a
+// background worker. Run blocks until the component stops; Stop asks it to stop and must return
+// once it has, or when ctx is done.
+type Component interface {
+	Name() string
+	Run(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Manager runs the application components and stops them when the process is asked to exit or
+// any of them stops on its own.
+type Manager struct {
+	components      []Component
+	beforeStop      []func()
+	drainDelay      time.Duration
+	shutdownTimeout time.Duration
+	logger          *slog.Logger
+}
+
+// New returns a Manager that waits drainDelay after the BeforeStop hooks, so load balancers
+// polling readiness notice the instance is going away before it stops accepting requests.
+func New(shutdownTimeout, drainDelay time.Duration, logger *slog.Logger) *Manager {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = DefaultShutdownTimeout
+	}
+
+	return &Manager{
+		drainDelay:      drainDelay,
+		shutdownTimeout: shutdownTimeout,
+		logger:          logging.Named(logger, "lifecycle"),
+	}
+}
+
+// Add registers components. They are stopped in reverse order, so add dependencies (e.g. the
+// database) before the components using them.
+func (m *Manager) Add(components ...Component) {
+	m.components = append(m.components, components...)
+}
+
+// BeforeStop registers a hook called when shutdown starts, before any component is stopped.
+func (m *Manager) BeforeStop(fn func()) {
+	m.beforeStop = append(m.beforeStop, fn)
+}
+
+// RunUntilSignal runs the components until SIGINT or SIGTERM is received.
+func (m *Manager) RunUntilSignal() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return m.Run(ctx)
+}
+
+// Run starts every component and blocks until ctx is done or a component stops. Components are
+// then given the shutdown timeout to drain; the ones still running after it are abandoned.
+func (m *Manager) Run(ctx context.Context) error {
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	defer cancelRun()
+
+	type result struct {
+		name string
+		err  error
+	}
+	done := make(chan result, len(m.components))
+	for _, c := range m.components {
+		m.logger.Info("starting component", slog.String("component", c.Name()))
+		go func(c Component) {
+			done <- result{name: c.Name(), err: c.Run(runCtx)}
+		}(c)
+	}
+
+	var errs []error
+	running := len(m.components)
+
+	select {
+	case <-ctx.Done():
+		m.logger.Info("shutdown requested")
+	case r := <-done:
+		running--
+		err := r.err
+		if err == nil {
+			err = errors.New("exited")
+		}
+		m.logger.Error("component stopped unexpectedly", slog.String("component", r.name), slog.Any("error", err))
+		errs = append(errs, fmt.Errorf("%s stopped unexpectedly: %w", r.name, err))
+	}
+
+	for _, fn := range m.beforeStop {
+		fn()
+	}
+
+	if m.drainDelay > 0 {
+		m.logger.Info("waiting before stopping components", slog.Duration("drain_delay", m.drainDelay))
+		time.Sleep(m.drainDelay)
+	}
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancelStop()
+
+	for i := len(m.components) - 1; i >= 0; i-- {
+		c := m.components[i]
+		m.logger.Info("stopping component", slog.String("component", c.Name()))
+		if err := c.Stop(stopCtx); err != nil {
+			m.logger.Error("error stopping component", slog.String("component", c.Name()), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("stopping %s: %w", c.Name(), err))
+		}
+	}
+
+	for running > 0 {
+		select {
+		case r := <-done:
+			running--
+			if r.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.name, r.err))
+			}
+		case <-stopCtx.Done():
+			m.logger.Error("shutdown timed out", slog.Int("running", running))
+			return errors.Join(append(errs, fmt.Errorf("%d components still running: %w", running, stopCtx.Err()))...)
+		}
+	}
+
+	m.logger.Info("shutdown completed")
+	return errors.Join(errs...)
+}
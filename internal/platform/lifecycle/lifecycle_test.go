@@ -0,0 +1,173 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var discardLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+// fakeComponent runs until stopped, recording the order of the calls it receives.
+type fakeComponent struct {
+	stopper
+	name     string
+	events   *events
+	runErr   error
+	exitNow  bool
+	stopWait time.Duration
+}
+
+type events struct {
+	mu   sync.Mutex
+	list []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.list = append(e.list, event)
+}
+
+func (e *events) all() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.list...)
+}
+
+func newFake(name string, ev *events) *fakeComponent {
+	return &fakeComponent{stopper: newStopper(), name: name, events: ev}
+}
+
+func (f *fakeComponent) Name() string {
+	return f.name
+}
+
+func (f *fakeComponent) Run(ctx context.Context) error {
+	defer close(f.done)
+	if f.exitNow {
+		return f.runErr
+	}
+
+	<-f.stop
+	time.Sleep(f.stopWait)
+	f.events.add("stopped " + f.name)
+	return f.runErr
+}
+
+func (f *fakeComponent) Stop(ctx context.Context) error {
+	f.events.add("stop " + f.name)
+	return f.stopper.Stop(ctx)
+}
+
+type LifecycleTestSuite struct {
+	suite.Suite
+}
+
+func TestLifecycle(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
+
+func (s *LifecycleTestSuite) TestStopsInReverseOrderOnShutdown() {
+	ev := &events{}
+	manager := New(time.Second, 0, discardLogger)
+	manager.Add(newFake("db", ev), newFake("worker", ev), newFake("http", ev))
+	manager.BeforeStop(func() { ev.add("drain") })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	s.Require().NoError(manager.Run(ctx))
+	s.Equal([]string{"drain", "stop http", "stopped http", "stop worker", "stopped worker", "stop db", "stopped db"}, ev.all())
+}
+
+func (s *LifecycleTestSuite) TestDrainDelay() {
+	ev := &events{}
+	manager := New(time.Second, 50*time.Millisecond, discardLogger)
+	manager.Add(newFake("http", ev))
+
+	var drained time.Time
+	manager.BeforeStop(func() {
+		drained = time.Now()
+		ev.add("drain")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s.Require().NoError(manager.Run(ctx))
+	s.Equal([]string{"drain", "stop http", "stopped http"}, ev.all())
+	s.GreaterOrEqual(time.Since(drained), 50*time.Millisecond)
+}
+
+func (s *LifecycleTestSuite) TestComponentFailureStopsTheRest() {
+	ev := &events{}
+	failing := newFake("http", ev)
+	failing.exitNow = true
+	failing.runErr = fmt.Errorf("address already in use")
+
+	manager := New(time.Second, 0, discardLogger)
+	manager.Add(newFake("worker", ev), failing)
+
+	err := manager.Run(context.Background())
+	s.Require().Error(err)
+	s.ErrorIs(err, failing.runErr)
+	s.Contains(ev.all(), "stopped worker")
+}
+
+func (s *LifecycleTestSuite) TestShutdownDeadline() {
+	ev := &events{}
+	slow := newFake("worker", ev)
+	slow.stopWait = time.Second
+
+	manager := New(20*time.Millisecond, 0, discardLogger)
+	manager.Add(slow)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := manager.Run(ctx)
+	s.Require().Error(err)
+	s.ErrorIs(err, context.DeadlineExceeded)
+	s.Less(time.Since(start), 500*time.Millisecond)
+}
+
+func (s *LifecycleTestSuite) TestPeriodic() {
+	var runs atomic.Int32
+	worker := Periodic("worker", 5*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return fmt.Errorf("mock error")
+	}, discardLogger)
+
+	done := make(chan error)
+	go func() { done <- worker.Run(context.Background()) }()
+
+	s.Eventually(func() bool { return runs.Load() >= 3 }, time.Second, time.Millisecond)
+	s.Require().NoError(worker.Stop(context.Background()))
+	s.NoError(<-done)
+}
+
+func (s *LifecycleTestSuite) TestCloser() {
+	closed := false
+	c := Closer("db", func() error {
+		closed = true
+		return nil
+	})
+
+	done := make(chan error)
+	go func() { done <- c.Run(context.Background()) }()
+
+	s.Require().NoError(c.Stop(context.Background()))
+	s.NoError(<-done)
+	s.True(closed)
+}
@@ -0,0 +1,47 @@
+package logging
+
+import "context"
+
+type fieldsKey struct{}
+
+// WithRequestID, WithOrderID and WithTenant store correlation fields in the context; every
+// log line written with that context includes them.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return withField(ctx, RequestIDKey, requestID)
+}
+
+func WithOrderID(ctx context.Context, orderID string) context.Context {
+	return withField(ctx, OrderIDKey, orderID)
+}
+
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return withField(ctx, TenantKey, tenant)
+}
+
+// RequestID returns the request ID stored in the context, if any.
+func RequestID(ctx context.Context) string {
+	return fromContext(ctx)[RequestIDKey]
+}
+
+func withField(ctx context.Context, key, value string) context.Context {
+	if len(value) == 0 {
+		return ctx
+	}
+
+	current := fromContext(ctx)
+	fields := make(map[string]string, len(current)+1)
+	for k, v := range current {
+		fields[k] = v
+	}
+	fields[key] = value
+
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+func fromContext(ctx context.Context) map[string]string {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(map[string]string)
+	return fields
+}
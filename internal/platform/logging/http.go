@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware assigns every request an ID, reusing the X-Request-ID sent by the caller, echoes it
+// back in the response and logs the outcome of the request.
+func Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	logger = Named(logger, "http")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			requestID := req.Header.Get(echo.HeaderXRequestID)
+			if len(requestID) == 0 {
+				requestID = uuid.New().String()
+			}
+
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+			c.SetRequest(req.WithContext(WithRequestID(req.Context(), requestID)))
+
+			start := time.Now()
+			err := next(c)
+
+			if err != nil {
+				// write the error response now so the logged status is the one sent to the client;
+				// the error handler ignores the error once the response is committed
+				c.Error(err)
+			}
+			status := c.Response().Status
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.Log(c.Request().Context(), level, "request completed",
+				slog.String("method", req.Method),
+				slog.String("route", c.Path()),
+				slog.Int("status", status),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("client_ip", c.RealIP()),
+			)
+
+			return err
+		}
+	}
+}
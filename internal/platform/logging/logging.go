@@ -0,0 +1,122 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	PackageKey   = "package"
+	RequestIDKey = "request_id"
+	OrderIDKey   = "order_id"
+	TenantKey    = "tenant"
+
+	redacted = "[REDACTED]"
+)
+
+// piiKeys are attribute keys holding customer data. Their values are never written to the logs.
+var piiKeys = map[string]struct{}{
+	"customer":       {},
+	"customer_name":  {},
+	"customer_phone": {},
+	"customer_email": {},
+	"phone":          {},
+	"email":          {},
+	"address":        {},
+}
+
+type Config struct {
+	// Level applies to every package without an explicit entry in Packages.
+	Level    slog.Level
+	Packages map[string]slog.Level
+}
+
+// New creates a JSON logger. Loggers derived with Named use the level configured for their
+// package, and every record carries the request_id, order_id and tenant found in its context.
+func New(w io.Writer, cfg Config) *slog.Logger {
+	json := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       slog.LevelDebug,
+		ReplaceAttr: redact,
+	})
+
+	return slog.New(&handler{
+		next:  json,
+		cfg:   cfg,
+		level: cfg.Level,
+	})
+}
+
+// Named returns a logger for the given package, e.g. Named(logger, "sql").
+func Named(logger *slog.Logger, pkg string) *slog.Logger {
+	return logger.With(slog.String(PackageKey, pkg))
+}
+
+// ParseLevels reads a default level followed by optional per package overrides, e.g.
+// level "info" and packages "sql=debug,kvstore=warn".
+func ParseLevels(level string, packages string) (Config, error) {
+	cfg := Config{Level: slog.LevelInfo, Packages: make(map[string]slog.Level)}
+	if strings.TrimSpace(level) != "" {
+		if err := cfg.Level.UnmarshalText([]byte(strings.TrimSpace(level))); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if strings.TrimSpace(packages) == "" {
+		return cfg, nil
+	}
+
+	for _, entry := range strings.Split(packages, ",") {
+		pkg, value, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		var l slog.Level
+		if err := l.UnmarshalText([]byte(value)); err != nil {
+			return Config{}, err
+		}
+		cfg.Packages[pkg] = l
+	}
+
+	return cfg, nil
+}
+
+func redact(groups []string, a slog.Attr) slog.Attr {
+	if _, isPII := piiKeys[strings.ToLower(a.Key)]; isPII {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
+
+type handler struct {
+	next  slog.Handler
+	cfg   Config
+	level slog.Level
+}
+
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	for key, value := range fromContext(ctx) {
+		r.AddAttrs(slog.String(key, value))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	level := h.level
+	for _, a := range attrs {
+		if a.Key != PackageKey {
+			continue
+		}
+		if l, ok := h.cfg.Packages[a.Value.String()]; ok {
+			level = l
+		}
+	}
+
+	return &handler{next: h.next.WithAttrs(attrs), cfg: h.cfg, level: level}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), cfg: h.cfg, level: h.level}
+}
@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type LoggingTestSuite struct {
+	suite.Suite
+	output *bytes.Buffer
+}
+
+func (s *LoggingTestSuite) SetupTest() {
+	s.output = &bytes.Buffer{}
+}
+
+func TestLogging(t *testing.T) {
+	suite.Run(t, new(LoggingTestSuite))
+}
+
+func (s *LoggingTestSuite) lines() []map[string]any {
+	var result []map[string]any
+	decoder := json.NewDecoder(s.output)
+	for decoder.More() {
+		line := map[string]any{}
+		s.Require().NoError(decoder.Decode(&line))
+		result = append(result, line)
+	}
+	return result
+}
+
+func (s *LoggingTestSuite) TestContextFields() {
+	logger := New(s.output, Config{Level: slog.LevelInfo})
+
+	ctx := WithTenant(context.Background(), "centro")
+	ctx = WithRequestID(ctx, "req-1")
+	ctx = WithOrderID(ctx, "123456")
+	logger.InfoContext(ctx, "order created")
+	logger.Info("without context")
+
+	lines := s.lines()
+	s.Require().Len(lines, 2)
+	s.Equal("centro", lines[0][TenantKey])
+	s.Equal("req-1", lines[0][RequestIDKey])
+	s.Equal("123456", lines[0][OrderIDKey])
+	s.NotContains(lines[1], RequestIDKey)
+}
+
+func (s *LoggingTestSuite) TestPackageLevels() {
+	cfg, err := ParseLevels("warn", "sql=debug")
+	s.Require().NoError(err)
+	logger := New(s.output, cfg)
+
+	Named(logger, "sql").Debug("sql debug")
+	Named(logger, "kvstore").Info("kvstore info")
+	logger.Warn("root warn")
+
+	lines := s.lines()
+	s.Require().Len(lines, 2)
+	s.Equal("sql debug", lines[0]["msg"])
+	s.Equal("sql", lines[0][PackageKey])
+	s.Equal("root warn", lines[1]["msg"])
+}
+
+func (s *LoggingTestSuite) TestParseLevelsError() {
+	_, err := ParseLevels("info", "sql=loud")
+	s.Error(err)
+}
+
+func (s *LoggingTestSuite) TestRedactsPII() {
+	logger := New(s.output, Config{Level: slog.LevelInfo})
+
+	logger.Info("delivery", slog.String("customer_phone", "+54 261 555 1234"), slog.String("address", "San Martín 1000"),
+		slog.String("status", "PENDING"))
+
+	lines := s.lines()
+	s.Require().Len(lines, 1)
+	s.Equal(redacted, lines[0]["customer_phone"])
+	s.Equal(redacted, lines[0]["address"])
+	s.Equal("PENDING", lines[0]["status"])
+}
+
+func (s *LoggingTestSuite) TestMiddlewareRequestID() {
+	logger := New(s.output, Config{Level: slog.LevelInfo})
+
+	e := echo.New()
+	e.Use(Middleware(logger))
+
+	var requestID string
+	e.GET("/ping", func(c echo.Context) error {
+		requestID = RequestID(c.Request().Context())
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/ping", nil))
+
+	s.NotEmpty(requestID)
+	s.Equal(requestID, recorder.Header().Get(echo.HeaderXRequestID))
+
+	lines := s.lines()
+	s.Require().Len(lines, 1)
+	s.Equal("request completed", lines[0]["msg"])
+	s.Equal(requestID, lines[0][RequestIDKey])
+	s.Equal(float64(http.StatusNoContent), lines[0]["status"])
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const startTimeKey = "metrics:start_time"
+
+// GormPlugin observes the latency of every query executed through gorm.
+type GormPlugin struct {
+	metrics *Metrics
+}
+
+func (m *Metrics) GormPlugin() *GormPlugin {
+	return &GormPlugin{metrics: m}
+}
+
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	type register func(name string, fn func(*gorm.DB)) error
+
+	callbacks := []struct {
+		operation string
+		before    register
+		after     register
+	}{
+		{"create", db.Callback().Create().Before("gorm:create").Register, db.Callback().Create().After("gorm:create").Register},
+		{"query", db.Callback().Query().Before("gorm:query").Register, db.Callback().Query().After("gorm:query").Register},
+		{"update", db.Callback().Update().Before("gorm:update").Register, db.Callback().Update().After("gorm:update").Register},
+		{"delete", db.Callback().Delete().Before("gorm:delete").Register, db.Callback().Delete().After("gorm:delete").Register},
+		{"row", db.Callback().Row().Before("gorm:row").Register, db.Callback().Row().After("gorm:row").Register},
+		{"raw", db.Callback().Raw().Before("gorm:raw").Register, db.Callback().Raw().After("gorm:raw").Register},
+	}
+
+	for _, cb := range callbacks {
+		if err := cb.before("metrics:before_"+cb.operation, p.before); err != nil {
+			return err
+		}
+		if err := cb.after("metrics:after_"+cb.operation, p.after(cb.operation)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func (p *GormPlugin) after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if len(table) == 0 {
+			table = "unknown"
+		}
+		p.metrics.dbDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"challenge-yuno/internal/platform/problem"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Middleware records latency and errors of every request, labelled with the route template
+// (e.g. /order/:ID) instead of the raw path to keep cardinality bounded.
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				status = problem.Status(err)
+			}
+
+			route := c.Path()
+			if len(route) == 0 {
+				route = "unmatched"
+			}
+			labels := []string{c.Request().Method, route, strconv.Itoa(status)}
+
+			m.httpDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+			if status >= http.StatusBadRequest {
+				m.httpErrors.WithLabelValues(labels...).Inc()
+			}
+
+			return err
+		}
+	}
+}
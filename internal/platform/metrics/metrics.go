@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+const namespace = "yuno"
+
+type Metrics struct {
+	httpDuration  *prometheus.HistogramVec
+	httpErrors    *prometheus.CounterVec
+	dbDuration    *prometheus.HistogramVec
+	ordersCreated *prometheus.CounterVec
+	prepTime      *prometheus.HistogramVec
+	notifications *prometheus.CounterVec
+	overdue       *prometheus.GaugeVec
+}
+
+// New creates the application metrics and registers them on the given registerer. Tests should
+// pass their own prometheus.NewRegistry() so they don't share state with the default registry.
+func New(registerer prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests by route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_errors_total",
+			Help:      "HTTP requests answered with a 4xx or 5xx status, by route.",
+		}, []string{"method", "route", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Latency of database queries by operation and table.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table"}),
+		ordersCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "orders",
+			Name:      "created_total",
+			Help:      "Orders created by branch, source and type.",
+		}, []string{"branch", "source", "type"}),
+		prepTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "orders",
+			Name:      "preparation_duration_seconds",
+			Help:      "Time orders spend from IN_PREPARATION to FINISHED.",
+			Buckets:   []float64{60, 120, 300, 600, 900, 1200, 1800, 2700, 3600},
+		}, []string{"branch", "type"}),
+		notifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "notifications",
+			Name:      "sent_total",
+			Help:      "Notifications sent, by result.",
+		}, []string{"result"}),
+		overdue: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "orders",
+			Name:      "overdue",
+			Help:      "Orders that exceeded the SLA of their status, by branch and status.",
+		}, []string{"branch", "status"}),
+	}
+
+	registerer.MustRegister(m.httpDuration, m.httpErrors, m.dbDuration, m.ordersCreated, m.prepTime, m.notifications, m.overdue)
+
+	return m
+}
+
+func (m *Metrics) OrderCreated(order *model.Order) {
+	m.ordersCreated.WithLabelValues(order.BranchID, string(order.Source), string(order.Type)).Inc()
+}
+
+// OrderPrepared records how long order was in preparation. Negative durations, from clock skew or
+// times migrated without their date, are dropped rather than skewing the histogram.
+func (m *Metrics) OrderPrepared(order *model.Order, duration time.Duration) {
+	if duration < 0 {
+		return
+	}
+	m.prepTime.WithLabelValues(order.BranchID, string(order.Type)).Observe(duration.Seconds())
+}
+
+// OverdueOrders replaces the overdue count of every branch for the given status, so branches that
+// caught up drop back to no series.
+func (m *Metrics) OverdueOrders(status model.Status, countByBranch map[string]int) {
+	m.overdue.DeletePartialMatch(prometheus.Labels{"status": string(status)})
+	for branch, count := range countByBranch {
+		m.overdue.WithLabelValues(branch, string(status)).Set(float64(count))
+	}
+}
+
+func (m *Metrics) notificationSent(err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.notifications.WithLabelValues(result).Inc()
+}
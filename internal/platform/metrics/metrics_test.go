@@ -0,0 +1,153 @@
+package metrics
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"context"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+	registry *prometheus.Registry
+	metrics  *Metrics
+}
+
+func (s *MetricsTestSuite) SetupTest() {
+	s.registry = prometheus.NewRegistry()
+	s.metrics = New(s.registry)
+}
+
+func TestMetrics(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+type fakeNotificationService struct {
+	err error
+}
+
+func (f *fakeNotificationService) SendNotification(context.Context, *model.Order) error {
+	return f.err
+}
+
+func (s *MetricsTestSuite) TestMiddleware() {
+	e := echo.New()
+	e.Use(s.metrics.Middleware())
+	e.GET("/order/:ID", func(c echo.Context) error {
+		if c.Param("ID") == "missing" {
+			return echo.NewHTTPError(http.StatusNotFound, "order not found")
+		}
+		return c.JSON(http.StatusOK, "ok")
+	})
+
+	for _, id := range []string{"1", "2", "missing"} {
+		req := httptest.NewRequest(http.MethodGet, "/order/"+id, nil)
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	s.Equal(2, testutil.CollectAndCount(s.metrics.httpDuration))
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.httpErrors.WithLabelValues(http.MethodGet, "/order/:ID", "404")))
+
+	expected := `
+		# HELP yuno_http_request_errors_total HTTP requests answered with a 4xx or 5xx status, by route.
+		# TYPE yuno_http_request_errors_total counter
+		yuno_http_request_errors_total{method="GET",route="/order/:ID",status="404"} 1
+	`
+	s.NoError(testutil.GatherAndCompare(s.registry, strings.NewReader(expected), "yuno_http_request_errors_total"))
+}
+
+func (s *MetricsTestSuite) TestOrders() {
+	order := &model.Order{ID: "1", BranchID: "centro", Source: model.Phone, Type: model.VIP}
+
+	s.metrics.OrderCreated(order)
+	s.metrics.OrderCreated(order)
+	s.metrics.OrderPrepared(order, 5*time.Minute)
+	s.metrics.OrderPrepared(order, -23*time.Hour)
+
+	s.Equal(float64(2), testutil.ToFloat64(s.metrics.ordersCreated.WithLabelValues("centro", "PHONE", "VIP")))
+
+	expected := `
+		# HELP yuno_orders_preparation_duration_seconds Time orders spend from IN_PREPARATION to FINISHED.
+		# TYPE yuno_orders_preparation_duration_seconds histogram
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="60"} 0
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="120"} 0
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="300"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="600"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="900"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="1200"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="1800"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="2700"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="3600"} 1
+		yuno_orders_preparation_duration_seconds_bucket{branch="centro",type="VIP",le="+Inf"} 1
+		yuno_orders_preparation_duration_seconds_sum{branch="centro",type="VIP"} 300
+		yuno_orders_preparation_duration_seconds_count{branch="centro",type="VIP"} 1
+	`
+	s.NoError(testutil.GatherAndCompare(s.registry, strings.NewReader(expected), "yuno_orders_preparation_duration_seconds"))
+}
+
+func (s *MetricsTestSuite) TestOverdueOrders() {
+	s.metrics.OverdueOrders(model.Pending, map[string]int{"centro": 2, "norte": 1})
+	s.metrics.OverdueOrders(model.InPreparation, map[string]int{"centro": 1})
+	s.metrics.OverdueOrders(model.Pending, map[string]int{"centro": 1})
+
+	expected := `
+		# HELP yuno_orders_overdue Orders that exceeded the SLA of their status, by branch and status.
+		# TYPE yuno_orders_overdue gauge
+		yuno_orders_overdue{branch="centro",status="IN_PREPARATION"} 1
+		yuno_orders_overdue{branch="centro",status="PENDING"} 1
+	`
+	s.NoError(testutil.GatherAndCompare(s.registry, strings.NewReader(expected), "yuno_orders_overdue"))
+}
+
+func (s *MetricsTestSuite) TestQueueDepth() {
+	RegisterQueueDepth(s.registry, func(context.Context) (map[string]map[model.Status]int, error) {
+		return map[string]map[model.Status]int{
+			"centro": {model.Pending: 3, model.InPreparation: 1},
+		}, nil
+	})
+
+	expected := `
+		# HELP yuno_orders_queue_depth Active orders by branch and status.
+		# TYPE yuno_orders_queue_depth gauge
+		yuno_orders_queue_depth{branch="centro",status="IN_PREPARATION"} 1
+		yuno_orders_queue_depth{branch="centro",status="PENDING"} 3
+	`
+	s.NoError(testutil.GatherAndCompare(s.registry, strings.NewReader(expected), "yuno_orders_queue_depth"))
+}
+
+func (s *MetricsTestSuite) TestInstrumentNotifications() {
+	order := &model.Order{ID: "1"}
+
+	s.NoError(s.metrics.InstrumentNotifications(&fakeNotificationService{}).SendNotification(context.Background(), order))
+	s.Error(s.metrics.InstrumentNotifications(&fakeNotificationService{err: fmt.Errorf("mock error")}).SendNotification(context.Background(), order))
+
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.notifications.WithLabelValues("success")))
+	s.Equal(float64(1), testutil.ToFloat64(s.metrics.notifications.WithLabelValues("failure")))
+}
+
+func (s *MetricsTestSuite) TestExposition() {
+	s.metrics.OrderCreated(&model.Order{BranchID: "centro", Source: model.InPerson, Type: model.Normal})
+
+	server := httptest.NewServer(promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	s.Require().NoError(err)
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Contains(res.Header.Get("Content-Type"), "text/plain")
+	s.Contains(string(body), `yuno_orders_created_total{branch="centro",source="IN_PERSON",type="NORMAL"} 1`)
+}
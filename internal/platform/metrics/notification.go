@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/interfaces"
+	"context"
+)
+
+type instrumentedNotificationService struct {
+	next    interfaces.INotificationService
+	metrics *Metrics
+}
+
+// InstrumentNotifications wraps a notification service counting successful and failed sends.
+func (m *Metrics) InstrumentNotifications(next interfaces.INotificationService) interfaces.INotificationService {
+	return &instrumentedNotificationService{
+		next:    next,
+		metrics: m,
+	}
+}
+
+func (s *instrumentedNotificationService) SendNotification(ctx context.Context, order *model.Order) error {
+	err := s.next.SendNotification(ctx, order)
+	s.metrics.notificationSent(err)
+	return err
+}
@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	model "challenge-yuno/internal/business/domain/order"
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// QueueDepthSource returns the number of active orders per branch and status.
+type QueueDepthSource func(ctx context.Context) (map[string]map[model.Status]int, error)
+
+type queueDepthCollector struct {
+	source QueueDepthSource
+	desc   *prometheus.Desc
+}
+
+// RegisterQueueDepth exposes a gauge with the active queue depth. The source is queried on every
+// scrape so the value is always consistent with the database.
+func RegisterQueueDepth(registerer prometheus.Registerer, source QueueDepthSource) {
+	registerer.MustRegister(&queueDepthCollector{
+		source: source,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "orders", "queue_depth"),
+			"Active orders by branch and status.",
+			[]string{"branch", "status"}, nil,
+		),
+	})
+}
+
+func (c *queueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *queueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	depths, err := c.source(context.Background())
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+
+	for branch, statuses := range depths {
+		for status, count := range statuses {
+			ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(count), branch, string(status))
+		}
+	}
+}
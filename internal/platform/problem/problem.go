@@ -0,0 +1,125 @@
+package problem
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+const (
+	MIMEProblemJSON = "application/problem+json"
+
+	codeInternal = "internal_error"
+)
+
+// Details is an RFC 7807 problem document. Code is a stable identifier of the error and
+// RequestID lets clients report the failing request.
+type Details struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Status returns the HTTP status for err, so middlewares and the error handler agree on it.
+func Status(err error) int {
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Code
+	case errors.Is(err, errs.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errs.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, errs.ErrInvalid):
+		return http.StatusBadRequest
+	case errors.Is(err, errs.ErrStale):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, errs.ErrUnavailable) && errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, errs.ErrUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// From builds the problem document of err. Unknown errors are reported as internal errors
+// without details, so nothing about the failure leaks to the client.
+func From(err error) Details {
+	status := Status(err)
+	details := Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   codeInternal,
+	}
+
+	var httpErr *echo.HTTPError
+	if e, ok := errs.As(err); ok {
+		details.Code = e.Code
+		details.Detail = e.Message
+	} else if errors.As(err, &httpErr) {
+		details.Code = codeFromStatus(status)
+		details.Detail = fmt.Sprint(httpErr.Message)
+	}
+
+	return details
+}
+
+// ErrorHandler writes every error returned by a handler as application/problem+json. Internal
+// errors are logged, as their cause is not part of the response.
+func ErrorHandler(logger *slog.Logger) echo.HTTPErrorHandler {
+	logger = logging.Named(logger, "http")
+
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		ctx := c.Request().Context()
+		details := From(err)
+		details.Instance = c.Request().URL.Path
+		details.RequestID = logging.RequestID(ctx)
+
+		if details.Status >= http.StatusInternalServerError {
+			logger.ErrorContext(ctx, "request failed", slog.Int("status", details.Status), slog.Any("error", err))
+		}
+
+		body, marshalErr := json.Marshal(details)
+		if marshalErr != nil {
+			logger.ErrorContext(ctx, "error encoding problem details", slog.Any("error", marshalErr))
+			c.Response().WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if c.Request().Method == http.MethodHead {
+			err = c.NoContent(details.Status)
+		} else {
+			err = c.Blob(details.Status, MIMEProblemJSON, body)
+		}
+		if err != nil {
+			logger.ErrorContext(ctx, "error writing problem details", slog.Any("error", err))
+		}
+	}
+}
+
+// codeFromStatus derives a code for errors raised by the framework itself, e.g. an unknown route
+// becomes "not_found".
+func codeFromStatus(status int) string {
+	text := http.StatusText(status)
+	if len(text) == 0 {
+		return codeInternal
+	}
+	return strings.ReplaceAll(strings.ToLower(text), " ", "_")
+}
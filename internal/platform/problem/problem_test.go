@@ -0,0 +1,85 @@
+package problem
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ProblemTestSuite struct {
+	suite.Suite
+}
+
+func TestProblem(t *testing.T) {
+	suite.Run(t, new(ProblemTestSuite))
+}
+
+func (s *ProblemTestSuite) TestStatus() {
+	var tests = []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "not_found", err: errs.NotFound("order_not_found", "order not found"), expectedStatus: http.StatusNotFound},
+		{name: "conflict", err: errs.Conflict("order_already_exists", "order already added"), expectedStatus: http.StatusConflict},
+		{name: "invalid", err: fmt.Errorf("wrapped: %w", errs.Invalid("invalid_body", "error binding order body")), expectedStatus: http.StatusBadRequest},
+		{name: "stale", err: errs.Stale("order_modified", "order was modified"), expectedStatus: http.StatusPreconditionFailed},
+		{name: "timeout", err: errs.Unavailable("database_timeout", "database timeout", context.DeadlineExceeded), expectedStatus: http.StatusGatewayTimeout},
+		{name: "unavailable", err: errs.Unavailable("request_canceled", "request canceled", context.Canceled), expectedStatus: http.StatusServiceUnavailable},
+		{name: "echo", err: echo.ErrMethodNotAllowed, expectedStatus: http.StatusMethodNotAllowed},
+		{name: "unknown", err: fmt.Errorf("connection refused"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expectedStatus, Status(tt.err))
+		})
+	}
+}
+
+func (s *ProblemTestSuite) TestFrom() {
+	s.Equal(Details{
+		Type:   "about:blank",
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Detail: "order not found",
+		Code:   "order_not_found",
+	}, From(errs.NotFound("order_not_found", "order not found")))
+
+	s.Equal(Details{
+		Type:   "about:blank",
+		Title:  "Internal Server Error",
+		Status: http.StatusInternalServerError,
+		Code:   "internal_error",
+	}, From(fmt.Errorf("error getting order: connection refused")))
+
+	s.Equal("method_not_allowed", From(echo.ErrMethodNotAllowed).Code)
+}
+
+func (s *ProblemTestSuite) TestErrorHandler() {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler(slog.New(slog.NewJSONHandler(io.Discard, nil)))
+	e.GET("/order/:ID", func(c echo.Context) error {
+		return errs.NotFound("order_not_found", "order not found")
+	})
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/order/123456", nil))
+
+	s.Equal(http.StatusNotFound, recorder.Code)
+	s.Equal(MIMEProblemJSON, recorder.Header().Get(echo.HeaderContentType))
+
+	details := Details{}
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &details))
+	s.Equal("order_not_found", details.Code)
+	s.Equal("order not found", details.Detail)
+	s.Equal("/order/123456", details.Instance)
+}
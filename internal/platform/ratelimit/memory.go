@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepEvery is how many calls to Allow happen between removals of idle buckets.
+const sweepEvery = 1000
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// MemoryLimiter keeps the buckets in process memory, so every replica enforces its own limits.
+type MemoryLimiter struct {
+	buckets map[string]*bucket
+	calls   int
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Decision, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.calls++
+	if l.calls%sweepEvery == 0 {
+		l.sweep(now)
+	}
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+
+	tokens, decision := refill(b.tokens, now.Sub(b.updated), limit)
+	b.tokens, b.updated, b.limit = tokens, now, limit
+
+	return decision, nil
+}
+
+// sweep drops the buckets that are full again; they behave exactly like a missing bucket.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= b.limit.Per {
+			delete(l.buckets, key)
+		}
+	}
+}
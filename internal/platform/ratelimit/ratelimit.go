@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket that holds up to Burst tokens and refills Burst tokens every Per, e.g.
+// 60 requests per minute.
+type Limit struct {
+	Burst int
+	Per   time.Duration
+}
+
+// Rate returns the refill rate in tokens per second.
+func (l Limit) Rate() float64 {
+	return float64(l.Burst) / l.Per.Seconds()
+}
+
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Burst, l.Per)
+}
+
+type Decision struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next token is available; zero when Allowed.
+	RetryAfter time.Duration
+}
+
+// Limiter takes a token from the bucket identified by key.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// refill computes the tokens of a bucket after elapsed time and tries to take one of them.
+func refill(tokens float64, elapsed time.Duration, limit Limit) (float64, Decision) {
+	tokens = math.Min(float64(limit.Burst), tokens+elapsed.Seconds()*limit.Rate())
+	if tokens < 1 {
+		wait := time.Duration((1 - tokens) / limit.Rate() * float64(time.Second))
+		return tokens, Decision{Allowed: false, RetryAfter: wait}
+	}
+
+	tokens--
+	return tokens, Decision{Allowed: true, Remaining: int(tokens)}
+}
+
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseLimits reads a comma separated list of per route limits, e.g.
+// "POST /order=60/m,POST /order/test=2/m". Routes use the Echo path template.
+func ParseLimits(value string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+	if strings.TrimSpace(value) == "" {
+		return limits, nil
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		route, raw, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || len(strings.Fields(route)) != 2 {
+			return nil, fmt.Errorf("invalid entry %q, expected \"METHOD /path=N/unit\"", entry)
+		}
+
+		limit, err := parseLimit(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit for %s: %w", route, err)
+		}
+		limits[strings.Join(strings.Fields(route), " ")] = limit
+	}
+
+	return limits, nil
+}
+
+func parseLimit(value string) (Limit, error) {
+	count, unit, found := strings.Cut(value, "/")
+	if !found {
+		return Limit{}, fmt.Errorf("expected N/unit, got %q", value)
+	}
+
+	burst, err := strconv.Atoi(count)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("invalid count %q", count)
+	}
+
+	per, ok := units[unit]
+	if !ok {
+		return Limit{}, fmt.Errorf("invalid unit %q, expected s, m or h", unit)
+	}
+
+	return Limit{Burst: burst, Per: per}, nil
+}
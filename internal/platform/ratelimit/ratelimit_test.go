@@ -0,0 +1,59 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func TestRateLimit(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) TestMemoryLimiter() {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+	limit := Limit{Burst: 2, Per: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		decision, err := limiter.Allow(context.Background(), "client", limit)
+		s.Require().NoError(err)
+		s.True(decision.Allowed)
+		s.Equal(1-i, decision.Remaining)
+	}
+
+	decision, err := limiter.Allow(context.Background(), "client", limit)
+	s.Require().NoError(err)
+	s.False(decision.Allowed)
+	s.Equal(30*time.Second, decision.RetryAfter)
+
+	decision, err = limiter.Allow(context.Background(), "other", limit)
+	s.Require().NoError(err)
+	s.True(decision.Allowed)
+
+	now = now.Add(30 * time.Second)
+	decision, err = limiter.Allow(context.Background(), "client", limit)
+	s.Require().NoError(err)
+	s.True(decision.Allowed)
+	s.Equal(0, decision.Remaining)
+}
+
+func (s *RateLimitTestSuite) TestParseLimits() {
+	limits, err := ParseLimits("POST /order=60/m, POST  /order/test=2/h")
+	s.Require().NoError(err)
+	s.Equal(map[string]Limit{
+		"POST /order":      {Burst: 60, Per: time.Minute},
+		"POST /order/test": {Burst: 2, Per: time.Hour},
+	}, limits)
+
+	for _, invalid := range []string{"/order=60/m", "POST /order=60", "POST /order=0/m", "POST /order=5/d"} {
+		_, err = ParseLimits(invalid)
+		s.Error(err, invalid)
+	}
+}
@@ -0,0 +1,41 @@
+package kvstore
+
+import (
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+)
+
+type BranchRepository struct {
+	branches map[string]tenant.Branch
+	order    []string
+}
+
+func NewBranchRepository(branches []tenant.Branch) *BranchRepository {
+	repo := &BranchRepository{
+		branches: make(map[string]tenant.Branch, len(branches)),
+	}
+	for _, b := range branches {
+		repo.branches[b.ID] = b
+		repo.order = append(repo.order, b.ID)
+	}
+
+	return repo
+}
+
+func (r *BranchRepository) GetBranch(branchID string) (*tenant.Branch, error) {
+	b, exists := r.branches[branchID]
+	if !exists {
+		return nil, errs.NotFound("branch_not_found", "branch not found")
+	}
+
+	return &b, nil
+}
+
+func (r *BranchRepository) ListBranches() []tenant.Branch {
+	result := make([]tenant.Branch, 0, len(r.order))
+	for _, id := range r.order {
+		result = append(result, r.branches[id])
+	}
+
+	return result
+}
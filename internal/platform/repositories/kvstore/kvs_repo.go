@@ -2,72 +2,102 @@ package kvstore
 
 import (
 	domain "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
 	"github.com/google/uuid"
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
-	"net/http"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
 	"sync"
 	"time"
 )
 
+var tracer = otel.Tracer("challenge-yuno/internal/platform/repositories/kvstore")
+
 type OrderRepository struct {
 	indexMap map[string]int
 	orders   []orderDB
-	mu       sync.Mutex
+	logger   *slog.Logger
+	mu       sync.RWMutex
 }
 
 type orderDB struct {
 	ID        string
+	BranchID  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Menu      []string
 	Status    string
 	Source    string
 	Type      string
+	Version   int
 }
 
-func NewOrderRepository() *OrderRepository {
+func NewOrderRepository(logger *slog.Logger) *OrderRepository {
 	im := make(map[string]int)
 	return &OrderRepository{
 		indexMap: im,
 		orders:   []orderDB{},
+		logger:   logging.Named(logger, "kvstore"),
 	}
 }
 
-func toOrderDB(o domain.Order) orderDB {
+func toOrderDB(branchID string, o domain.Order) orderDB {
 	now := time.Now().Truncate(time.Millisecond)
 	return orderDB{
 		ID:        uuid.New().String(),
+		BranchID:  branchID,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Menu:      o.Menu,
 		Status:    string(o.Status),
 		Source:    string(o.Source),
 		Type:      string(o.Type),
+		Version:   1,
 	}
 }
 
 func (o *orderDB) toOrderModel() *domain.Order {
 	return &domain.Order{
 		ID:        o.ID,
+		BranchID:  o.BranchID,
 		CreatedAt: o.CreatedAt,
 		UpdatedAt: o.UpdatedAt,
 		Menu:      o.Menu,
 		Status:    domain.Status(o.Status),
 		Source:    domain.Source(o.Source),
 		Type:      domain.OrderType(o.Type),
+		Version:   o.Version,
 	}
 }
 
-func (r *OrderRepository) AddOrder(order domain.Order) (*domain.Order, error) {
+// lookup returns the index of the order only if it belongs to the given branch, so orders of
+// other branches are reported as not found.
+func (r *OrderRepository) lookup(branch tenant.Branch, orderID string) (int, bool) {
+	index, exists := r.indexMap[orderID]
+	if !exists || r.orders[index].BranchID != branch.ID {
+		return 0, false
+	}
+
+	return index, true
+}
+
+func (r *OrderRepository) AddOrder(ctx context.Context, branch tenant.Branch, order domain.Order) (_ *domain.Order, err error) {
+	_, span := tracer.Start(ctx, "KVSOrderRepository.AddOrder", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	oDB := toOrderDB(order)
+	oDB := toOrderDB(branch.ID, order)
 
 	if _, exists := r.indexMap[oDB.ID]; exists {
-		log.Errorf("order %s already exists", oDB.ID)
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "order already added")
+		r.logger.ErrorContext(ctx, "order already exists", slog.String("id", oDB.ID))
+		return nil, errs.Conflict("order_already_exists", "order already added")
 	}
 
 	r.orders = append(r.orders, oDB)
@@ -76,60 +106,90 @@ func (r *OrderRepository) AddOrder(order domain.Order) (*domain.Order, error) {
 	return oDB.toOrderModel(), nil
 }
 
-func (r *OrderRepository) GetOrder(orderID string) (*domain.Order, error) {
+func (r *OrderRepository) GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (_ *domain.Order, err error) {
+	_, span := tracer.Start(ctx, "KVSOrderRepository.GetOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var index int
 	var exists bool
 
-	if index, exists = r.indexMap[orderID]; !exists {
-		log.Errorf("there is no order in db with id %s", orderID)
-		return nil, echo.NewHTTPError(http.StatusNotFound, "order not found")
+	if index, exists = r.lookup(branch, orderID); !exists {
+		r.logger.DebugContext(ctx, "order not found")
+		return nil, errs.NotFound("order_not_found", "order not found")
 	}
 
 	return r.orders[index].toOrderModel(), nil
 }
 
-func (r *OrderRepository) ListActiveOrders() ([]domain.Order, error) {
+func (r *OrderRepository) ListActiveOrders(ctx context.Context, branch tenant.Branch) (_ []domain.Order, err error) {
+	_, span := tracer.Start(ctx, "KVSOrderRepository.ListActiveOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	var result []domain.Order
 
 	for _, val := range r.orders {
-		if val.Status == string(domain.Pending) {
+		if val.BranchID == branch.ID && val.Status == string(domain.Pending) {
 			result = append(result, *val.toOrderModel())
 		}
 	}
 
 	if len(result) == 0 {
-		log.Errorf("there is no pending orders")
-		return nil, echo.NewHTTPError(http.StatusNotFound, "orders not found")
+		r.logger.DebugContext(ctx, "there is no pending orders")
+		return nil, errs.NotFound("no_active_orders", "orders not found")
 	}
 
 	return result, nil
 }
 
-func (r *OrderRepository) UpdateOrderStatus(orderID string, status domain.Status) (*domain.Order, error) {
+// UpdateOrderStatus changes the status of an order. Like the SQL repository it rejects updates
+// based on an older version of the order.
+func (r *OrderRepository) UpdateOrderStatus(ctx context.Context, branch tenant.Branch, update domain.StatusUpdate) (_ *domain.Order, err error) {
+	_, span := tracer.Start(ctx, "KVSOrderRepository.UpdateOrderStatus", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", update.OrderID)))
+	defer func() { tracing.End(span, err) }()
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	var index int
 	var exists bool
 
-	if index, exists = r.indexMap[orderID]; !exists {
-		log.Errorf("there is no order in db with id %s", orderID)
-		return nil, echo.NewHTTPError(http.StatusNotFound, "order not found")
+	if index, exists = r.lookup(branch, update.OrderID); !exists {
+		r.logger.DebugContext(ctx, "order not found")
+		return nil, errs.NotFound("order_not_found", "order not found")
+	}
+
+	if err = domain.CheckVersion(*r.orders[index].toOrderModel(), update.Version); err != nil {
+		return nil, err
 	}
 
-	r.orders[index].Status = string(status)
+	r.orders[index].Status = string(update.Status)
 	r.orders[index].UpdatedAt = time.Now().Truncate(time.Millisecond)
+	r.orders[index].Version++
 
 	return r.orders[index].toOrderModel(), nil
 }
 
-func (r *OrderRepository) GetAllOrders() []domain.Order {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+func (r *OrderRepository) GetAllOrders(ctx context.Context, branch tenant.Branch) []domain.Order {
+	_, span := tracer.Start(ctx, "KVSOrderRepository.GetAllOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer span.End()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	var orders []domain.Order
 
 	for _, o := range r.orders {
+		if o.BranchID != branch.ID {
+			continue
+		}
 		orders = append(orders, *o.toOrderModel())
 	}
 
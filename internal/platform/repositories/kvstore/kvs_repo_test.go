@@ -2,19 +2,28 @@ package kvstore
 
 import (
 	domain "challenge-yuno/internal/business/domain/order"
-	"github.com/labstack/echo/v4"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"context"
 	"github.com/stretchr/testify/suite"
-	"net/http"
+	"io"
+	"log/slog"
 	"testing"
 )
 
+var (
+	testBranch    = tenant.Branch{ID: "centro"}
+	otherBranch   = tenant.Branch{ID: "norte"}
+	discardLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+)
+
 type OrderRepositoryTestSuite struct {
 	suite.Suite
 	orderRepo *OrderRepository
 }
 
 func (s *OrderRepositoryTestSuite) SetupTest() {
-	s.orderRepo = NewOrderRepository()
+	s.orderRepo = NewOrderRepository(discardLogger)
 }
 
 func TestOrderRepository(t *testing.T) {
@@ -29,7 +38,7 @@ func (s *OrderRepositoryTestSuite) TestAddOrder() {
 		Type:   domain.Normal,
 	}
 
-	response, err := s.orderRepo.AddOrder(order)
+	response, err := s.orderRepo.AddOrder(context.Background(), testBranch, order)
 	s.Require().NoError(err)
 	s.Require().NotNil(response)
 	s.Require().NotNil(response.ID)
@@ -37,10 +46,10 @@ func (s *OrderRepositoryTestSuite) TestAddOrder() {
 }
 
 func (s *OrderRepositoryTestSuite) TestGetOrder() {
-	response, err := s.orderRepo.GetOrder("test-id")
+	response, err := s.orderRepo.GetOrder(context.Background(), testBranch, "test-id")
 	s.Require().Nil(response)
 	s.Require().Error(err)
-	s.Require().Equal(echo.NewHTTPError(http.StatusNotFound, "order not found"), err)
+	s.Require().Equal(errs.NotFound("order_not_found", "order not found"), err)
 
 	order := domain.Order{
 		Menu:   []string{"food", "drink"},
@@ -48,23 +57,23 @@ func (s *OrderRepositoryTestSuite) TestGetOrder() {
 		Source: domain.Delivery,
 		Type:   domain.Normal,
 	}
-	response, err = s.orderRepo.AddOrder(order)
+	response, err = s.orderRepo.AddOrder(context.Background(), testBranch, order)
 	s.Require().NoError(err)
 	s.Require().NotNil(response)
 	s.Require().NotNil(response.ID)
 	s.Require().NotNil(response.CreatedAt)
 
-	getResponse, err := s.orderRepo.GetOrder(response.ID)
+	getResponse, err := s.orderRepo.GetOrder(context.Background(), testBranch, response.ID)
 	s.Require().NoError(err)
 	s.Require().NotNil(getResponse)
 	s.Require().Equal(response, getResponse)
 }
 
 func (s *OrderRepositoryTestSuite) TestListActiveOrders() {
-	listOrders, err := s.orderRepo.ListActiveOrders()
+	listOrders, err := s.orderRepo.ListActiveOrders(context.Background(), testBranch)
 	s.Require().Nil(listOrders)
 	s.Require().Error(err)
-	s.Require().Equal(echo.NewHTTPError(http.StatusNotFound, "orders not found"), err)
+	s.Require().Equal(errs.NotFound("no_active_orders", "orders not found"), err)
 
 	order := domain.Order{
 		Menu:   []string{"food", "drink"},
@@ -73,23 +82,23 @@ func (s *OrderRepositoryTestSuite) TestListActiveOrders() {
 		Type:   domain.Normal,
 	}
 
-	response, err := s.orderRepo.AddOrder(order)
+	response, err := s.orderRepo.AddOrder(context.Background(), testBranch, order)
 	s.Require().NoError(err)
 	s.Require().NotNil(response)
 	s.Require().NotNil(response.ID)
 	s.Require().NotNil(response.CreatedAt)
 
-	listOrders, err = s.orderRepo.ListActiveOrders()
+	listOrders, err = s.orderRepo.ListActiveOrders(context.Background(), testBranch)
 	s.Require().NoError(err)
 	s.Require().Equal(1, len(listOrders))
 	s.Require().Equal(*response, listOrders[0])
 }
 
 func (s *OrderRepositoryTestSuite) TestUpdateOrderStatus() {
-	orderUpdated, err := s.orderRepo.UpdateOrderStatus("some-id", domain.InPreparation)
+	orderUpdated, err := s.orderRepo.UpdateOrderStatus(context.Background(), testBranch, domain.StatusUpdate{OrderID: "some-id", Status: domain.InPreparation})
 	s.Require().Nil(orderUpdated)
 	s.Require().Error(err)
-	s.Require().Equal(echo.NewHTTPError(http.StatusNotFound, "order not found"), err)
+	s.Require().Equal(errs.NotFound("order_not_found", "order not found"), err)
 
 	order := domain.Order{
 		Menu:   []string{"food", "drink"},
@@ -98,15 +107,66 @@ func (s *OrderRepositoryTestSuite) TestUpdateOrderStatus() {
 		Type:   domain.Normal,
 	}
 
-	response, err := s.orderRepo.AddOrder(order)
+	response, err := s.orderRepo.AddOrder(context.Background(), testBranch, order)
 	s.Require().NoError(err)
 	s.Require().NotNil(response)
 	s.Require().NotNil(response.ID)
 	s.Require().NotNil(response.CreatedAt)
 
-	orderUpdated, err = s.orderRepo.UpdateOrderStatus(response.ID, domain.InPreparation)
+	orderUpdated, err = s.orderRepo.UpdateOrderStatus(context.Background(), testBranch, domain.StatusUpdate{OrderID: response.ID, Status: domain.InPreparation})
 	s.Require().NoError(err)
 	s.Require().NotNil(orderUpdated)
 	s.Require().Equal(response.ID, orderUpdated.ID)
 	s.Require().Equal(domain.InPreparation, orderUpdated.Status)
+	s.Require().Equal(2, orderUpdated.Version)
+}
+
+func (s *OrderRepositoryTestSuite) TestUpdateOrderStatusVersion() {
+	response, err := s.orderRepo.AddOrder(context.Background(), testBranch, domain.Order{Menu: []string{"food"}, Status: domain.Pending})
+	s.Require().NoError(err)
+	s.Require().Equal(1, response.Version)
+
+	read := response.Version
+	updated, err := s.orderRepo.UpdateOrderStatus(context.Background(), testBranch,
+		domain.StatusUpdate{OrderID: response.ID, Status: domain.InPreparation, Version: &read})
+	s.Require().NoError(err)
+	s.Require().Equal(2, updated.Version)
+
+	// a second screen still showing the first version can't overwrite the change
+	updated, err = s.orderRepo.UpdateOrderStatus(context.Background(), testBranch,
+		domain.StatusUpdate{OrderID: response.ID, Status: domain.Canceled, Version: &read})
+	s.Require().Nil(updated)
+	s.Require().Equal(domain.ErrOrderModified, err)
+
+	current, err := s.orderRepo.GetOrder(context.Background(), testBranch, response.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(domain.InPreparation, current.Status)
+}
+
+func (s *OrderRepositoryTestSuite) TestBranchIsolation() {
+	order := domain.Order{
+		Menu:   []string{"food"},
+		Status: domain.Pending,
+		Source: domain.Phone,
+		Type:   domain.Normal,
+	}
+
+	response, err := s.orderRepo.AddOrder(context.Background(), testBranch, order)
+	s.Require().NoError(err)
+	s.Require().Equal(testBranch.ID, response.BranchID)
+
+	getResponse, err := s.orderRepo.GetOrder(context.Background(), otherBranch, response.ID)
+	s.Require().Nil(getResponse)
+	s.Require().Equal(errs.NotFound("order_not_found", "order not found"), err)
+
+	orderUpdated, err := s.orderRepo.UpdateOrderStatus(context.Background(), otherBranch, domain.StatusUpdate{OrderID: response.ID, Status: domain.Canceled})
+	s.Require().Nil(orderUpdated)
+	s.Require().Equal(errs.NotFound("order_not_found", "order not found"), err)
+
+	listOrders, err := s.orderRepo.ListActiveOrders(context.Background(), otherBranch)
+	s.Require().Nil(listOrders)
+	s.Require().Error(err)
+
+	s.Require().Empty(s.orderRepo.GetAllOrders(context.Background(), otherBranch))
+	s.Require().Len(s.orderRepo.GetAllOrders(context.Background(), testBranch), 1)
 }
@@ -0,0 +1,20 @@
+package kvstore
+
+import "challenge-yuno/internal/business/domain/menu"
+
+type MenuRepository struct {
+	items []menu.Item
+}
+
+func NewMenuRepository(items []menu.Item) *MenuRepository {
+	return &MenuRepository{
+		items: items,
+	}
+}
+
+func (r *MenuRepository) ListItems() []menu.Item {
+	result := make([]menu.Item, len(r.items))
+	copy(result, r.items)
+
+	return result
+}
@@ -0,0 +1,257 @@
+package sql
+
+import (
+	model "challenge-yuno/internal/business/domain/audit"
+	domain "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// appendOnlyTrigger rejects any UPDATE or DELETE on audit_entries, so rows can only be inserted.
+const appendOnlyTrigger = `
+CREATE OR REPLACE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_entries_append_only ON audit_entries;
+
+CREATE TRIGGER audit_entries_append_only
+	BEFORE UPDATE OR DELETE ON audit_entries
+	FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
+`
+
+type AuditRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewAuditRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *AuditRepository {
+	logger = logging.Named(logger, "sql")
+	return &AuditRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logger,
+	}
+}
+
+type auditEntryDB struct {
+	ID        string    `gorm:"type:string; size:255; primary_key;"`
+	BranchID  string    `gorm:"type:string; size:255; not null; index"`
+	CreatedAt time.Time `gorm:"<-:create; type:timestamptz; not null; index"`
+	Actor     string    `gorm:"type:string; size:255; not null;"`
+	Endpoint  string    `gorm:"type:string; size:255; not null;"`
+	OrderID   string    `gorm:"type:string; size:255; not null; index"`
+	ClientIP  string    `gorm:"type:string; size:255;"`
+	RequestID string    `gorm:"type:string; size:255;"`
+	Before    *string   `gorm:"type:jsonb;"`
+	After     *string   `gorm:"type:jsonb;"`
+	Changes   string    `gorm:"type:jsonb; not null;"`
+}
+
+func (auditEntryDB) TableName() string {
+	return "audit_entries"
+}
+
+func toAuditEntryDB(branchID string, e model.Entry) (auditEntryDB, error) {
+	changes, err := json.Marshal(e.Changes)
+	if err != nil {
+		return auditEntryDB{}, err
+	}
+
+	before, err := marshalSnapshot(e.Before)
+	if err != nil {
+		return auditEntryDB{}, err
+	}
+
+	after, err := marshalSnapshot(e.After)
+	if err != nil {
+		return auditEntryDB{}, err
+	}
+
+	return auditEntryDB{
+		ID:        uuid.New().String(),
+		BranchID:  branchID,
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+		Actor:     e.Actor,
+		Endpoint:  e.Endpoint,
+		OrderID:   e.OrderID,
+		ClientIP:  e.ClientIP,
+		RequestID: e.RequestID,
+		Before:    before,
+		After:     after,
+		Changes:   string(changes),
+	}, nil
+}
+
+func marshalSnapshot(o *domain.Order) (*string, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := string(raw)
+	return &snapshot, nil
+}
+
+func unmarshalSnapshot(raw *string) (*domain.Order, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var o domain.Order
+	if err := json.Unmarshal([]byte(*raw), &o); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// toEntryModel decodes the stored snapshots. A corrupt column is logged and left empty so one bad
+// row doesn't hide the rest of the log.
+func (r *AuditRepository) toEntryModel(ctx context.Context, a auditEntryDB) model.Entry {
+	changes := []model.Change{}
+	if err := json.Unmarshal([]byte(a.Changes), &changes); err != nil {
+		r.logger.ErrorContext(ctx, "error reading audit changes", slog.String("entry_id", a.ID), slog.Any("error", err))
+	}
+
+	before, err := unmarshalSnapshot(a.Before)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error reading audit snapshot", slog.String("entry_id", a.ID), slog.Any("error", err))
+	}
+
+	after, err := unmarshalSnapshot(a.After)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error reading audit snapshot", slog.String("entry_id", a.ID), slog.Any("error", err))
+	}
+
+	return model.Entry{
+		ID:        a.ID,
+		BranchID:  a.BranchID,
+		CreatedAt: a.CreatedAt,
+		Actor:     a.Actor,
+		Endpoint:  a.Endpoint,
+		OrderID:   a.OrderID,
+		ClientIP:  a.ClientIP,
+		RequestID: a.RequestID,
+		Before:    before,
+		After:     after,
+		Changes:   changes,
+	}
+}
+
+func (r *AuditRepository) scoped(ctx context.Context, branch tenant.Branch) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&auditEntryDB{}).Where("branch_id = ?", branch.ID)
+}
+
+func (r *AuditRepository) filtered(ctx context.Context, branch tenant.Branch, filter model.Filter) *gorm.DB {
+	query := r.scoped(ctx, branch)
+	if len(filter.Actor) > 0 {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if len(filter.Endpoint) > 0 {
+		query = query.Where("endpoint = ?", filter.Endpoint)
+	}
+	if len(filter.OrderID) > 0 {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	return query.Order("created_at ASC").Order("id ASC")
+}
+
+func (r *AuditRepository) AddEntry(ctx context.Context, branch tenant.Branch, entry model.Entry) (*model.Entry, error) {
+	aDB, err := toAuditEntryDB(branch.ID, entry)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error encoding audit entry", slog.Any("error", err))
+		return nil, fmt.Errorf("audit entry wasn't created: %w", err)
+	}
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AddEntry")
+	defer cancel()
+
+	if err = r.db.WithContext(ctx).Create(&aDB).Error; err != nil {
+		r.logger.ErrorContext(ctx, "error saving audit entry", slog.Any("error", err))
+		return nil, dbError(ctx, err, "audit entry wasn't created")
+	}
+
+	result := r.toEntryModel(ctx, aDB)
+	return &result, nil
+}
+
+func (r *AuditRepository) ListEntries(ctx context.Context, branch tenant.Branch, filter model.Filter) ([]model.Entry, error) {
+	var entriesDB []auditEntryDB
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListEntries")
+	defer cancel()
+
+	err := r.filtered(ctx, branch, filter).Limit(limit).Find(&entriesDB).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting audit entries", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting audit entries")
+	}
+
+	result := make([]model.Entry, 0, len(entriesDB))
+	for _, aDB := range entriesDB {
+		result = append(result, r.toEntryModel(ctx, aDB))
+	}
+
+	return result, nil
+}
+
+// StreamEntries calls fn for every entry matching the filter, reading them one row at a time so
+// exports don't need to hold the whole log in memory. The filter limit is ignored and, as exports
+// may be long, only the request context bounds the stream.
+func (r *AuditRepository) StreamEntries(ctx context.Context, branch tenant.Branch, filter model.Filter, fn func(model.Entry) error) error {
+	rows, err := r.filtered(ctx, branch, filter).Rows()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error streaming audit entries", slog.Any("error", err))
+		return dbError(ctx, err, "error getting audit entries")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var aDB auditEntryDB
+		if err = r.db.ScanRows(rows, &aDB); err != nil {
+			r.logger.ErrorContext(ctx, "error reading audit entry", slog.Any("error", err))
+			return dbError(ctx, err, "error getting audit entries")
+		}
+
+		if err = fn(r.toEntryModel(ctx, aDB)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
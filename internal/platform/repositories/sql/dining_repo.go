@@ -0,0 +1,357 @@
+package sql
+
+import (
+	model "challenge-yuno/internal/business/domain/dining"
+	domain "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+)
+
+// openSessionIndex lets a table have a single open session, so two waiters seating the same
+// table at once can't both succeed.
+const openSessionIndex = `
+CREATE UNIQUE INDEX IF NOT EXISTS dining_sessions_open_table
+	ON dining_sessions (branch_id, table_id) WHERE closed_at IS NULL;
+`
+
+type DiningRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewDiningRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *DiningRepository {
+	logger = logging.Named(logger, "sql")
+	return &DiningRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logger,
+	}
+}
+
+type diningTableDB struct {
+	BranchID  string    `gorm:"type:string; size:255; primaryKey"`
+	ID        string    `gorm:"type:string; size:255; primaryKey"`
+	Seats     int       `gorm:"type:integer; not null"`
+	CreatedAt time.Time `gorm:"<-:create; type:timestamptz; not null"`
+}
+
+func (diningTableDB) TableName() string {
+	return "dining_tables"
+}
+
+type diningSessionDB struct {
+	ID       string     `gorm:"type:string; size:255; primaryKey"`
+	BranchID string     `gorm:"type:string; size:255; not null; index"`
+	TableID  string     `gorm:"type:string; size:255; not null; index"`
+	OpenedAt time.Time  `gorm:"type:timestamptz; not null"`
+	ClosedAt *time.Time `gorm:"type:timestamptz"`
+}
+
+func (diningSessionDB) TableName() string {
+	return "dining_sessions"
+}
+
+// sessionOrderDB attaches an order to a session. OrderID is the key, so an order belongs to one
+// session at most.
+type sessionOrderDB struct {
+	OrderID    string    `gorm:"type:string; size:255; primaryKey"`
+	SessionID  string    `gorm:"type:string; size:255; not null; index"`
+	BranchID   string    `gorm:"type:string; size:255; not null"`
+	AttachedAt time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (sessionOrderDB) TableName() string {
+	return "session_orders"
+}
+
+func (t *diningTableDB) toTableModel() *model.Table {
+	return &model.Table{
+		ID:        t.ID,
+		BranchID:  t.BranchID,
+		Seats:     t.Seats,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func (s *diningSessionDB) toSessionModel(orderIDs []string) *model.Session {
+	if orderIDs == nil {
+		orderIDs = []string{}
+	}
+
+	return &model.Session{
+		ID:       s.ID,
+		BranchID: s.BranchID,
+		TableID:  s.TableID,
+		OpenedAt: s.OpenedAt,
+		ClosedAt: s.ClosedAt,
+		OrderIDs: orderIDs,
+	}
+}
+
+// isUniqueViolation tells whether err is Postgres rejecting a duplicate key.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (r *DiningRepository) AddTable(ctx context.Context, branch tenant.Branch, table model.Table) (_ *model.Table, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.AddTable", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AddTable")
+	defer cancel()
+
+	tDB := diningTableDB{
+		BranchID:  branch.ID,
+		ID:        table.ID,
+		Seats:     table.Seats,
+		CreatedAt: time.Now().Truncate(time.Millisecond),
+	}
+	if err = r.db.WithContext(ctx).Create(&tDB).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, errs.Conflict("table_already_exists", "table already exists")
+		}
+		r.logger.ErrorContext(ctx, "error saving table", slog.Any("error", err))
+		return nil, dbError(ctx, err, "table wasn't created")
+	}
+
+	return tDB.toTableModel(), nil
+}
+
+func (r *DiningRepository) GetTable(ctx context.Context, branch tenant.Branch, tableID string) (_ *model.Table, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.GetTable", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "GetTable")
+	defer cancel()
+
+	var tDB diningTableDB
+	err = r.db.WithContext(ctx).First(&tDB, "branch_id = ? AND id = ?", branch.ID, tableID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("table_not_found", "table not found")
+		}
+		r.logger.ErrorContext(ctx, "error getting table", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting table")
+	}
+
+	return tDB.toTableModel(), nil
+}
+
+func (r *DiningRepository) ListTables(ctx context.Context, branch tenant.Branch) (_ []model.Table, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.ListTables", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListTables")
+	defer cancel()
+
+	var tablesDB []diningTableDB
+	err = r.db.WithContext(ctx).Where("branch_id = ?", branch.ID).Order("id ASC").Find(&tablesDB).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting tables", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting tables")
+	}
+	if len(tablesDB) == 0 {
+		return nil, errs.NotFound("no_tables", "there is no tables")
+	}
+
+	result := make([]model.Table, 0, len(tablesDB))
+	for _, tDB := range tablesDB {
+		result = append(result, *tDB.toTableModel())
+	}
+
+	return result, nil
+}
+
+func (r *DiningRepository) OpenSession(ctx context.Context, branch tenant.Branch, tableID string) (_ *model.Session, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.OpenSession", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "OpenSession")
+	defer cancel()
+
+	sDB := diningSessionDB{
+		ID:       uuid.New().String(),
+		BranchID: branch.ID,
+		TableID:  tableID,
+		OpenedAt: time.Now().Truncate(time.Millisecond),
+	}
+	if err = r.db.WithContext(ctx).Create(&sDB).Error; err != nil {
+		if isUniqueViolation(err) {
+			return nil, errs.Conflict("session_already_open", "the table already has an open session")
+		}
+		r.logger.ErrorContext(ctx, "error opening session", slog.Any("error", err))
+		return nil, dbError(ctx, err, "session wasn't opened")
+	}
+
+	return sDB.toSessionModel(nil), nil
+}
+
+// GetOpenSession returns the session currently seated at the table, with its orders.
+func (r *DiningRepository) GetOpenSession(ctx context.Context, branch tenant.Branch, tableID string) (_ *model.Session, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.GetOpenSession", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "GetOpenSession")
+	defer cancel()
+
+	var sDB diningSessionDB
+	err = r.db.WithContext(ctx).
+		First(&sDB, "branch_id = ? AND table_id = ? AND closed_at IS NULL", branch.ID, tableID).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("no_open_session", "the table has no open session")
+		}
+		r.logger.ErrorContext(ctx, "error getting session", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting session")
+	}
+
+	var orderIDs []string
+	err = r.db.WithContext(ctx).Model(&sessionOrderDB{}).
+		Where("session_id = ?", sDB.ID).
+		Order("attached_at ASC").
+		Pluck("order_id", &orderIDs).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting session orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting session")
+	}
+
+	return sDB.toSessionModel(orderIDs), nil
+}
+
+// AttachOrder adds the order to the session, as long as the session is still open. The check and
+// the insert are a single statement that locks the session row for update until it commits, the
+// lock CloseSession takes before checking the orders, so a concurrent close either sees the order
+// or makes the attach fail.
+func (r *DiningRepository) AttachOrder(ctx context.Context, branch tenant.Branch, sessionID, orderID string) (err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.AttachOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AttachOrder")
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Exec(`INSERT INTO session_orders (order_id, session_id, branch_id, attached_at)
+			SELECT ?, s.id, s.branch_id, ? FROM dining_sessions s
+			WHERE s.branch_id = ? AND s.id = ? AND s.closed_at IS NULL
+			FOR UPDATE`,
+			orderID, time.Now().Truncate(time.Millisecond), branch.ID, sessionID)
+	if err = result.Error; err != nil {
+		if isUniqueViolation(err) {
+			return errs.Conflict("order_already_attached", "order is already attached to a session")
+		}
+		r.logger.ErrorContext(ctx, "error attaching order", slog.Any("error", err))
+		return dbError(ctx, err, "order wasn't attached")
+	}
+	if result.RowsAffected == 0 {
+		return errs.NotFound("no_open_session", "the table has no open session")
+	}
+
+	return nil
+}
+
+func (r *DiningRepository) ListSessionOrders(ctx context.Context, branch tenant.Branch, sessionID string) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.ListSessionOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListSessionOrders")
+	defer cancel()
+
+	orders, err := sessionOrders(r.db.WithContext(ctx), branch, sessionID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting session orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting session orders")
+	}
+
+	return orders, nil
+}
+
+// sessionOrders reads the orders attached to the session through db, in the order they were
+// attached.
+func sessionOrders(db *gorm.DB, branch tenant.Branch, sessionID string) ([]domain.Order, error) {
+	var ordersDB []orderDB
+	err := db.Model(&orderDB{}).
+		Joins("JOIN session_orders ON session_orders.order_id = order_dbs.id").
+		Where("order_dbs.branch_id = ? AND session_orders.session_id = ?", branch.ID, sessionID).
+		Order("session_orders.attached_at ASC").
+		Find(&ordersDB).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.Order, 0, len(ordersDB))
+	for _, oDB := range ordersDB {
+		result = append(result, *oDB.toOrderModel())
+	}
+
+	return result, nil
+}
+
+// CloseSession closes the session if every order attached to it was delivered or canceled. The
+// session row is locked before its orders are checked, the same lock AttachOrder takes, so an
+// attach either commits first and its order is checked, or waits and then finds the session
+// closed.
+func (r *DiningRepository) CloseSession(ctx context.Context, branch tenant.Branch, sessionID string) (_ *model.Session, err error) {
+	ctx, span := tracer.Start(ctx, "DiningRepository.CloseSession", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "CloseSession")
+	defer cancel()
+
+	var closed *model.Session
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sDB diningSessionDB
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&sDB, "branch_id = ? AND id = ? AND closed_at IS NULL", branch.ID, sessionID).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.NotFound("no_open_session", "the table has no open session")
+			}
+			return err
+		}
+
+		// a separate statement, so it sees the orders attached by whoever held the lock before
+		orders, err := sessionOrders(tx, branch, sessionID)
+		if err != nil {
+			return err
+		}
+		if err = model.CanClose(orders); err != nil {
+			return err
+		}
+
+		now := time.Now().Truncate(time.Millisecond)
+		if err = tx.Model(&sDB).Update("closed_at", now).Error; err != nil {
+			return err
+		}
+		sDB.ClosedAt = &now
+		closed = sDB.toSessionModel(nil)
+		return nil
+	})
+	if err != nil {
+		if _, known := errs.As(err); known {
+			return nil, err
+		}
+		r.logger.ErrorContext(ctx, "error closing session", slog.Any("error", err))
+		return nil, dbError(ctx, err, "session wasn't closed")
+	}
+
+	return closed, nil
+}
@@ -0,0 +1,44 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// PingCheck verifies the database accepts connections.
+func PingCheck(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// MigrationsCheck verifies the database is at the latest migration and the tables the repositories
+// rely on exist.
+func MigrationsCheck(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		db := db.WithContext(ctx)
+		var version int
+		if err := db.Model(&schemaMigrationDB{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+			return err
+		}
+		if version < latestMigration() {
+			return fmt.Errorf("database is at migration %d, expected %d", version, latestMigration())
+		}
+		for _, model := range []any{&orderDB{}, &statusChangeDB{}, &archivedOrderDB{}, &orderRevisionDB{}, &dailySummaryDB{}, &auditEntryDB{}, &outboxMessageDB{}, &diningTableDB{}, &diningSessionDB{}, &sessionOrderDB{},
+			&reservationDB{}, &reservationOrderDB{}, &ingredientDB{}, &recipeLineDB{}} {
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(model); err != nil {
+				return err
+			}
+			if !db.Migrator().HasTable(stmt.Table) {
+				return fmt.Errorf("table %s is missing", stmt.Table)
+			}
+		}
+		return nil
+	}
+}
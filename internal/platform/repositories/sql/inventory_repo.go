@@ -0,0 +1,291 @@
+package sql
+
+import (
+	model "challenge-yuno/internal/business/domain/inventory"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+type InventoryRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewInventoryRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *InventoryRepository {
+	return &InventoryRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logging.Named(logger, "sql"),
+	}
+}
+
+type ingredientDB struct {
+	BranchID  string    `gorm:"type:string; size:255; primaryKey"`
+	Name      string    `gorm:"type:string; size:255; primaryKey"`
+	Unit      string    `gorm:"type:string; size:50; not null"`
+	Stock     int64     `gorm:"type:bigint; not null"`
+	UpdatedAt time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (ingredientDB) TableName() string {
+	return "ingredients"
+}
+
+// recipeLineDB is one ingredient of a menu item. Recipes are shared by every branch, like the
+// menu, while stock is kept per branch.
+type recipeLineDB struct {
+	Item       string `gorm:"type:string; size:255; primaryKey"`
+	Ingredient string `gorm:"type:string; size:255; primaryKey"`
+	Quantity   int64  `gorm:"type:bigint; not null"`
+}
+
+func (recipeLineDB) TableName() string {
+	return "recipe_lines"
+}
+
+func (i *ingredientDB) toIngredientModel() *model.Ingredient {
+	return &model.Ingredient{
+		BranchID:  i.BranchID,
+		Name:      i.Name,
+		Unit:      i.Unit,
+		Stock:     i.Stock,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+// SetIngredient records the stock counted for the ingredient, creating it the first time.
+func (r *InventoryRepository) SetIngredient(ctx context.Context, branch tenant.Branch, ingredient model.Ingredient) (_ *model.Ingredient, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.SetIngredient", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "SetIngredient")
+	defer cancel()
+
+	iDB := ingredientDB{
+		BranchID:  branch.ID,
+		Name:      ingredient.Name,
+		Unit:      ingredient.Unit,
+		Stock:     ingredient.Stock,
+		UpdatedAt: time.Now().Truncate(time.Millisecond),
+	}
+	err = r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "branch_id"}, {Name: "name"}},
+			DoUpdates: clause.AssignmentColumns([]string{"unit", "stock", "updated_at"}),
+		}).
+		Create(&iDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error saving ingredient", slog.Any("error", err))
+		return nil, dbError(ctx, err, "ingredient wasn't saved")
+	}
+
+	return iDB.toIngredientModel(), nil
+}
+
+func (r *InventoryRepository) ListIngredients(ctx context.Context, branch tenant.Branch) (_ []model.Ingredient, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.ListIngredients", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListIngredients")
+	defer cancel()
+
+	var ingredientsDB []ingredientDB
+	err = r.db.WithContext(ctx).Where("branch_id = ?", branch.ID).Order("name ASC").Find(&ingredientsDB).Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting ingredients", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting ingredients")
+	}
+	if len(ingredientsDB) == 0 {
+		return nil, errs.NotFound("no_ingredients", "there is no ingredients")
+	}
+
+	result := make([]model.Ingredient, 0, len(ingredientsDB))
+	for _, iDB := range ingredientsDB {
+		result = append(result, *iDB.toIngredientModel())
+	}
+
+	return result, nil
+}
+
+// Stock returns the stock of the given ingredients, or of every ingredient of the branch when
+// none is given. Ingredients the branch never counted are left out.
+func (r *InventoryRepository) Stock(ctx context.Context, branch tenant.Branch, ingredients ...string) (_ map[string]int64, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.Stock", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Stock")
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Where("branch_id = ?", branch.ID)
+	if len(ingredients) > 0 {
+		query = query.Where("name IN ?", ingredients)
+	}
+
+	var ingredientsDB []ingredientDB
+	if err = query.Find(&ingredientsDB).Error; err != nil {
+		r.logger.ErrorContext(ctx, "error getting stock", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting stock")
+	}
+
+	stock := make(map[string]int64, len(ingredientsDB))
+	for _, iDB := range ingredientsDB {
+		stock[iDB.Name] = iDB.Stock
+	}
+
+	return stock, nil
+}
+
+// SetRecipe replaces the recipe of the item.
+func (r *InventoryRepository) SetRecipe(ctx context.Context, recipe model.Recipe) (_ *model.Recipe, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.SetRecipe")
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "SetRecipe")
+	defer cancel()
+
+	linesDB := make([]recipeLineDB, 0, len(recipe.Lines))
+	for _, line := range recipe.Lines {
+		linesDB = append(linesDB, recipeLineDB{Item: recipe.Item, Ingredient: line.Ingredient, Quantity: line.Quantity})
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item = ?", recipe.Item).Delete(&recipeLineDB{}).Error; err != nil {
+			return err
+		}
+		if len(linesDB) == 0 {
+			return nil
+		}
+		return tx.Create(&linesDB).Error
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error saving recipe", slog.Any("error", err))
+		return nil, dbError(ctx, err, "recipe wasn't saved")
+	}
+
+	return &recipe, nil
+}
+
+// ListRecipes returns the recipes of the given items, or every recipe when none is given. Items
+// without a recipe are left out.
+func (r *InventoryRepository) ListRecipes(ctx context.Context, items ...string) (_ []model.Recipe, err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.ListRecipes")
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListRecipes")
+	defer cancel()
+
+	query := r.db.WithContext(ctx).Order("item ASC, ingredient ASC")
+	if len(items) > 0 {
+		query = query.Where("item IN ?", items)
+	}
+
+	var linesDB []recipeLineDB
+	if err = query.Find(&linesDB).Error; err != nil {
+		r.logger.ErrorContext(ctx, "error getting recipes", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting recipes")
+	}
+
+	var result []model.Recipe
+	for _, lDB := range linesDB {
+		if len(result) == 0 || result[len(result)-1].Item != lDB.Item {
+			result = append(result, model.Recipe{Item: lDB.Item})
+		}
+		last := &result[len(result)-1]
+		last.Lines = append(last.Lines, model.RecipeLine{Ingredient: lDB.Ingredient, Quantity: lDB.Quantity})
+	}
+
+	return result, nil
+}
+
+// Consume takes the required quantities out of the stock of the branch. Either every ingredient
+// is covered and all of them are decremented, or nothing changes and the missing ones are
+// reported.
+func (r *InventoryRepository) Consume(ctx context.Context, branch tenant.Branch, required map[string]int64) (err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.Consume", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Consume")
+	defer cancel()
+
+	var missing []string
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Truncate(time.Millisecond)
+		// a fixed order keeps two orders sharing ingredients from locking rows in opposite order
+		for _, ingredient := range sortedKeys(required) {
+			result := tx.Model(&ingredientDB{}).
+				Where("branch_id = ? AND name = ? AND stock >= ?", branch.ID, ingredient, required[ingredient]).
+				Updates(map[string]any{"stock": gorm.Expr("stock - ?", required[ingredient]), "updated_at": now})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				missing = append(missing, ingredient)
+			}
+		}
+
+		if len(missing) > 0 {
+			return model.InsufficientStock(missing)
+		}
+		return nil
+	})
+	if err != nil {
+		if len(missing) > 0 {
+			return err
+		}
+		r.logger.ErrorContext(ctx, "error consuming stock", slog.Any("error", err))
+		return dbError(ctx, err, "stock wasn't consumed")
+	}
+
+	return nil
+}
+
+// Restore puts back stock taken by Consume. Ingredients the branch no longer tracks are skipped.
+func (r *InventoryRepository) Restore(ctx context.Context, branch tenant.Branch, required map[string]int64) (err error) {
+	ctx, span := tracer.Start(ctx, "InventoryRepository.Restore", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Restore")
+	defer cancel()
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().Truncate(time.Millisecond)
+		for _, ingredient := range sortedKeys(required) {
+			err := tx.Model(&ingredientDB{}).
+				Where("branch_id = ? AND name = ?", branch.ID, ingredient).
+				Updates(map[string]any{"stock": gorm.Expr("stock + ?", required[ingredient]), "updated_at": now}).
+				Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error restoring stock", slog.Any("error", err))
+		return dbError(ctx, err, "stock wasn't restored")
+	}
+
+	return nil
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
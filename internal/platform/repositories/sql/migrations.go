@@ -0,0 +1,210 @@
+package sql
+
+import (
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+)
+
+// migrationLock is the key of the advisory lock held while migrating, so two instances starting
+// at once don't apply the same migration twice.
+const migrationLock = 4871203
+
+// migration is a schema change. Migrations run once each, in version order, and are recorded in
+// schema_migrations.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *gorm.DB, legacyBranchID string) error
+}
+
+// migrations must only be appended to: a deployed database has already applied the ones it
+// records, so changing them has no effect there.
+var migrations = []migration{
+	{version: 1, name: "create_tables", up: func(tx *gorm.DB, legacyBranchID string) error {
+		if err := addOrderBranches(tx, legacyBranchID); err != nil {
+			return err
+		}
+		err := createTables(tx, &orders{}, &orderDB{}, &statusChangeDB{}, &archivedOrderDB{}, &orderRevisionDB{},
+			&dailySummaryDB{}, &auditEntryDB{}, &outboxMessageDB{}, &diningTableDB{}, &diningSessionDB{}, &sessionOrderDB{},
+			&reservationDB{}, &reservationOrderDB{}, &ingredientDB{}, &recipeLineDB{}, &rateLimitBucketDB{})
+		if err != nil {
+			return err
+		}
+		if err = tx.Exec(appendOnlyTrigger).Error; err != nil {
+			return err
+		}
+		return tx.Exec(openSessionIndex).Error
+	}},
+	{version: 2, name: "order_timestamps_with_date", up: func(tx *gorm.DB, _ string) error {
+		firstChange := "(SELECT MIN(h.changed_at) FROM order_status_history h WHERE h.order_id = t.id)"
+		lastChange := "(SELECT MAX(h.changed_at) FROM order_status_history h WHERE h.order_id = t.id)"
+		for _, table := range []string{"order_dbs", "order_archive"} {
+			if err := timeToTimestamptz(tx, table, "created_at", firstChange); err != nil {
+				return err
+			}
+			if err := timeToTimestamptz(tx, table, "updated_at", lastChange); err != nil {
+				return err
+			}
+		}
+		return createTables(tx, &orderDB{}, &archivedOrderDB{})
+	}},
+	{version: 3, name: "audit_timestamps_with_date", up: func(tx *gorm.DB, _ string) error {
+		// an entry is written with the change it records, so it takes the date of the status
+		// change of its order closest in time of day
+		closestChange := `(SELECT h.changed_at::date + t.created_at FROM order_status_history h WHERE h.order_id = t.order_id
+			ORDER BY ABS(EXTRACT(EPOCH FROM h.changed_at::time - t.created_at)) LIMIT 1)`
+		// the conversion rewrites every row, which the append-only trigger would reject
+		if err := tx.Exec("ALTER TABLE audit_entries DISABLE TRIGGER audit_entries_append_only").Error; err != nil {
+			return err
+		}
+		if err := timeToTimestamptz(tx, "audit_entries", "created_at", closestChange); err != nil {
+			return err
+		}
+		if err := tx.Exec("ALTER TABLE audit_entries ENABLE TRIGGER audit_entries_append_only").Error; err != nil {
+			return err
+		}
+		return createTables(tx, &auditEntryDB{})
+	}},
+}
+
+type schemaMigrationDB struct {
+	Version   int       `gorm:"primaryKey; autoIncrement:false"`
+	Name      string    `gorm:"type:string; size:255; not null"`
+	AppliedAt time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (schemaMigrationDB) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrate applies the migrations the database hasn't recorded yet, in a single transaction.
+// Orders saved before branches existed are assigned to legacyBranchID.
+func Migrate(ctx context.Context, db *gorm.DB, legacyBranchID string, logger *slog.Logger) error {
+	logger = logging.Named(logger, "sql")
+
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLock).Error; err != nil {
+			return err
+		}
+		if err := createTables(tx, &schemaMigrationDB{}); err != nil {
+			return err
+		}
+
+		var current int
+		err := tx.Model(&schemaMigrationDB{}).Select("COALESCE(MAX(version), 0)").Scan(&current).Error
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if m.version <= current {
+				continue
+			}
+			if err = m.up(tx, legacyBranchID); err != nil {
+				return fmt.Errorf("migration %d %s: %w", m.version, m.name, err)
+			}
+			err = tx.Create(&schemaMigrationDB{Version: m.version, Name: m.name, AppliedAt: time.Now()}).Error
+			if err != nil {
+				return err
+			}
+			logger.InfoContext(ctx, "applied migration", slog.Int("version", m.version), slog.String("name", m.name))
+		}
+		return nil
+	})
+}
+
+// createTables creates the tables of models, or the columns and indexes an existing table lacks.
+// Unlike AutoMigrate it never alters an existing column: type changes need their own migration,
+// which can say how to convert the data.
+func createTables(tx *gorm.DB, models ...any) error {
+	migrator := tx.Migrator()
+	for _, model := range models {
+		if !migrator.HasTable(model) {
+			if err := migrator.CreateTable(model); err != nil {
+				return err
+			}
+			continue
+		}
+
+		stmt := &gorm.Statement{DB: tx}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			if len(field.DBName) == 0 || migrator.HasColumn(model, field.DBName) {
+				continue
+			}
+			if err := migrator.AddColumn(model, field.DBName); err != nil {
+				return err
+			}
+		}
+		for name := range stmt.Schema.ParseIndexes() {
+			if migrator.HasIndex(model, name) {
+				continue
+			}
+			if err := migrator.CreateIndex(model, name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addOrderBranches adds branch_id to an order_dbs table created before branches existed, whose
+// rows all belong to branchID. It is added as nullable and filled before it becomes NOT NULL,
+// which existing rows would otherwise fail.
+func addOrderBranches(tx *gorm.DB, branchID string) error {
+	migrator := tx.Migrator()
+	if !migrator.HasTable(&orderDB{}) || migrator.HasColumn(&orderDB{}, "branch_id") {
+		return nil
+	}
+
+	if err := tx.Exec("ALTER TABLE order_dbs ADD COLUMN branch_id varchar(255)").Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("UPDATE order_dbs SET branch_id = ?", branchID).Error; err != nil {
+		return err
+	}
+	return tx.Exec("ALTER TABLE order_dbs ALTER COLUMN branch_id SET NOT NULL").Error
+}
+
+// timeToTimestamptz converts column of table from time, which only kept the time of day, to
+// timestamptz. The date can't be derived from the old value, so each row takes recovered, an SQL
+// expression over the row aliased t, and falls back to the time of day on the migration date.
+// Columns that already are timestamptz, as in databases created after the change, are left alone.
+// Indexes on the column are dropped with it; the caller recreates them.
+func timeToTimestamptz(tx *gorm.DB, table, column, recovered string) error {
+	var dataType string
+	err := tx.Raw(`SELECT data_type FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?`, table, column).
+		Scan(&dataType).
+		Error
+	if err != nil {
+		return err
+	}
+	if dataType != "time without time zone" {
+		return nil
+	}
+
+	for _, statement := range []string{
+		`ALTER TABLE %[1]s ADD COLUMN %[2]s_tz timestamptz`,
+		`UPDATE %[1]s t SET %[2]s_tz = COALESCE(%[3]s, CURRENT_DATE + t.%[2]s)`,
+		`ALTER TABLE %[1]s DROP COLUMN %[2]s`,
+		`ALTER TABLE %[1]s RENAME COLUMN %[2]s_tz TO %[2]s`,
+		`ALTER TABLE %[1]s ALTER COLUMN %[2]s SET NOT NULL`,
+	} {
+		if err = tx.Exec(fmt.Sprintf(statement, table, column, recovered)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// latestMigration is the version a fully migrated database records.
+func latestMigration() int {
+	return migrations[len(migrations)-1].version
+}
@@ -0,0 +1,23 @@
+package sql
+
+import (
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+}
+
+func TestMigrations(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
+
+func (s *MigrationsTestSuite) TestVersionsAreSequential() {
+	for i, m := range migrations {
+		s.Equal(i+1, m.version, m.name)
+		s.NotEmpty(m.name)
+		s.NotNil(m.up, m.name)
+	}
+	s.Equal(len(migrations), latestMigration())
+}
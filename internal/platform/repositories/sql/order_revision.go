@@ -0,0 +1,164 @@
+package sql
+
+import (
+	domain "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// orderRevisionDB records an edit of the content of an order. Like the status history it is
+// insert-only and written together with the change. Items are joined with commas, as in order_dbs.
+type orderRevisionDB struct {
+	ID         string    `gorm:"type:string; size:255; primary_key;"`
+	OrderID    string    `gorm:"type:string; size:255; not null; index"`
+	BranchID   string    `gorm:"type:string; size:255; not null; index"`
+	Status     string    `gorm:"type:string; size:255; not null"`
+	Added      string    `gorm:"type:text; not null; default:''"`
+	Removed    string    `gorm:"type:text; not null; default:''"`
+	Source     *string   `gorm:"type:string; size:255"`
+	Type       *string   `gorm:"type:string; size:255"`
+	Notes      *string   `gorm:"type:text"`
+	KitchenAck string    `gorm:"type:string; size:255; not null; default:''"`
+	EditedAt   time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (orderRevisionDB) TableName() string {
+	return "order_revisions"
+}
+
+func toOrderRevisionDB(branchID string, revision domain.Revision) orderRevisionDB {
+	rDB := orderRevisionDB{
+		ID:         uuid.New().String(),
+		OrderID:    revision.OrderID,
+		BranchID:   branchID,
+		Status:     string(revision.Status),
+		Added:      strings.Join(revision.Added, ","),
+		Removed:    strings.Join(revision.Removed, ","),
+		Notes:      revision.Notes,
+		KitchenAck: revision.KitchenAck,
+		EditedAt:   revision.EditedAt.Truncate(time.Millisecond),
+	}
+	if revision.Source != nil {
+		source := string(*revision.Source)
+		rDB.Source = &source
+	}
+	if revision.Type != nil {
+		orderType := string(*revision.Type)
+		rDB.Type = &orderType
+	}
+
+	return rDB
+}
+
+func (r *orderRevisionDB) toRevisionModel() domain.Revision {
+	revision := domain.Revision{
+		ID:         r.ID,
+		OrderID:    r.OrderID,
+		Status:     domain.Status(r.Status),
+		Notes:      r.Notes,
+		KitchenAck: r.KitchenAck,
+		EditedAt:   r.EditedAt,
+	}
+	if len(r.Added) > 0 {
+		revision.Added = strings.Split(r.Added, ",")
+	}
+	if len(r.Removed) > 0 {
+		revision.Removed = strings.Split(r.Removed, ",")
+	}
+	if r.Source != nil {
+		source := domain.Source(*r.Source)
+		revision.Source = &source
+	}
+	if r.Type != nil {
+		orderType := domain.OrderType(*r.Type)
+		revision.Type = &orderType
+	}
+
+	return revision
+}
+
+// EditOrder saves the content of an edited order and the revision recording the edit. order must
+// carry the version it was edited from: the row is only written if it is still at that version,
+// with a single UPDATE ... RETURNING. Neither the status nor updated_at change.
+func (r *OrderRepository) EditOrder(ctx context.Context, branch tenant.Branch, order domain.Order, revision domain.Revision) (_ *domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.EditOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", order.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "EditOrder")
+	defer cancel()
+
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "error editing order")
+	}
+	defer r.unlock()
+
+	var edited orderDB
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var rows []orderDB
+		err := tx.
+			Raw(`UPDATE order_dbs SET menu = ?, source = ?, type = ?, notes = ?, version = version + 1
+				WHERE branch_id = ? AND id = ? AND version = ?
+				RETURNING *`,
+				strings.Join(order.Menu, ","), order.Source, order.Type, order.Notes, branch.ID, order.ID, order.Version).
+			Scan(&rows).
+			Error
+		if err != nil {
+			return err
+		}
+		// the order was read before the edit, so a missing row means it changed since
+		if len(rows) == 0 {
+			return domain.ErrOrderModified
+		}
+		edited = rows[0]
+
+		rDB := toOrderRevisionDB(branch.ID, revision)
+		return tx.Create(&rDB).Error
+	})
+	if err != nil {
+		if _, known := errs.As(err); known {
+			return nil, err
+		}
+		r.logger.ErrorContext(ctx, "error editing order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error editing order")
+	}
+
+	return edited.toOrderModel(), nil
+}
+
+// ListRevisions returns the edits of an order, oldest first.
+func (r *OrderRepository) ListRevisions(ctx context.Context, branch tenant.Branch, orderID string) (_ []domain.Revision, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ListRevisions", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListRevisions")
+	defer cancel()
+
+	var revisionsDB []orderRevisionDB
+	err = r.db.WithContext(ctx).
+		Where("branch_id = ? AND order_id = ?", branch.ID, orderID).
+		Order("edited_at ASC").
+		Find(&revisionsDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error listing order revisions", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error listing order revisions")
+	}
+
+	revisions := make([]domain.Revision, len(revisionsDB))
+	for i := range revisionsDB {
+		revisions[i] = revisionsDB[i].toRevisionModel()
+	}
+
+	return revisions, nil
+}
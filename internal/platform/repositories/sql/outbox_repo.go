@@ -0,0 +1,184 @@
+package sql
+
+import (
+	"challenge-yuno/internal/business/domain/notification"
+	domain "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+)
+
+type OutboxRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewOutboxRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *OutboxRepository {
+	return &OutboxRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logging.Named(logger, "sql"),
+	}
+}
+
+// outboxMessageDB is pending while NextAttemptAt is set and SentAt is not. Messages that ran out
+// of attempts keep both empty and stay in the table for inspection.
+type outboxMessageDB struct {
+	ID            string     `gorm:"type:string; size:255; primary_key;"`
+	BranchID      string     `gorm:"type:string; size:255; not null; index"`
+	OrderID       string     `gorm:"type:string; size:255; not null; index"`
+	Payload       string     `gorm:"type:jsonb; not null;"`
+	Attempts      int        `gorm:"type:integer; not null; default:0"`
+	CreatedAt     time.Time  `gorm:"<-:create; type:timestamptz; not null;"`
+	NextAttemptAt *time.Time `gorm:"type:timestamptz; index"`
+	SentAt        *time.Time `gorm:"type:timestamptz;"`
+	LastError     string     `gorm:"type:text;"`
+}
+
+func (outboxMessageDB) TableName() string {
+	return "notification_outbox"
+}
+
+func (m *outboxMessageDB) toMessageModel() (notification.Message, error) {
+	var order domain.Order
+	if err := json.Unmarshal([]byte(m.Payload), &order); err != nil {
+		return notification.Message{}, fmt.Errorf("error reading outbox message %s: %w", m.ID, err)
+	}
+
+	return notification.Message{
+		ID:            m.ID,
+		BranchID:      m.BranchID,
+		OrderID:       m.OrderID,
+		Order:         order,
+		Attempts:      m.Attempts,
+		CreatedAt:     m.CreatedAt,
+		NextAttemptAt: m.NextAttemptAt,
+		SentAt:        m.SentAt,
+		LastError:     m.LastError,
+	}, nil
+}
+
+func (r *OutboxRepository) Enqueue(ctx context.Context, branch tenant.Branch, order *domain.Order) error {
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("error encoding outbox message: %w", err)
+	}
+
+	now := time.Now().Truncate(time.Millisecond)
+	mDB := outboxMessageDB{
+		ID:            uuid.New().String(),
+		BranchID:      branch.ID,
+		OrderID:       order.ID,
+		Payload:       string(payload),
+		CreatedAt:     now,
+		NextAttemptAt: &now,
+	}
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Enqueue")
+	defer cancel()
+
+	if err = r.db.WithContext(ctx).Create(&mDB).Error; err != nil {
+		r.logger.ErrorContext(ctx, "error saving outbox message", slog.Any("error", err))
+		return dbError(ctx, err, "notification wasn't queued")
+	}
+
+	return nil
+}
+
+// Claim returns up to limit messages due for delivery and hides them from other dispatchers for
+// the lease duration. Rows locked by a concurrent claim are skipped, so several instances can
+// dispatch from the same table.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]notification.Message, error) {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Claim")
+	defer cancel()
+
+	now := time.Now()
+	var messagesDB []outboxMessageDB
+
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE notification_outbox SET next_attempt_at = ?
+		WHERE id IN (
+			SELECT id FROM notification_outbox
+			WHERE sent_at IS NULL AND next_attempt_at <= ?
+			ORDER BY next_attempt_at
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, now.Add(lease), now, limit).
+		Scan(&messagesDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error claiming outbox messages", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error claiming notifications")
+	}
+
+	result := make([]notification.Message, 0, len(messagesDB))
+	for _, mDB := range messagesDB {
+		message, err := mDB.toMessageModel()
+		if err != nil {
+			r.logger.ErrorContext(ctx, "error reading outbox message", slog.Any("error", err))
+			continue
+		}
+		result = append(result, message)
+	}
+
+	return result, nil
+}
+
+func (r *OutboxRepository) MarkSent(ctx context.Context, messageID string) error {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "MarkSent")
+	defer cancel()
+
+	now := time.Now().Truncate(time.Millisecond)
+	err := r.db.WithContext(ctx).Model(&outboxMessageDB{}).
+		Where("id = ?", messageID).
+		Updates(map[string]any{"sent_at": now, "next_attempt_at": nil, "attempts": gorm.Expr("attempts + 1")}).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error marking outbox message as sent", slog.Any("error", err))
+		return dbError(ctx, err, "error updating notification")
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed delivery. A nil nextAttemptAt gives up on the message.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, messageID string, attempts int, nextAttemptAt *time.Time, cause error) error {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "MarkFailed")
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Model(&outboxMessageDB{}).
+		Where("id = ?", messageID).
+		Updates(map[string]any{"attempts": attempts, "next_attempt_at": nextAttemptAt, "last_error": cause.Error()}).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error marking outbox message as failed", slog.Any("error", err))
+		return dbError(ctx, err, "error updating notification")
+	}
+
+	return nil
+}
+
+// Backlog returns how many messages are still waiting to be delivered.
+func (r *OutboxRepository) Backlog(ctx context.Context) (int, error) {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Backlog")
+	defer cancel()
+
+	var count int64
+	err := r.db.WithContext(ctx).Model(&outboxMessageDB{}).
+		Where("sent_at IS NULL AND next_attempt_at IS NOT NULL").
+		Count(&count).
+		Error
+	if err != nil {
+		return 0, dbError(ctx, err, "error counting notifications")
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/ratelimit"
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+	"time"
+)
+
+// takeToken refills the bucket for the time elapsed since it was last used and takes a token if
+// one is available, all in a single statement so concurrent replicas never oversubscribe it.
+const takeToken = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+VALUES (@key, @burst - 1, true, now())
+ON CONFLICT (key) DO UPDATE SET
+	allowed = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1,
+	tokens = LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate)
+		- CASE WHEN LEAST(@burst, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1 THEN 1 ELSE 0 END,
+	updated_at = now()
+RETURNING tokens, allowed`
+
+// RateLimitRepository is a ratelimit.Limiter whose buckets live in Postgres, so every replica
+// shares the same limits.
+type RateLimitRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewRateLimitRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *RateLimitRepository {
+	return &RateLimitRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logging.Named(logger, "sql"),
+	}
+}
+
+type rateLimitBucketDB struct {
+	Key       string    `gorm:"type:string; size:255; primary_key;"`
+	Tokens    float64   `gorm:"type:double precision; not null;"`
+	Allowed   bool      `gorm:"not null;"`
+	UpdatedAt time.Time `gorm:"type:timestamptz; not null;"`
+}
+
+func (rateLimitBucketDB) TableName() string {
+	return "rate_limit_buckets"
+}
+
+func (r *RateLimitRepository) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Allow")
+	defer cancel()
+
+	var result struct {
+		Tokens  float64
+		Allowed bool
+	}
+	err := r.db.WithContext(ctx).
+		Raw(takeToken, map[string]any{"key": key, "burst": limit.Burst, "rate": limit.Rate()}).
+		Scan(&result).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error taking rate limit token", slog.Any("error", err))
+		return ratelimit.Decision{}, dbError(ctx, err, "error checking rate limit")
+	}
+
+	if !result.Allowed {
+		wait := time.Duration((1 - result.Tokens) / limit.Rate() * float64(time.Second))
+		return ratelimit.Decision{RetryAfter: wait}, nil
+	}
+
+	return ratelimit.Decision{Allowed: true, Remaining: int(result.Tokens)}, nil
+}
+
+// Prune removes the buckets not used since before the given time. A missing bucket starts full,
+// so only buckets idle for longer than their refill period should be pruned.
+func (r *RateLimitRepository) Prune(ctx context.Context, before time.Time) error {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Prune")
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Where("updated_at < ?", before).Delete(&rateLimitBucketDB{}).Error; err != nil {
+		r.logger.ErrorContext(ctx, "error pruning rate limit buckets", slog.Any("error", err))
+		return dbError(ctx, err, "error pruning rate limit buckets")
+	}
+
+	return nil
+}
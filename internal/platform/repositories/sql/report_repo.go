@@ -0,0 +1,308 @@
+package sql
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	model "challenge-yuno/internal/business/domain/report"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+)
+
+// ReportRepository aggregates the orders and their status history in the database, so reports
+// never load the orders themselves.
+type ReportRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewReportRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *ReportRepository {
+	return &ReportRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logging.Named(logger, "sql"),
+	}
+}
+
+type dailySummaryDB struct {
+	BranchID         string    `gorm:"type:string; size:255; primaryKey"`
+	Day              string    `gorm:"type:string; size:10; primaryKey"`
+	From             time.Time `gorm:"column:starts_at; type:timestamptz; not null"`
+	To               time.Time `gorm:"column:ends_at; type:timestamptz; not null"`
+	Orders           int64     `gorm:"type:bigint; not null"`
+	Canceled         int64     `gorm:"type:bigint; not null"`
+	CancellationRate float64   `gorm:"type:double precision; not null"`
+	Revenue          int64     `gorm:"type:bigint; not null"`
+	WaitOrders       int64     `gorm:"type:bigint; not null"`
+	WaitAverage      float64   `gorm:"type:double precision; not null"`
+	WaitP90          float64   `gorm:"column:wait_p90; type:double precision; not null"`
+	PrepOrders       int64     `gorm:"type:bigint; not null"`
+	PrepAverage      float64   `gorm:"type:double precision; not null"`
+	PrepP90          float64   `gorm:"column:prep_p90; type:double precision; not null"`
+	ClosedAt         time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (dailySummaryDB) TableName() string {
+	return "daily_summaries"
+}
+
+func toDailySummaryDB(summary model.DailySummary) dailySummaryDB {
+	return dailySummaryDB{
+		BranchID:         summary.BranchID,
+		Day:              summary.Day,
+		From:             summary.From,
+		To:               summary.To,
+		Orders:           summary.Orders,
+		Canceled:         summary.Canceled,
+		CancellationRate: summary.CancellationRate,
+		Revenue:          summary.Revenue,
+		WaitOrders:       summary.Wait.Orders,
+		WaitAverage:      summary.Wait.AverageSeconds,
+		WaitP90:          summary.Wait.P90Seconds,
+		PrepOrders:       summary.Prep.Orders,
+		PrepAverage:      summary.Prep.AverageSeconds,
+		PrepP90:          summary.Prep.P90Seconds,
+		ClosedAt:         summary.ClosedAt,
+	}
+}
+
+func (d *dailySummaryDB) toDailySummaryModel() model.DailySummary {
+	return model.DailySummary{
+		BranchID: d.BranchID,
+		Day:      d.Day,
+		Summary: model.Summary{
+			Range:            model.Range{From: d.From, To: d.To},
+			Orders:           d.Orders,
+			Canceled:         d.Canceled,
+			CancellationRate: d.CancellationRate,
+			Revenue:          d.Revenue,
+			Wait:             model.Durations{Orders: d.WaitOrders, AverageSeconds: d.WaitAverage, P90Seconds: d.WaitP90},
+			Prep:             model.Durations{Orders: d.PrepOrders, AverageSeconds: d.PrepAverage, P90Seconds: d.PrepP90},
+		},
+		ClosedAt: d.ClosedAt,
+	}
+}
+
+// placedOrders selects the orders of the branch placed in [from, to), archived or not. An order
+// is placed when it enters its first status, which for imported orders is the status they were
+// imported with. History after to can't change when an order was placed, so it is left out to
+// use the index.
+const placedOrders = `WITH placed AS (
+		SELECT o.id, o.status, o.source, o.type, o.menu, MIN(h.changed_at) AS placed_at
+		FROM (
+			SELECT id, branch_id, status, source, type, menu FROM order_dbs
+			UNION ALL
+			SELECT id, branch_id, status, source, type, menu FROM order_archive
+		) o
+		JOIN order_status_history h ON h.order_id = o.id AND h.branch_id = o.branch_id
+		WHERE o.branch_id = @branch AND h.changed_at < @to
+		GROUP BY o.id, o.status, o.source, o.type, o.menu
+		HAVING MIN(h.changed_at) >= @from
+	)
+`
+
+// dimensionColumns maps every dimension to the expression orders are grouped by. Hours are
+// truncated in UTC so buckets don't depend on the session time zone.
+var dimensionColumns = map[model.Dimension]string{
+	model.Hour:   `to_char(date_trunc('hour', placed_at AT TIME ZONE 'UTC'), 'YYYY-MM-DD"T"HH24:00:00"Z"')`,
+	model.Source: "source",
+	model.Type:   "type",
+}
+
+func rangeArgs(branch tenant.Branch, rng model.Range) map[string]any {
+	return map[string]any{
+		"branch":   branch.ID,
+		"from":     rng.From,
+		"to":       rng.To,
+		"canceled": order.Canceled,
+	}
+}
+
+// CountOrders returns how many orders were placed, and how many of them were canceled, for every
+// value of the dimension, in ascending order of the value.
+func (r *ReportRepository) CountOrders(ctx context.Context, branch tenant.Branch, rng model.Range, dimension model.Dimension) (_ []model.Count, err error) {
+	ctx, span := tracer.Start(ctx, "ReportRepository.CountOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("report.dimension", string(dimension))))
+	defer func() { tracing.End(span, err) }()
+
+	column, ok := dimensionColumns[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown report dimension %q", dimension)
+	}
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "CountOrders")
+	defer cancel()
+
+	var counts []model.Count
+	err = r.db.WithContext(ctx).
+		Raw(placedOrders+`SELECT `+column+` AS key,
+				COUNT(*) AS orders,
+				COUNT(*) FILTER (WHERE status = @canceled) AS canceled
+			FROM placed
+			GROUP BY key
+			ORDER BY key ASC`,
+			rangeArgs(branch, rng)).
+		Scan(&counts).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error counting orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error counting orders")
+	}
+
+	return counts, nil
+}
+
+// Totals counts the orders placed and canceled, and measures how long they waited to be picked up
+// by the kitchen and how long they took to prepare. Stages are measured from the first time the
+// order entered each status.
+func (r *ReportRepository) Totals(ctx context.Context, branch tenant.Branch, rng model.Range) (_ *model.Totals, err error) {
+	ctx, span := tracer.Start(ctx, "ReportRepository.Totals", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "Totals")
+	defer cancel()
+
+	var row struct {
+		Orders      int64
+		Canceled    int64
+		WaitOrders  int64
+		WaitAverage float64
+		WaitP90     float64 `gorm:"column:wait_p90"`
+		PrepOrders  int64
+		PrepAverage float64
+		PrepP90     float64 `gorm:"column:prep_p90"`
+	}
+	err = r.db.WithContext(ctx).
+		Raw(placedOrders+`, entered AS (
+				SELECT h.order_id,
+					MIN(h.changed_at) FILTER (WHERE h.status = @pending) AS pending_at,
+					MIN(h.changed_at) FILTER (WHERE h.status = @preparing) AS preparing_at,
+					MIN(h.changed_at) FILTER (WHERE h.status = @finished) AS finished_at
+				FROM order_status_history h
+				JOIN placed p ON p.id = h.order_id
+				WHERE h.branch_id = @branch
+				GROUP BY h.order_id
+			), stages AS (
+				SELECT EXTRACT(EPOCH FROM preparing_at - pending_at) AS wait,
+					EXTRACT(EPOCH FROM finished_at - preparing_at) AS prep
+				FROM entered
+			)
+			SELECT (SELECT COUNT(*) FROM placed) AS orders,
+				(SELECT COUNT(*) FROM placed WHERE status = @canceled) AS canceled,
+				COUNT(wait) AS wait_orders,
+				COALESCE(AVG(wait), 0) AS wait_average,
+				COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY wait), 0) AS wait_p90,
+				COUNT(prep) AS prep_orders,
+				COALESCE(AVG(prep), 0) AS prep_average,
+				COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY prep), 0) AS prep_p90
+			FROM stages`,
+			statusArgs(rangeArgs(branch, rng))).
+		Scan(&row).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting report totals", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting report totals")
+	}
+
+	return &model.Totals{
+		Orders:   row.Orders,
+		Canceled: row.Canceled,
+		Wait:     model.Durations{Orders: row.WaitOrders, AverageSeconds: row.WaitAverage, P90Seconds: row.WaitP90},
+		Prep:     model.Durations{Orders: row.PrepOrders, AverageSeconds: row.PrepAverage, P90Seconds: row.PrepP90},
+	}, nil
+}
+
+func statusArgs(args map[string]any) map[string]any {
+	args["pending"] = order.Pending
+	args["preparing"] = order.InPreparation
+	args["finished"] = order.Finished
+	return args
+}
+
+// ItemSales counts the portions of every menu item in the orders placed that weren't canceled,
+// best sellers first. Revenue is left for the caller, who knows the prices.
+func (r *ReportRepository) ItemSales(ctx context.Context, branch tenant.Branch, rng model.Range) (_ []model.ItemSales, err error) {
+	ctx, span := tracer.Start(ctx, "ReportRepository.ItemSales", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ItemSales")
+	defer cancel()
+
+	var items []model.ItemSales
+	err = r.db.WithContext(ctx).
+		Raw(placedOrders+`SELECT item, COUNT(*) AS quantity
+			FROM placed, unnest(string_to_array(placed.menu, ',')) AS item
+			WHERE placed.status <> @canceled AND item <> ''
+			GROUP BY item
+			ORDER BY quantity DESC, item ASC`,
+			rangeArgs(branch, rng)).
+		Scan(&items).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error counting item sales", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error counting item sales")
+	}
+
+	return items, nil
+}
+
+// SaveDailySummary stores the snapshot of the day, replacing the previous one when the day is
+// closed again.
+func (r *ReportRepository) SaveDailySummary(ctx context.Context, summary model.DailySummary) (err error) {
+	ctx, span := tracer.Start(ctx, "ReportRepository.SaveDailySummary", trace.WithAttributes(
+		attribute.String("branch.id", summary.BranchID), attribute.String("report.day", summary.Day)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "SaveDailySummary")
+	defer cancel()
+
+	sDB := toDailySummaryDB(summary)
+	err = r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "branch_id"}, {Name: "day"}},
+			UpdateAll: true,
+		}).
+		Create(&sDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error saving daily summary", slog.Any("error", err))
+		return dbError(ctx, err, "daily summary wasn't saved")
+	}
+
+	return nil
+}
+
+// ListDailySummaries returns the summaries of the days starting in the range, oldest first.
+func (r *ReportRepository) ListDailySummaries(ctx context.Context, branch tenant.Branch, rng model.Range) (_ []model.DailySummary, err error) {
+	ctx, span := tracer.Start(ctx, "ReportRepository.ListDailySummaries", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListDailySummaries")
+	defer cancel()
+
+	var summariesDB []dailySummaryDB
+	err = r.db.WithContext(ctx).
+		Where("branch_id = ? AND starts_at >= ? AND starts_at < ?", branch.ID, rng.From, rng.To).
+		Order("starts_at ASC").
+		Find(&summariesDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting daily summaries", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting daily summaries")
+	}
+
+	result := make([]model.DailySummary, 0, len(summariesDB))
+	for _, sDB := range summariesDB {
+		result = append(result, sDB.toDailySummaryModel())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,311 @@
+package sql
+
+import (
+	diningmodel "challenge-yuno/internal/business/domain/dining"
+	inventorymodel "challenge-yuno/internal/business/domain/inventory"
+	domain "challenge-yuno/internal/business/domain/order"
+	reservationmodel "challenge-yuno/internal/business/domain/reservation"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RepositoryTestSuite runs the repositories against the Postgres database in TEST_DATABASE_URL,
+// for instance the one of docker-compose:
+//
+//	TEST_DATABASE_URL="host=localhost user=user password=password dbname=postgres sslmode=disable" go test ./...
+//
+// The tables are emptied before every test, so it must not point to a database in use.
+type RepositoryTestSuite struct {
+	suite.Suite
+	dsn    string
+	db     *gorm.DB
+	logger *slog.Logger
+	orders *OrderRepository
+	branch tenant.Branch
+}
+
+func TestRepositories(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if len(dsn) == 0 {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	suite.Run(t, &RepositoryTestSuite{dsn: dsn})
+}
+
+func (s *RepositoryTestSuite) SetupSuite() {
+	db, err := gorm.Open(postgres.Open(s.dsn), &gorm.Config{Logger: logger.Discard})
+	s.Require().NoError(err)
+	s.db = db
+	s.logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	s.Require().NoError(Migrate(context.Background(), db, "centro", s.logger))
+}
+
+func (s *RepositoryTestSuite) SetupTest() {
+	s.Require().NoError(s.db.Exec(`TRUNCATE order_dbs, order_status_history, order_archive, order_revisions,
+		notification_outbox, ingredients, recipe_lines, dining_tables, dining_sessions, session_orders,
+		reservations, reservation_orders`).Error)
+	s.orders = NewOrderRepository(s.db, Timeouts{}, s.logger)
+	s.branch = tenant.Branch{ID: "centro"}
+}
+
+// addOrder creates an order of the test branch, failing the test if it can't.
+func (s *RepositoryTestSuite) addOrder(order domain.Order) *domain.Order {
+	created, err := s.orders.AddOrder(context.Background(), s.branch, order)
+	s.Require().NoError(err)
+	return created
+}
+
+func (s *RepositoryTestSuite) TestPromotedOrdersJoinTheBackOfTheQueue() {
+	ctx := context.Background()
+	due := time.Now().Add(10 * time.Minute)
+	scheduled := s.addOrder(domain.Order{Menu: []string{"pizza"}, Status: domain.Scheduled, Source: domain.Phone, Type: domain.Normal, ScheduledFor: &due})
+	first := s.addOrder(domain.Order{Menu: []string{"salad"}, Status: domain.Pending, Source: domain.InPerson, Type: domain.Normal})
+	second := s.addOrder(domain.Order{Menu: []string{"soda"}, Status: domain.Pending, Source: domain.InPerson, Type: domain.Normal})
+	s.Less(scheduled.Priority, first.Priority)
+
+	promoted, err := s.orders.PromoteScheduledOrders(ctx, domain.Normal, due.Add(time.Minute))
+	s.Require().NoError(err)
+	s.Require().Len(promoted, 1)
+	s.Equal(scheduled.ID, promoted[0].ID)
+	s.Equal(domain.Pending, promoted[0].Status)
+	s.Greater(promoted[0].Priority, second.Priority)
+
+	queue, err := s.orders.ListActiveOrders(ctx, s.branch)
+	s.Require().NoError(err)
+	var ids []string
+	for _, o := range queue {
+		ids = append(ids, o.ID)
+	}
+	s.Equal([]string{first.ID, second.ID, scheduled.ID}, ids)
+}
+
+func (s *RepositoryTestSuite) TestCloseSessionWithOpenOrders() {
+	ctx := context.Background()
+	dining := NewDiningRepository(s.db, Timeouts{}, s.logger)
+	_, err := dining.AddTable(ctx, s.branch, diningmodel.Table{ID: "t1", Seats: 4})
+	s.Require().NoError(err)
+	session, err := dining.OpenSession(ctx, s.branch, "t1")
+	s.Require().NoError(err)
+	created := s.addOrder(domain.Order{Menu: []string{"pizza"}, Status: domain.Pending, Source: domain.InPerson, Type: domain.Normal})
+	s.Require().NoError(dining.AttachOrder(ctx, s.branch, session.ID, created.ID))
+
+	_, err = dining.CloseSession(ctx, s.branch, session.ID)
+	s.Equal(errs.Conflict("session_has_open_orders",
+		fmt.Sprintf("orders %s must be DELIVERED or CANCELED before closing the session", created.ID)), err)
+
+	_, err = s.orders.UpdateOrder(ctx, s.branch, domain.StatusUpdate{OrderID: created.ID, Status: domain.Canceled})
+	s.Require().NoError(err)
+	closed, err := dining.CloseSession(ctx, s.branch, session.ID)
+	s.Require().NoError(err)
+	s.NotNil(closed.ClosedAt)
+
+	_, err = dining.CloseSession(ctx, s.branch, session.ID)
+	s.Equal(errs.NotFound("no_open_session", "the table has no open session"), err)
+}
+
+func (s *RepositoryTestSuite) TestAttachOrderToClosedSession() {
+	ctx := context.Background()
+	dining := NewDiningRepository(s.db, Timeouts{}, s.logger)
+	_, err := dining.AddTable(ctx, s.branch, diningmodel.Table{ID: "t1", Seats: 4})
+	s.Require().NoError(err)
+	session, err := dining.OpenSession(ctx, s.branch, "t1")
+	s.Require().NoError(err)
+	_, err = dining.CloseSession(ctx, s.branch, session.ID)
+	s.Require().NoError(err)
+
+	created := s.addOrder(domain.Order{Menu: []string{"pizza"}, Status: domain.Pending, Source: domain.InPerson, Type: domain.Normal})
+	err = dining.AttachOrder(ctx, s.branch, session.ID, created.ID)
+	s.Equal(errs.NotFound("no_open_session", "the table has no open session"), err)
+
+	orders, err := dining.ListSessionOrders(ctx, s.branch, session.ID)
+	s.Require().NoError(err)
+	s.Empty(orders)
+}
+
+func (s *RepositoryTestSuite) TestPreOrderOfCanceledReservation() {
+	ctx := context.Background()
+	unitOfWork, _, _ := s.unitOfWork()
+	reservations := NewReservationRepository(s.db, Timeouts{}, s.logger)
+	_, err := NewDiningRepository(s.db, Timeouts{}, s.logger).AddTable(ctx, s.branch, diningmodel.Table{ID: "t1", Seats: 4})
+	s.Require().NoError(err)
+	startsAt := time.Now().Add(time.Hour)
+	booked, err := reservations.AddReservation(ctx, s.branch, reservationmodel.Reservation{
+		TableID: "t1", Name: "Ana", PartySize: 2, StartsAt: startsAt, EndsAt: startsAt.Add(time.Hour)})
+	s.Require().NoError(err)
+	_, err = reservations.UpdateReservationStatus(ctx, s.branch, booked.ID, reservationmodel.Booked, reservationmodel.Canceled)
+	s.Require().NoError(err)
+
+	err = unitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		created, err := repos.Orders.AddOrder(ctx, s.branch, domain.Order{Menu: []string{"pizza"}, Status: domain.Scheduled,
+			Source: domain.InPerson, Type: domain.Normal, ScheduledFor: &startsAt})
+		if err != nil {
+			return err
+		}
+		return repos.Reservations.AttachOrder(ctx, s.branch, booked.ID, created.ID)
+	})
+	s.Equal(errs.Conflict("reservation_not_booked", "pre-orders can only be placed for BOOKED reservations"), err)
+
+	scheduled, err := s.orders.ListScheduledOrders(ctx, s.branch)
+	if err != nil {
+		s.ErrorIs(err, errs.ErrNotFound)
+	}
+	s.Empty(scheduled)
+}
+
+func (s *RepositoryTestSuite) TestUpdateOrderNotFoundAndModified() {
+	ctx := context.Background()
+	created := s.addOrder(domain.Order{Menu: []string{"pizza"}, Status: domain.Pending, Source: domain.Phone, Type: domain.Normal})
+	stale := created.Version - 1
+	current := created.Version
+
+	_, err := s.orders.UpdateOrder(ctx, s.branch, domain.StatusUpdate{OrderID: "missing", Status: domain.InPreparation, Version: &current})
+	s.Equal(errs.NotFound("order_not_found", "order not found"), err)
+
+	_, err = s.orders.UpdateOrder(ctx, s.branch, domain.StatusUpdate{OrderID: created.ID, Status: domain.InPreparation, Version: &stale})
+	s.Equal(domain.ErrOrderModified, err)
+
+	updated, err := s.orders.UpdateOrder(ctx, s.branch, domain.StatusUpdate{OrderID: created.ID, Status: domain.InPreparation, Version: &current})
+	s.Require().NoError(err)
+	s.Equal(domain.InPreparation, updated.Status)
+	s.Equal(current+1, updated.Version)
+
+	// the order is on another branch, so it doesn't exist for this one
+	_, err = s.orders.UpdateOrder(ctx, tenant.Branch{ID: "norte"}, domain.StatusUpdate{OrderID: created.ID, Status: domain.Finished})
+	s.Equal(errs.NotFound("order_not_found", "order not found"), err)
+}
+
+// unitOfWork returns a UnitOfWork over the test database and the inventory it writes to, with
+// 10 units of dough in stock.
+func (s *RepositoryTestSuite) unitOfWork() (*UnitOfWork, *InventoryRepository, *OutboxRepository) {
+	inventory := NewInventoryRepository(s.db, Timeouts{}, s.logger)
+	outbox := NewOutboxRepository(s.db, Timeouts{}, s.logger)
+	_, err := inventory.SetIngredient(context.Background(), s.branch, inventorymodel.Ingredient{Name: "dough", Unit: "g", Stock: 10})
+	s.Require().NoError(err)
+
+	unitOfWork := NewUnitOfWork(s.db, s.orders, inventory, outbox,
+		NewDiningRepository(s.db, Timeouts{}, s.logger), NewReservationRepository(s.db, Timeouts{}, s.logger), s.logger)
+	return unitOfWork, inventory, outbox
+}
+
+// finish takes the dough of the order, finishes it and queues its notification through repos.
+func (s *RepositoryTestSuite) finish(ctx context.Context, repos interfaces.Repositories, orderID string) error {
+	if err := repos.Inventory.Consume(ctx, s.branch, map[string]int64{"dough": 3}); err != nil {
+		return err
+	}
+	finished, err := repos.Orders.UpdateOrder(ctx, s.branch, domain.StatusUpdate{OrderID: orderID, Status: domain.Finished})
+	if err != nil {
+		return err
+	}
+	return repos.Outbox.Enqueue(ctx, s.branch, finished)
+}
+
+func (s *RepositoryTestSuite) TestUnitOfWorkCommits() {
+	ctx := context.Background()
+	unitOfWork, inventory, outbox := s.unitOfWork()
+	created := s.addOrder(domain.Order{Menu: []string{"pizza"}, Status: domain.InPreparation, Source: domain.Phone, Type: domain.Normal})
+
+	err := unitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		return s.finish(ctx, repos, created.ID)
+	})
+	s.Require().NoError(err)
+
+	stored, err := s.orders.GetOrder(ctx, s.branch, created.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.Finished, stored.Status)
+	stock, err := inventory.Stock(ctx, s.branch, "dough")
+	s.Require().NoError(err)
+	s.Equal(int64(7), stock["dough"])
+	backlog, err := outbox.Backlog(ctx)
+	s.Require().NoError(err)
+	s.Equal(1, backlog)
+}
+
+func (s *RepositoryTestSuite) TestUnitOfWorkRollsBack() {
+	ctx := context.Background()
+	unitOfWork, inventory, outbox := s.unitOfWork()
+	created := s.addOrder(domain.Order{Menu: []string{"pizza"}, Status: domain.InPreparation, Source: domain.Phone, Type: domain.Normal})
+
+	err := unitOfWork.Do(ctx, func(repos interfaces.Repositories) error {
+		if err := s.finish(ctx, repos, created.ID); err != nil {
+			return err
+		}
+		return domain.ErrOrderModified
+	})
+	s.Equal(domain.ErrOrderModified, err)
+
+	stored, err := s.orders.GetOrder(ctx, s.branch, created.ID)
+	s.Require().NoError(err)
+	s.Equal(domain.InPreparation, stored.Status)
+	s.Equal(created.Version, stored.Version)
+	stock, err := inventory.Stock(ctx, s.branch, "dough")
+	s.Require().NoError(err)
+	s.Equal(int64(10), stock["dough"])
+	backlog, err := outbox.Backlog(ctx)
+	s.Require().NoError(err)
+	s.Zero(backlog)
+}
+
+// baselineSchema is the order_dbs table as the first release created it, before branches,
+// versions and dated timestamps.
+const baselineSchema = `CREATE TABLE order_dbs (
+	id varchar(255) PRIMARY KEY,
+	created_at time NOT NULL,
+	updated_at time NOT NULL,
+	menu varchar(255) NOT NULL,
+	status varchar(255) NOT NULL,
+	source varchar(255) NOT NULL,
+	type varchar(255) NOT NULL,
+	priority integer NOT NULL DEFAULT 0
+)`
+
+func (s *RepositoryTestSuite) TestMigrateFromBaseline() {
+	ctx := context.Background()
+	s.Require().NoError(s.db.Exec("DROP SCHEMA IF EXISTS baseline_upgrade CASCADE").Error)
+	s.Require().NoError(s.db.Exec("CREATE SCHEMA baseline_upgrade").Error)
+	defer s.db.Exec("DROP SCHEMA baseline_upgrade CASCADE")
+
+	// a database of its own, whose tables are created in the new schema
+	dsn := s.dsn + " search_path=baseline_upgrade"
+	if strings.Contains(s.dsn, "://") {
+		separator := "?"
+		if strings.Contains(s.dsn, "?") {
+			separator = "&"
+		}
+		dsn = s.dsn + separator + "search_path=baseline_upgrade"
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	s.Require().NoError(err)
+	sqlDB, err := db.DB()
+	s.Require().NoError(err)
+	defer sqlDB.Close()
+
+	s.Require().NoError(db.Exec(baselineSchema).Error)
+	s.Require().NoError(db.Exec(`INSERT INTO order_dbs (id, created_at, updated_at, menu, status, source, type, priority)
+		VALUES ('legacy', '12:30:00', '12:45:00', 'pizza', ?, ?, ?, 1)`, domain.Pending, domain.Phone, domain.Normal).Error)
+
+	s.Require().NoError(Migrate(ctx, db, "centro", s.logger))
+
+	stored, err := NewOrderRepository(db, Timeouts{}, s.logger).GetOrder(ctx, s.branch, "legacy")
+	s.Require().NoError(err)
+	s.Equal("centro", stored.BranchID)
+	s.Equal(domain.Pending, stored.Status)
+	s.Equal(1, stored.Version)
+	s.False(stored.CreatedAt.IsZero())
+
+	var version int
+	s.Require().NoError(db.Model(&schemaMigrationDB{}).Select("MAX(version)").Scan(&version).Error)
+	s.Equal(latestMigration(), version)
+}
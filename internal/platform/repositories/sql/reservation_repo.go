@@ -0,0 +1,305 @@
+package sql
+
+import (
+	model "challenge-yuno/internal/business/domain/reservation"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log/slog"
+	"time"
+)
+
+type ReservationRepository struct {
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+}
+
+func NewReservationRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *ReservationRepository {
+	return &ReservationRepository{
+		db:       db,
+		timeouts: timeouts,
+		logger:   logging.Named(logger, "sql"),
+	}
+}
+
+type reservationDB struct {
+	ID        string    `gorm:"type:string; size:255; primaryKey"`
+	BranchID  string    `gorm:"type:string; size:255; not null; index:reservations_branch_slot"`
+	TableID   string    `gorm:"type:string; size:255; not null"`
+	Name      string    `gorm:"type:string; size:255; not null"`
+	PartySize int       `gorm:"type:integer; not null"`
+	StartsAt  time.Time `gorm:"type:timestamptz; not null; index:reservations_branch_slot"`
+	EndsAt    time.Time `gorm:"type:timestamptz; not null"`
+	Status    string    `gorm:"type:string; size:20; not null"`
+	CreatedAt time.Time `gorm:"<-:create; type:timestamptz; not null"`
+	UpdatedAt time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (reservationDB) TableName() string {
+	return "reservations"
+}
+
+// reservationOrderDB links a pre-order to its reservation. OrderID is the key, so an order
+// belongs to one reservation at most.
+type reservationOrderDB struct {
+	OrderID       string    `gorm:"type:string; size:255; primaryKey"`
+	ReservationID string    `gorm:"type:string; size:255; not null; index"`
+	BranchID      string    `gorm:"type:string; size:255; not null"`
+	CreatedAt     time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (reservationOrderDB) TableName() string {
+	return "reservation_orders"
+}
+
+func (r *reservationDB) toReservationModel(orderIDs []string) *model.Reservation {
+	if orderIDs == nil {
+		orderIDs = []string{}
+	}
+
+	return &model.Reservation{
+		ID:        r.ID,
+		BranchID:  r.BranchID,
+		TableID:   r.TableID,
+		Name:      r.Name,
+		PartySize: r.PartySize,
+		StartsAt:  r.StartsAt,
+		EndsAt:    r.EndsAt,
+		Status:    model.Status(r.Status),
+		OrderIDs:  orderIDs,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+// AddReservation books the table for the slot. The table row is locked while the slot is checked
+// against the other reservations of the table, so two concurrent bookings can't both take it.
+func (r *ReservationRepository) AddReservation(ctx context.Context, branch tenant.Branch, reservation model.Reservation) (_ *model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationRepository.AddReservation", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("table.id", reservation.TableID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AddReservation")
+	defer cancel()
+
+	now := time.Now().Truncate(time.Millisecond)
+	rDB := reservationDB{
+		ID:        uuid.New().String(),
+		BranchID:  branch.ID,
+		TableID:   reservation.TableID,
+		Name:      reservation.Name,
+		PartySize: reservation.PartySize,
+		StartsAt:  reservation.StartsAt,
+		EndsAt:    reservation.EndsAt,
+		Status:    string(model.Booked),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var tDB diningTableDB
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&tDB, "branch_id = ? AND id = ?", branch.ID, reservation.TableID).
+			Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errs.NotFound("table_not_found", "table not found")
+			}
+			return err
+		}
+
+		var overlapping int64
+		err = tx.Model(&reservationDB{}).
+			Where("branch_id = ? AND table_id = ? AND status IN ?", branch.ID, reservation.TableID, holdingStatuses()).
+			Where("starts_at < ? AND ends_at > ?", reservation.EndsAt, reservation.StartsAt).
+			Count(&overlapping).
+			Error
+		if err != nil {
+			return err
+		}
+		if overlapping > 0 {
+			return errs.Conflict("table_already_booked", fmt.Sprintf("table %s is already booked for that slot", reservation.TableID))
+		}
+
+		return tx.Create(&rDB).Error
+	})
+	if err != nil {
+		if _, known := errs.As(err); known {
+			return nil, err
+		}
+		r.logger.ErrorContext(ctx, "error saving reservation", slog.Any("error", err))
+		return nil, dbError(ctx, err, "reservation wasn't created")
+	}
+
+	return rDB.toReservationModel(nil), nil
+}
+
+func (r *ReservationRepository) GetReservation(ctx context.Context, branch tenant.Branch, reservationID string) (_ *model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationRepository.GetReservation", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("reservation.id", reservationID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "GetReservation")
+	defer cancel()
+
+	var rDB reservationDB
+	err = r.db.WithContext(ctx).First(&rDB, "branch_id = ? AND id = ?", branch.ID, reservationID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("reservation_not_found", "reservation not found")
+		}
+		r.logger.ErrorContext(ctx, "error getting reservation", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting reservation")
+	}
+
+	orderIDs, err := r.orderIDs(ctx, rDB.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return rDB.toReservationModel(orderIDs[rDB.ID]), nil
+}
+
+// ListReservations returns the reservations whose slot overlaps [from, to), whatever their
+// status, ordered by when they start.
+func (r *ReservationRepository) ListReservations(ctx context.Context, branch tenant.Branch, from, to time.Time) (_ []model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationRepository.ListReservations", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListReservations")
+	defer cancel()
+
+	var reservationsDB []reservationDB
+	err = r.db.WithContext(ctx).
+		Where("branch_id = ? AND starts_at < ? AND ends_at > ?", branch.ID, to, from).
+		Order("starts_at ASC, table_id ASC").
+		Find(&reservationsDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting reservations", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting reservations")
+	}
+	if len(reservationsDB) == 0 {
+		return nil, errs.NotFound("no_reservations", "there is no reservations")
+	}
+
+	ids := make([]string, 0, len(reservationsDB))
+	for _, rDB := range reservationsDB {
+		ids = append(ids, rDB.ID)
+	}
+	orderIDs, err := r.orderIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.Reservation, 0, len(reservationsDB))
+	for _, rDB := range reservationsDB {
+		result = append(result, *rDB.toReservationModel(orderIDs[rDB.ID]))
+	}
+
+	return result, nil
+}
+
+// UpdateReservationStatus moves the reservation to status only if it is still in from, so two
+// hosts marking the same reservation can't both succeed.
+func (r *ReservationRepository) UpdateReservationStatus(ctx context.Context, branch tenant.Branch, reservationID string, from, to model.Status) (_ *model.Reservation, err error) {
+	ctx, span := tracer.Start(ctx, "ReservationRepository.UpdateReservationStatus", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("reservation.id", reservationID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "UpdateReservationStatus")
+	defer cancel()
+
+	var reservationsDB []reservationDB
+	err = r.db.WithContext(ctx).
+		Raw(`UPDATE reservations SET status = ?, updated_at = ?
+			WHERE branch_id = ? AND id = ? AND status = ?
+			RETURNING *`,
+			string(to), time.Now().Truncate(time.Millisecond), branch.ID, reservationID, string(from)).
+		Scan(&reservationsDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error updating reservation", slog.Any("error", err))
+		return nil, dbError(ctx, err, "reservation wasn't updated")
+	}
+	if len(reservationsDB) == 0 {
+		return nil, errs.Conflict("reservation_not_booked", fmt.Sprintf("reservation is no longer %s", from))
+	}
+
+	orderIDs, err := r.orderIDs(ctx, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservationsDB[0].toReservationModel(orderIDs[reservationID]), nil
+}
+
+// AttachOrder adds the pre-order to the reservation, as long as it is still BOOKED. The
+// reservation row is locked by the same statement, so a cancellation either runs first and the
+// attach fails, or waits for it and then cancels the new pre-order too.
+func (r *ReservationRepository) AttachOrder(ctx context.Context, branch tenant.Branch, reservationID, orderID string) (err error) {
+	ctx, span := tracer.Start(ctx, "ReservationRepository.AttachOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AttachOrder")
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Exec(`INSERT INTO reservation_orders (order_id, reservation_id, branch_id, created_at)
+			SELECT ?, id, branch_id, ? FROM reservations
+			WHERE branch_id = ? AND id = ? AND status = ?
+			FOR UPDATE`,
+			orderID, time.Now().Truncate(time.Millisecond), branch.ID, reservationID, string(model.Booked))
+	if err = result.Error; err != nil {
+		if isUniqueViolation(err) {
+			return errs.Conflict("order_already_attached", "order is already attached to a reservation")
+		}
+		r.logger.ErrorContext(ctx, "error attaching order to reservation", slog.Any("error", err))
+		return dbError(ctx, err, "order wasn't attached")
+	}
+	if result.RowsAffected == 0 {
+		return errs.Conflict("reservation_not_booked", "pre-orders can only be placed for BOOKED reservations")
+	}
+
+	return nil
+}
+
+// orderIDs returns the pre-orders of each reservation, in the order they were placed.
+func (r *ReservationRepository) orderIDs(ctx context.Context, reservationIDs ...string) (map[string][]string, error) {
+	var rows []reservationOrderDB
+	err := r.db.WithContext(ctx).
+		Where("reservation_id IN ?", reservationIDs).
+		Order("created_at ASC").
+		Find(&rows).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting reservation orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting reservation orders")
+	}
+
+	result := make(map[string][]string, len(reservationIDs))
+	for _, row := range rows {
+		result[row.ReservationID] = append(result[row.ReservationID], row.OrderID)
+	}
+
+	return result, nil
+}
+
+func holdingStatuses() []string {
+	statuses := make([]string, 0, len(model.HoldingStatuses))
+	for _, s := range model.HoldingStatuses {
+		statuses = append(statuses, string(s))
+	}
+	return statuses
+}
@@ -2,28 +2,40 @@ package sql
 
 import (
 	domain "challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"errors"
 	"github.com/google/uuid"
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/gommon/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
-	"net/http"
+	"gorm.io/gorm/clause"
+	"log/slog"
 	"strings"
-	"sync"
 	"time"
 )
 
+var tracer = otel.Tracer("challenge-yuno/internal/platform/repositories/sql")
+
 type OrderRepository struct {
-	db *gorm.DB
-	mu sync.Mutex
+	db       *gorm.DB
+	timeouts Timeouts
+	logger   *slog.Logger
+	// mu serializes writes; it is a channel so waiting for it can be abandoned when the
+	// request context is done.
+	mu chan struct{}
 }
 
-func NewOrderRepository(db *gorm.DB) *OrderRepository {
-	db.Exec("DROP TABLE IF EXISTS orders")
-	db.Exec("DROP TABLE IF EXISTS order_dbs")
-	db.AutoMigrate(&orders{})
-	db.AutoMigrate(&orderDB{})
+func NewOrderRepository(db *gorm.DB, timeouts Timeouts, logger *slog.Logger) *OrderRepository {
 	return &OrderRepository{
-		db: db,
+		db:       db,
+		timeouts: timeouts,
+		logger:   logging.Named(logger, "sql"),
+		mu:       make(chan struct{}, 1),
 	}
 }
 
@@ -39,13 +51,53 @@ type orders struct {
 
 type orderDB struct {
 	ID        string    `json:"id" gorm:"type:string; size:255; primary_key;"`
-	CreatedAt time.Time `json:"created_at" gorm:"<-:create; type:time; not null;"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"type:time; not null"`
+	BranchID  string    `json:"branch_id" gorm:"type:string; size:255; not null; index"`
+	CreatedAt time.Time `json:"created_at" gorm:"<-:create; type:timestamptz; not null;"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamptz; not null"`
 	Menu      string    `json:"menu" gorm:"type:string; size:255; not null;"`
 	Status    string    `json:"status" gorm:"type:string; size:255; not null;"`
 	Source    string    `json:"order_source" gorm:"type:string; size:255; not null;"`
 	Type      string    `json:"order_type" gorm:"type:string; size:255; not null;"`
 	Priority  int       `json:"priority" gorm:"type:integer;not null;default:0"`
+	// ScheduledFor is when an order placed ahead of time is due, and is kept after it is promoted
+	ScheduledFor *time.Time `json:"scheduled_for" gorm:"type:timestamptz; index"`
+	// Version grows with every update; writes based on an older one are rejected
+	Version int    `json:"version" gorm:"type:integer; not null; default:1"`
+	Notes   string `json:"notes" gorm:"type:text; not null; default:''"`
+}
+
+// statusChangeDB records when an order entered a status. It is insert-only and written together
+// with the change, so reports can measure how long orders spent in each status.
+type statusChangeDB struct {
+	ID        uint64    `gorm:"primaryKey; autoIncrement"`
+	OrderID   string    `gorm:"type:string; size:255; not null; index"`
+	BranchID  string    `gorm:"type:string; size:255; not null; index:order_status_history_branch_changed"`
+	Status    string    `gorm:"type:string; size:255; not null"`
+	ChangedAt time.Time `gorm:"type:timestamptz; not null; index:order_status_history_branch_changed"`
+}
+
+func (statusChangeDB) TableName() string {
+	return "order_status_history"
+}
+
+// archivedOrderDB is a closed order moved out of order_dbs once it is old enough, so the live
+// table only holds recent orders.
+type archivedOrderDB struct {
+	orderDB    `gorm:"embedded"`
+	ArchivedAt time.Time `gorm:"type:timestamptz; not null"`
+}
+
+func (archivedOrderDB) TableName() string {
+	return "order_archive"
+}
+
+func newStatusChange(oDB orderDB) statusChangeDB {
+	return statusChangeDB{
+		OrderID:   oDB.ID,
+		BranchID:  oDB.BranchID,
+		Status:    oDB.Status,
+		ChangedAt: oDB.UpdatedAt,
+	}
 }
 
 func toOrderDB(o domain.Order) orders {
@@ -61,10 +113,11 @@ func toOrderDB(o domain.Order) orders {
 	}
 }
 
-func toOrderDB2(o domain.Order, priority int) orderDB {
+func toOrderDB2(branchID string, o domain.Order, priority int) orderDB {
 	now := time.Now().Truncate(time.Millisecond)
 	return orderDB{
 		ID:        uuid.New().String(),
+		BranchID:  branchID,
 		CreatedAt: now,
 		UpdatedAt: now,
 		Menu:      strings.Join(o.Menu, ","),
@@ -72,6 +125,29 @@ func toOrderDB2(o domain.Order, priority int) orderDB {
 		Source:    string(o.Source),
 		Type:      string(o.Type),
 		Priority:  priority + 1,
+		Version:   1,
+
+		ScheduledFor: o.ScheduledFor,
+	}
+}
+
+// toImportedOrderDB keeps the status, timestamps and priority of an order loaded from another
+// system instead of assigning new ones.
+func toImportedOrderDB(branchID string, o domain.Order) orderDB {
+	return orderDB{
+		ID:        uuid.New().String(),
+		BranchID:  branchID,
+		CreatedAt: o.CreatedAt.Truncate(time.Millisecond),
+		UpdatedAt: o.UpdatedAt.Truncate(time.Millisecond),
+		Menu:      strings.Join(o.Menu, ","),
+		Status:    string(o.Status),
+		Source:    string(o.Source),
+		Type:      string(o.Type),
+		Priority:  o.Priority,
+		Version:   1,
+		Notes:     o.Notes,
+
+		ScheduledFor: o.ScheduledFor,
 	}
 }
 
@@ -90,6 +166,7 @@ func (o *orders) toOrderModel() *domain.Order {
 func (o *orderDB) toOrderModel() *domain.Order {
 	return &domain.Order{
 		ID:        o.ID,
+		BranchID:  o.BranchID,
 		CreatedAt: o.CreatedAt,
 		UpdatedAt: o.UpdatedAt,
 		Menu:      strings.Split(o.Menu, ","),
@@ -97,6 +174,10 @@ func (o *orderDB) toOrderModel() *domain.Order {
 		Source:    domain.Source(o.Source),
 		Type:      domain.OrderType(o.Type),
 		Priority:  o.Priority,
+		Version:   o.Version,
+		Notes:     o.Notes,
+
+		ScheduledFor: o.ScheduledFor,
 	}
 }
 
@@ -109,93 +190,526 @@ func (r *OrderRepository) mapOrdersDBToOrdersModel(ordersDB []orderDB) []domain.
 	return result
 }
 
-func (r *OrderRepository) AddOrder(order domain.Order) (*domain.Order, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// scoped returns a query restricted to the rows of the given branch. Every read and write on
+// order_dbs must start from it so a branch can never reach another branch's orders.
+func (r *OrderRepository) scoped(ctx context.Context, branch tenant.Branch) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&orderDB{}).Where("branch_id = ?", branch.ID)
+}
+
+// filtered is the query behind listings and exports: the orders of the branch that match the
+// filter, by priority.
+func (r *OrderRepository) filtered(ctx context.Context, branch tenant.Branch, filter domain.Filter) *gorm.DB {
+	query := r.scoped(ctx, branch)
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if len(filter.Sources) > 0 {
+		query = query.Where("source IN ?", filter.Sources)
+	}
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+
+	return query.Order("priority ASC").Order("created_at ASC")
+}
+
+func (r *OrderRepository) lock(ctx context.Context) error {
+	select {
+	case r.mu <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *OrderRepository) unlock() {
+	<-r.mu
+}
 
-	priority, err := r.getDailyPriority()
+func (r *OrderRepository) AddOrder(ctx context.Context, branch tenant.Branch, order domain.Order) (_ *domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.AddOrder", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AddOrder")
+	defer cancel()
+
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "order wasn't created")
+	}
+	defer r.unlock()
+
+	priority, err := r.getDailyPriority(ctx, branch)
 	if err != nil {
 		return nil, err
 	}
-	oDB := toOrderDB2(order, *priority)
-	err = r.db.Create(&oDB).Error
+	oDB := toOrderDB2(branch.ID, order, *priority)
+
+	insertCtx, insertSpan := tracer.Start(ctx, "OrderRepository.insert")
+	err = r.db.WithContext(insertCtx).Transaction(func(tx *gorm.DB) error {
+		return insertOrder(tx, oDB)
+	})
+	tracing.End(insertSpan, err)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error saving order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "order wasn't created")
+	}
+
+	return oDB.toOrderModel(), nil
+}
+
+func (r *OrderRepository) ImportOrder(ctx context.Context, branch tenant.Branch, order domain.Order) (_ *domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ImportOrder", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ImportOrder")
+	defer cancel()
+
+	oDB := toImportedOrderDB(branch.ID, order)
+	// only the last status is known, so the history starts when the order reached it
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return insertOrder(tx, oDB)
+	})
 	if err != nil {
-		log.Errorf("error saving order: %v", err)
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "order wasn't created")
+		r.logger.ErrorContext(ctx, "error importing order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "order wasn't imported")
 	}
 
 	return oDB.toOrderModel(), nil
 }
 
-func (r *OrderRepository) GetOrder(orderID string) (*domain.Order, error) {
+func (r *OrderRepository) GetOrder(ctx context.Context, branch tenant.Branch, orderID string) (_ *domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.GetOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", orderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "GetOrder")
+	defer cancel()
+
 	var oDB orderDB
 
-	err := r.db.Model(&orderDB{}).First(&oDB, "id = ?", orderID).Error
+	err = r.scoped(ctx, branch).First(&oDB, "id = ?", orderID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.getArchivedOrder(ctx, branch, orderID)
+	}
 	if err != nil {
-		if strings.Contains(err.Error(), "record not found") {
-			return nil, echo.NewHTTPError(http.StatusNotFound, "order not found")
-		}
-		log.Errorf("error getting order: %v", err)
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "error getting order")
+		r.logger.ErrorContext(ctx, "error getting order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting order")
 	}
 
 	return oDB.toOrderModel(), nil
 }
 
-func (r *OrderRepository) ListActiveOrders() ([]domain.Order, error) {
+// getArchivedOrder looks the order up in the archive, where closed orders end up after a while.
+func (r *OrderRepository) getArchivedOrder(ctx context.Context, branch tenant.Branch, orderID string) (*domain.Order, error) {
+	var archived archivedOrderDB
+
+	err := r.db.WithContext(ctx).Where("branch_id = ? AND id = ?", branch.ID, orderID).First(&archived).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.NotFound("order_not_found", "order not found")
+		}
+		r.logger.ErrorContext(ctx, "error getting archived order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting order")
+	}
+
+	return archived.toOrderModel(), nil
+}
+
+func (r *OrderRepository) ListActiveOrders(ctx context.Context, branch tenant.Branch) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ListActiveOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListActiveOrders")
+	defer cancel()
+
 	var ordersDB []orderDB
 
-	err := r.db.Where("status = ?", domain.Pending).
+	err = r.scoped(ctx, branch).
+		Where("status = ?", domain.Pending).
 		Order("priority ASC").
 		Order("created_at ASC").
 		Find(&ordersDB).
 		Error
 	if err != nil {
-		log.Errorf("error getting order: %v", err)
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "error getting order")
+		r.logger.ErrorContext(ctx, "error getting order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting order")
+	}
+	if len(ordersDB) == 0 {
+		return nil, errs.NotFound("no_active_orders", "there is no active orders")
+	}
+
+	return r.mapOrdersDBToOrdersModel(ordersDB), nil
+}
+
+func (r *OrderRepository) ListScheduledOrders(ctx context.Context, branch tenant.Branch) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ListScheduledOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListScheduledOrders")
+	defer cancel()
+
+	var ordersDB []orderDB
+
+	err = r.scoped(ctx, branch).
+		Where("status = ?", domain.Scheduled).
+		Order("scheduled_for ASC").
+		Find(&ordersDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting scheduled orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting scheduled orders")
 	}
 	if len(ordersDB) == 0 {
-		return nil, echo.NewHTTPError(http.StatusNotFound, "there is no active orders")
+		return nil, errs.NotFound("no_scheduled_orders", "there is no scheduled orders")
 	}
 
 	return r.mapOrdersDBToOrdersModel(ordersDB), nil
 }
 
-func (r *OrderRepository) UpdateOrder(orderID string, status domain.Status, priority *int) (*domain.Order, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+// PromoteScheduledOrders moves the scheduled orders of the given type due before dueBefore into
+// PENDING, across every branch, and returns them. Like new orders they get a fresh priority, after
+// every order already waiting in the queue of their branch, in the order they were due. The update
+// and the read are a single statement so two replicas never promote the same order twice.
+func (r *OrderRepository) PromoteScheduledOrders(ctx context.Context, orderType domain.OrderType, dueBefore time.Time) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.PromoteScheduledOrders", trace.WithAttributes(attribute.String("order.type", string(orderType))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "PromoteScheduledOrders")
+	defer cancel()
+
+	// priorities are taken under the same lock as AddOrder's, so the two don't hand out the same one
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "error promoting scheduled orders")
+	}
+	defer r.unlock()
+
+	var ordersDB []orderDB
+
+	err = r.db.WithContext(ctx).
+		Raw(`WITH due AS (
+				SELECT id, branch_id, scheduled_for, created_at FROM order_dbs
+				WHERE status = ? AND type = ? AND scheduled_for <= ?
+				FOR UPDATE
+			), numbered AS (
+				SELECT d.id, ROW_NUMBER() OVER (PARTITION BY d.branch_id ORDER BY d.scheduled_for, d.created_at) + COALESCE((
+					SELECT MAX(p.priority) FROM order_dbs p WHERE p.branch_id = d.branch_id AND p.status = ?
+				), 0) AS priority
+				FROM due d
+			), promoted AS (
+				UPDATE order_dbs o SET status = ?, priority = n.priority, updated_at = ?, version = o.version + 1
+				FROM numbered n
+				WHERE o.id = n.id
+				RETURNING o.*
+			), history AS (
+				INSERT INTO order_status_history (order_id, branch_id, status, changed_at)
+				SELECT id, branch_id, status, updated_at FROM promoted
+			)
+			SELECT * FROM promoted ORDER BY branch_id, priority`,
+			domain.Scheduled, orderType, dueBefore, domain.Pending, domain.Pending, time.Now().Truncate(time.Millisecond)).
+		Scan(&ordersDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error promoting scheduled orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error promoting scheduled orders")
+	}
+
+	return r.mapOrdersDBToOrdersModel(ordersDB), nil
+}
+
+// UpdateOrder applies a status change. With update.Version set the row is only written if it is
+// still at that version, so of two concurrent changes to an order, on any replica, the second one
+// fails instead of overwriting the first. The order returned is the row the update wrote, so it
+// doesn't depend on reads outside the transaction it runs in.
+func (r *OrderRepository) UpdateOrder(ctx context.Context, branch tenant.Branch, update domain.StatusUpdate) (_ *domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.UpdateOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", update.OrderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "UpdateOrder")
+	defer cancel()
+
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "error updating order")
+	}
+	defer r.unlock()
+
+	_, current, err := updateStatus(r.db.WithContext(ctx), branch, update)
+	if err != nil {
+		if _, known := errs.As(err); known {
+			return nil, err
+		}
+		r.logger.ErrorContext(ctx, "error updating order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error updating order")
+	}
+
+	return current.toOrderModel(), nil
+}
 
-	var err error
-	if priority == nil {
-		err = r.db.Model(&orderDB{}).Where("id = ?", orderID).Update("status", status).Error
-	} else {
-		err = r.db.Model(&orderDB{}).
-			Where("id = ?", orderID).
-			Update("status", status).
-			Update("priority", *priority).
+// MoveOrder applies move to the queue of the branch and returns the queue in its new order. The
+// queue is locked while it is renumbered, and only the orders whose priority changed are written,
+// in a single UPDATE. updated_at is left alone, it marks when the order entered its status.
+func (r *OrderRepository) MoveOrder(ctx context.Context, branch tenant.Branch, move domain.Move) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.MoveOrder", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.String("order.id", move.OrderID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "MoveOrder")
+	defer cancel()
+
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "error moving order")
+	}
+	defer r.unlock()
+
+	var queue []domain.Order
+	err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ordersDB []orderDB
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("branch_id = ? AND status = ?", branch.ID, domain.Pending).
+			Order("priority ASC").
+			Order("created_at ASC").
+			Find(&ordersDB).
 			Error
+		if err != nil {
+			return err
+		}
+
+		if queue, err = domain.Reorder(r.mapOrdersDBToOrdersModel(ordersDB), move); err != nil {
+			return err
+		}
+
+		priorities := make(map[string]int, len(ordersDB))
+		for _, oDB := range ordersDB {
+			priorities[oDB.ID] = oDB.Priority
+		}
+		var values []string
+		var args []any
+		for i := range queue {
+			if queue[i].Priority != priorities[queue[i].ID] {
+				queue[i].Version++
+				values = append(values, "(?, CAST(? AS integer))")
+				args = append(args, queue[i].ID, queue[i].Priority)
+			}
+		}
+		if len(values) == 0 {
+			return nil
+		}
+
+		return tx.Exec(`UPDATE order_dbs o SET priority = v.priority, version = o.version + 1
+			FROM (VALUES `+strings.Join(values, ", ")+`) AS v(id, priority)
+			WHERE o.branch_id = ? AND o.id = v.id`, append(args, branch.ID)...).Error
+	})
+	if err != nil {
+		if _, known := errs.As(err); known {
+			return nil, err
+		}
+		r.logger.ErrorContext(ctx, "error moving order", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error moving order")
+	}
+
+	return queue, nil
+}
+
+// insertOrder saves a new order and the first entry of its history.
+func insertOrder(tx *gorm.DB, oDB orderDB) error {
+	if err := tx.Create(&oDB).Error; err != nil {
+		return err
+	}
+	change := newStatusChange(oDB)
+	return tx.Create(&change).Error
+}
+
+// updatedOrderDB is a row returned by updateStatus: the order after the change, along with the
+// status, time and priority it had before it.
+type updatedOrderDB struct {
+	orderDB           `gorm:"embedded"`
+	PreviousStatus    string
+	PreviousUpdatedAt time.Time
+	PreviousPriority  int
+}
+
+// updateStatus applies a status change and records it in the history with a single UPDATE ...
+// RETURNING, returning the order before and after it. The row is locked while it is read, so the
+// previous values are those the change replaced even with concurrent writers.
+func updateStatus(tx *gorm.DB, branch tenant.Branch, update domain.StatusUpdate) (previous, current orderDB, err error) {
+	var rows []updatedOrderDB
+	err = tx.
+		Raw(`WITH previous AS (
+				SELECT id, status, updated_at, priority, version FROM order_dbs
+				WHERE branch_id = ? AND id = ?
+				FOR UPDATE
+			), updated AS (
+				UPDATE order_dbs o SET status = ?, updated_at = ?, priority = COALESCE(?, o.priority), version = o.version + 1
+				FROM previous p
+				WHERE o.branch_id = ? AND o.id = p.id AND (CAST(? AS integer) IS NULL OR p.version = ?)
+				RETURNING o.*, p.status AS previous_status, p.updated_at AS previous_updated_at, p.priority AS previous_priority
+			), history AS (
+				INSERT INTO order_status_history (order_id, branch_id, status, changed_at)
+				SELECT id, branch_id, status, updated_at FROM updated WHERE status <> previous_status
+			)
+			SELECT * FROM updated`,
+			branch.ID, update.OrderID,
+			update.Status, time.Now().Truncate(time.Millisecond), update.Priority,
+			branch.ID, update.Version, update.Version).
+		Scan(&rows).
+		Error
+	if err != nil {
+		return previous, current, err
+	}
+
+	if len(rows) == 0 {
+		// nothing was written, either there is no such order or it is past the expected version
+		var count int64
+		if err = tx.Model(&orderDB{}).Where("branch_id = ? AND id = ?", branch.ID, update.OrderID).Count(&count).Error; err != nil {
+			return previous, current, err
+		}
+		if count == 0 {
+			return previous, current, errs.NotFound("order_not_found", "order not found")
+		}
+		return previous, current, domain.ErrOrderModified
 	}
+
+	current = rows[0].orderDB
+	previous = current
+	previous.Status = rows[0].PreviousStatus
+	previous.UpdatedAt = rows[0].PreviousUpdatedAt
+	previous.Priority = rows[0].PreviousPriority
+	previous.Version = current.Version - 1
+	return previous, current, nil
+}
+
+// AddOrders creates a batch of new orders in a single transaction, with consecutive priorities.
+// Every order runs in its own savepoint: in best-effort mode a failed order is rolled back alone,
+// in all-or-nothing mode the first failure rolls back the whole batch.
+func (r *OrderRepository) AddOrders(ctx context.Context, branch tenant.Branch, orders []domain.Order, mode domain.BatchMode) (_ []domain.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.AddOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.Int("batch.size", len(orders))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "AddOrders")
+	defer cancel()
+
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "orders weren't created")
+	}
+	defer r.unlock()
+
+	priority, err := r.getDailyPriority(ctx, branch)
 	if err != nil {
-		log.Errorf("error updating order %s: %v", orderID, err)
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "error updating order")
+		return nil, err
 	}
 
-	return r.GetOrder(orderID)
+	next := *priority
+	return r.runBatch(ctx, len(orders), mode, "order wasn't created", func(tx *gorm.DB, i int) (domain.BatchResult, error) {
+		oDB := toOrderDB2(branch.ID, orders[i], next)
+		if err := insertOrder(tx, oDB); err != nil {
+			return domain.BatchResult{}, err
+		}
+		next++
+		return domain.BatchResult{Order: oDB.toOrderModel()}, nil
+	})
 }
 
-func (r *OrderRepository) GetAllOrders() ([]domain.Order, error) {
+// ImportOrders is the batch form of ImportOrder, with the transaction rules of AddOrders.
+func (r *OrderRepository) ImportOrders(ctx context.Context, branch tenant.Branch, orders []domain.Order, mode domain.BatchMode) (_ []domain.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ImportOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.Int("batch.size", len(orders))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ImportOrders")
+	defer cancel()
+
+	return r.runBatch(ctx, len(orders), mode, "order wasn't imported", func(tx *gorm.DB, i int) (domain.BatchResult, error) {
+		oDB := toImportedOrderDB(branch.ID, orders[i])
+		if err := insertOrder(tx, oDB); err != nil {
+			return domain.BatchResult{}, err
+		}
+		return domain.BatchResult{Order: oDB.toOrderModel()}, nil
+	})
+}
+
+// UpdateOrders changes the status of a batch of orders, with the transaction rules of AddOrders.
+func (r *OrderRepository) UpdateOrders(ctx context.Context, branch tenant.Branch, updates []domain.StatusUpdate, mode domain.BatchMode) (_ []domain.BatchResult, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.UpdateOrders", trace.WithAttributes(
+		attribute.String("branch.id", branch.ID), attribute.Int("batch.size", len(updates))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "UpdateOrders")
+	defer cancel()
+
+	if err = r.lock(ctx); err != nil {
+		return nil, dbError(ctx, err, "error updating orders")
+	}
+	defer r.unlock()
+
+	return r.runBatch(ctx, len(updates), mode, "error updating order", func(tx *gorm.DB, i int) (domain.BatchResult, error) {
+		previous, current, err := updateStatus(tx, branch, updates[i])
+		if err != nil {
+			return domain.BatchResult{}, err
+		}
+		return domain.BatchResult{Order: current.toOrderModel(), Previous: previous.toOrderModel()}, nil
+	})
+}
+
+// errBatchAborted stops the transaction of an all-or-nothing batch once an item fails.
+var errBatchAborted = errors.New("batch aborted")
+
+// runBatch runs apply for every item of a batch in one transaction, each in its own savepoint.
+// Item failures are returned in the results; err is only set when the transaction itself fails.
+func (r *OrderRepository) runBatch(ctx context.Context, size int, mode domain.BatchMode, message string,
+	apply func(tx *gorm.DB, i int) (domain.BatchResult, error)) ([]domain.BatchResult, error) {
+	results := make([]domain.BatchResult, size)
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range results {
+			var result domain.BatchResult
+			itemErr := tx.Transaction(func(tx *gorm.DB) error {
+				var err error
+				result, err = apply(tx, i)
+				return err
+			})
+			if itemErr != nil {
+				if _, known := errs.As(itemErr); !known {
+					r.logger.ErrorContext(ctx, "error applying batch item", slog.Int("item", i), slog.Any("error", itemErr))
+					itemErr = dbError(ctx, itemErr, message)
+				}
+				results[i] = domain.BatchResult{Err: itemErr}
+				if mode == domain.AllOrNothing {
+					return errBatchAborted
+				}
+				continue
+			}
+			results[i] = result
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchAborted) {
+		return domain.Abort(results), nil
+	}
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error applying batch", slog.Any("error", err))
+		return nil, dbError(ctx, err, "batch wasn't applied")
+	}
+
+	return results, nil
+}
+
+func (r *OrderRepository) GetAllOrders(ctx context.Context, branch tenant.Branch, filter domain.Filter) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.GetAllOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "GetAllOrders")
+	defer cancel()
+
 	var ordersDB []orderDB
 
-	err := r.db.
-		Order("priority ASC").
-		Order("created_at ASC").
+	err = r.filtered(ctx, branch, filter).
 		Find(&ordersDB).
 		Error
 	if err != nil {
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "error getting all order")
+		return nil, dbError(ctx, err, "error getting all order")
 	}
 	if len(ordersDB) == 0 {
-		return nil, echo.NewHTTPError(http.StatusNotFound, "orders not found")
+		return nil, errs.NotFound("no_orders", "orders not found")
 	}
 
 	result := make([]domain.Order, 0, len(ordersDB))
@@ -206,22 +720,148 @@ func (r *OrderRepository) GetAllOrders() ([]domain.Order, error) {
 	return r.mapOrdersDBToOrdersModel(ordersDB), nil
 }
 
-func (r *OrderRepository) getDailyPriority() (*int, error) {
+// StreamOrders calls fn with every order of the branch that matches the filter, reading them one
+// at a time so exports don't hold the whole table in memory. It stops at the first error fn
+// returns. Streams aren't bound by the operation timeout, the caller's context ends them.
+func (r *OrderRepository) StreamOrders(ctx context.Context, branch tenant.Branch, filter domain.Filter, fn func(domain.Order) error) (err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.StreamOrders", trace.WithAttributes(attribute.String("branch.id", branch.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	rows, err := r.filtered(ctx, branch, filter).Rows()
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error streaming orders", slog.Any("error", err))
+		return dbError(ctx, err, "error getting orders")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var oDB orderDB
+		if err = r.db.ScanRows(rows, &oDB); err != nil {
+			r.logger.ErrorContext(ctx, "error reading order", slog.Any("error", err))
+			return dbError(ctx, err, "error getting orders")
+		}
+
+		if err = fn(*oDB.toOrderModel()); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *OrderRepository) getDailyPriority(ctx context.Context, branch tenant.Branch) (_ *int, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.getDailyPriority")
+	defer func() { tracing.End(span, err) }()
+
 	var count int64
 
-	startOfDay := time.Now().Truncate(24 * time.Hour)
-	endOfDay := startOfDay.Add(24 * time.Hour).Add(-time.Nanosecond)
+	startOfDay := branch.StartOfDay(time.Now())
+	endOfDay := startOfDay.AddDate(0, 0, 1).Add(-time.Nanosecond)
 
-	// Contar las órdenes del día
-	err := r.db.Model(&orderDB{}).
+	// Contar las órdenes del día de la sucursal
+	err = r.scoped(ctx, branch).
 		Where("created_at BETWEEN ? AND ?", startOfDay, endOfDay).
 		Count(&count).Error
 
 	if err != nil {
-		return nil, echo.NewHTTPError(http.StatusInternalServerError, "error getting daily priority")
+		return nil, dbError(ctx, err, "error getting daily priority")
 	}
 
 	countResult := int(count)
 
 	return &countResult, nil
 }
+
+// CountActiveOrders returns how many orders of every branch are waiting, in preparation or
+// ready for delivery. It is meant for operational metrics and never exposes the orders.
+func (r *OrderRepository) CountActiveOrders(ctx context.Context) (map[string]map[domain.Status]int, error) {
+	ctx, cancel := r.timeouts.withTimeout(ctx, "CountActiveOrders")
+	defer cancel()
+
+	var rows []struct {
+		BranchID string
+		Status   string
+		Count    int
+	}
+
+	err := r.db.WithContext(ctx).Model(&orderDB{}).
+		Select("branch_id, status, count(*) AS count").
+		Where("status IN ?", []domain.Status{domain.Pending, domain.InPreparation, domain.Finished}).
+		Group("branch_id, status").
+		Scan(&rows).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error counting active orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error counting active orders")
+	}
+
+	result := make(map[string]map[domain.Status]int)
+	for _, row := range rows {
+		if _, exists := result[row.BranchID]; !exists {
+			result[row.BranchID] = make(map[domain.Status]int)
+		}
+		result[row.BranchID][domain.Status(row.Status)] = row.Count
+	}
+
+	return result, nil
+}
+
+// ArchiveOrders moves the orders of every branch that are in one of the given statuses, and
+// haven't changed since before closedBefore, into the archive. Age is taken from the last entry of
+// the status history, since edits don't touch updated_at. It returns how many were moved.
+func (r *OrderRepository) ArchiveOrders(ctx context.Context, statuses []domain.Status, closedBefore time.Time) (_ int64, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ArchiveOrders")
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ArchiveOrders")
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Exec(`WITH moved AS (
+				DELETE FROM order_dbs o
+				WHERE o.status IN ? AND (
+					SELECT MAX(h.changed_at) FROM order_status_history h WHERE h.order_id = o.id
+				) < ?
+				RETURNING *
+			)
+			INSERT INTO order_archive (id, branch_id, created_at, updated_at, menu, status, source, type, priority, scheduled_for, version, notes, archived_at)
+			SELECT id, branch_id, created_at, updated_at, menu, status, source, type, priority, scheduled_for, version, notes, ?
+			FROM moved`,
+			statuses, closedBefore, time.Now().Truncate(time.Millisecond))
+	if result.Error != nil {
+		r.logger.ErrorContext(ctx, "error archiving orders", slog.Any("error", result.Error))
+		return 0, dbError(ctx, result.Error, "error archiving orders")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// ListOverdueOrders returns the orders of every branch that have been in the given status since
+// before updatedBefore, oldest first. As in ArchiveOrders, the time they entered it is the last
+// entry of the status history.
+func (r *OrderRepository) ListOverdueOrders(ctx context.Context, status domain.Status, updatedBefore time.Time) (_ []domain.Order, err error) {
+	ctx, span := tracer.Start(ctx, "OrderRepository.ListOverdueOrders", trace.WithAttributes(attribute.String("order.status", string(status))))
+	defer func() { tracing.End(span, err) }()
+
+	ctx, cancel := r.timeouts.withTimeout(ctx, "ListOverdueOrders")
+	defer cancel()
+
+	var ordersDB []orderDB
+
+	err = r.db.WithContext(ctx).
+		Raw(`SELECT o.* FROM order_dbs o
+				JOIN LATERAL (
+					SELECT MAX(h.changed_at) AS changed_at FROM order_status_history h WHERE h.order_id = o.id
+				) h ON true
+				WHERE o.status = ? AND h.changed_at < ?
+				ORDER BY h.changed_at ASC`,
+			status, updatedBefore).
+		Scan(&ordersDB).
+		Error
+	if err != nil {
+		r.logger.ErrorContext(ctx, "error getting overdue orders", slog.Any("error", err))
+		return nil, dbError(ctx, err, "error getting overdue orders")
+	}
+
+	return r.mapOrdersDBToOrdersModel(ordersDB), nil
+}
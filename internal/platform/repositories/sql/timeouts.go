@@ -0,0 +1,46 @@
+package sql
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const DefaultTimeout = 5 * time.Second
+
+// Timeouts bounds how long every repository operation may wait on the database. Operations are
+// keyed by method name, e.g. "GetAllOrders"; the ones not listed use Default.
+type Timeouts struct {
+	Default    time.Duration
+	Operations map[string]time.Duration
+}
+
+func (t Timeouts) For(operation string) time.Duration {
+	if timeout, ok := t.Operations[operation]; ok && timeout > 0 {
+		return timeout
+	}
+	if t.Default > 0 {
+		return t.Default
+	}
+	return DefaultTimeout
+}
+
+func (t Timeouts) withTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, t.For(operation))
+}
+
+// dbError wraps a database error with the operation that failed. Queries cut by the operation
+// deadline or by the caller going away are reported as unavailable, keeping the context error as
+// the cause so they can be told apart from failures.
+func dbError(ctx context.Context, err error, message string) error {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return errs.Unavailable("database_timeout", message+": database timeout", context.DeadlineExceeded)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return errs.Unavailable("request_canceled", message+": request canceled", context.Canceled)
+	}
+
+	return fmt.Errorf("%s: %w", message, err)
+}
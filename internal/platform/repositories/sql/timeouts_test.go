@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/business/domain/tenant"
+	"challenge-yuno/internal/business/errs"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type TimeoutsTestSuite struct {
+	suite.Suite
+}
+
+func TestTimeouts(t *testing.T) {
+	suite.Run(t, new(TimeoutsTestSuite))
+}
+
+func (s *TimeoutsTestSuite) TestFor() {
+	timeouts := Timeouts{
+		Default:    2 * time.Second,
+		Operations: map[string]time.Duration{"GetAllOrders": 10 * time.Second},
+	}
+
+	s.Equal(10*time.Second, timeouts.For("GetAllOrders"))
+	s.Equal(2*time.Second, timeouts.For("AddOrder"))
+	s.Equal(DefaultTimeout, Timeouts{}.For("AddOrder"))
+}
+
+func (s *TimeoutsTestSuite) TestDBError() {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	connectionRefused := fmt.Errorf("connection refused")
+
+	var tests = []struct {
+		name          string
+		ctx           context.Context
+		err           error
+		expectedError error
+	}{
+		{
+			name:          "deadline_exceeded",
+			ctx:           expired,
+			err:           fmt.Errorf("timeout: %w", context.DeadlineExceeded),
+			expectedError: errs.Unavailable("database_timeout", "error getting order: database timeout", context.DeadlineExceeded),
+		},
+		{
+			name:          "request_canceled",
+			ctx:           canceled,
+			err:           context.Canceled,
+			expectedError: errs.Unavailable("request_canceled", "error getting order: request canceled", context.Canceled),
+		},
+		{
+			name:          "database_error",
+			ctx:           context.Background(),
+			err:           connectionRefused,
+			expectedError: fmt.Errorf("error getting order: %w", connectionRefused),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expectedError, dbError(tt.ctx, tt.err, "error getting order"))
+		})
+	}
+}
+
+func (s *TimeoutsTestSuite) TestWaitingForLockTimesOut() {
+	repo := &OrderRepository{
+		timeouts: Timeouts{Default: 10 * time.Millisecond},
+		mu:       make(chan struct{}, 1),
+	}
+	repo.mu <- struct{}{}
+
+	response, err := repo.AddOrder(context.Background(), tenant.Branch{ID: "centro"}, order.Order{})
+	s.Nil(response)
+	s.Equal(errs.Unavailable("database_timeout", "order wasn't created: database timeout", context.DeadlineExceeded), err)
+}
@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"challenge-yuno/internal/business/errs"
+	"challenge-yuno/internal/business/interfaces"
+	"challenge-yuno/internal/platform/logging"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"gorm.io/gorm"
+	"log/slog"
+)
+
+// UnitOfWork runs a function in a database transaction, handing it copies of the repositories
+// bound to that transaction.
+type UnitOfWork struct {
+	db           *gorm.DB
+	orders       *OrderRepository
+	inventory    *InventoryRepository
+	outbox       *OutboxRepository
+	dining       *DiningRepository
+	reservations *ReservationRepository
+	logger       *slog.Logger
+}
+
+func NewUnitOfWork(db *gorm.DB, orders *OrderRepository, inventory *InventoryRepository, outbox *OutboxRepository,
+	dining *DiningRepository, reservations *ReservationRepository, logger *slog.Logger) *UnitOfWork {
+	return &UnitOfWork{
+		db:           db,
+		orders:       orders,
+		inventory:    inventory,
+		outbox:       outbox,
+		dining:       dining,
+		reservations: reservations,
+		logger:       logging.Named(logger, "sql"),
+	}
+}
+
+func (u *UnitOfWork) Do(ctx context.Context, fn func(repos interfaces.Repositories) error) (err error) {
+	ctx, span := tracer.Start(ctx, "UnitOfWork.Do")
+	defer func() { tracing.End(span, err) }()
+
+	err = u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(interfaces.Repositories{
+			Orders:       u.orders.withTx(tx),
+			Inventory:    u.inventory.withTx(tx),
+			Outbox:       u.outbox.withTx(tx),
+			Dining:       u.dining.withTx(tx),
+			Reservations: u.reservations.withTx(tx),
+		})
+	})
+	if err != nil {
+		if _, known := errs.As(err); known {
+			return err
+		}
+		u.logger.ErrorContext(ctx, "error running unit of work", slog.Any("error", err))
+		return dbError(ctx, err, "changes weren't saved")
+	}
+
+	return nil
+}
+
+// withTx returns a copy of the repository that runs its queries in tx. The copy shares the write
+// lock, so it still serializes with the writes of the original.
+func (r *OrderRepository) withTx(tx *gorm.DB) *OrderRepository {
+	bound := *r
+	bound.db = tx
+	return &bound
+}
+
+func (r *InventoryRepository) withTx(tx *gorm.DB) *InventoryRepository {
+	bound := *r
+	bound.db = tx
+	return &bound
+}
+
+func (r *OutboxRepository) withTx(tx *gorm.DB) *OutboxRepository {
+	bound := *r
+	bound.db = tx
+	return &bound
+}
+
+func (r *DiningRepository) withTx(tx *gorm.DB) *DiningRepository {
+	bound := *r
+	bound.db = tx
+	return &bound
+}
+
+func (r *ReservationRepository) withTx(tx *gorm.DB) *ReservationRepository {
+	bound := *r
+	bound.db = tx
+	return &bound
+}
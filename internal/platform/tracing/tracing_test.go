@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+	exporter *tracetest.InMemoryExporter
+	provider *sdktrace.TracerProvider
+}
+
+func (s *TracingTestSuite) SetupSuite() {
+	s.exporter = tracetest.NewInMemoryExporter()
+	s.provider = NewProvider(s.exporter)
+}
+
+func (s *TracingTestSuite) spans() tracetest.SpanStubs {
+	s.Require().NoError(s.provider.ForceFlush(context.Background()))
+	return s.exporter.GetSpans()
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.exporter.Reset()
+}
+
+func TestTracing(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) TestMiddleware() {
+	e := echo.New()
+	e.Use(Middleware())
+
+	var handlerSpan trace.SpanContext
+	e.GET("/order/:ID", func(c echo.Context) error {
+		handlerSpan = trace.SpanContextFromContext(c.Request().Context())
+		if c.Param("ID") == "broken" {
+			return echo.NewHTTPError(http.StatusInternalServerError, "error getting order")
+		}
+		return c.JSON(http.StatusOK, "ok")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/order/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := s.spans()
+	s.Require().Len(spans, 1)
+	s.Equal("GET /order/:ID", spans[0].Name)
+	s.Equal(trace.SpanKindServer, spans[0].SpanKind)
+	s.Equal("4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+	s.Equal("00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	s.Equal(spans[0].SpanContext.SpanID(), handlerSpan.SpanID())
+	s.Contains(spans[0].Attributes, attribute.Int("http.response.status_code", http.StatusOK))
+
+	s.exporter.Reset()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/order/broken", nil))
+
+	spans = s.spans()
+	s.Require().Len(spans, 1)
+	s.Equal(codes.Error, spans[0].Status.Code)
+	s.Contains(spans[0].Attributes, attribute.Int("http.response.status_code", http.StatusInternalServerError))
+}
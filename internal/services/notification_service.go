@@ -1,20 +1,66 @@
 package services
 
 import (
+	"bytes"
 	"challenge-yuno/internal/business/domain/order"
-	"github.com/labstack/gommon/log"
+	"challenge-yuno/internal/platform/logging"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"log/slog"
+	"net/http"
+	"time"
 )
 
 type NotificationService struct {
 	notificationClient string
+	webhookURL         string
+	httpClient         *http.Client
+	logger             *slog.Logger
 }
 
-func NewNotificationService(notificationClient string) *NotificationService {
+// NewNotificationService creates the service that tells customers their order is ready. When a
+// webhook URL is given the order is also posted there, otherwise the notification is only logged.
+func NewNotificationService(notificationClient string, webhookURL string, logger *slog.Logger) *NotificationService {
 	return &NotificationService{
 		notificationClient: notificationClient,
+		webhookURL:         webhookURL,
+		httpClient:         &http.Client{Timeout: 5 * time.Second},
+		logger:             logging.Named(logger, "services"),
 	}
 }
 
-func (n *NotificationService) SendNotification(order *order.Order) {
-	log.Infof("send notification of order %s through client %s \n", order.ID, n.notificationClient)
+func (n *NotificationService) SendNotification(ctx context.Context, order *order.Order) error {
+	n.logger.InfoContext(ctx, "sending notification",
+		slog.String("id", order.ID), slog.String("client", n.notificationClient))
+	if len(n.webhookURL) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Notification-Client", n.notificationClient)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	res, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("notification webhook answered with status %d", res.StatusCode)
+	}
+
+	return nil
 }
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"challenge-yuno/internal/business/domain/order"
+	"challenge-yuno/internal/platform/tracing"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var discardLogger = slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+type NotificationServiceTestSuite struct {
+	suite.Suite
+}
+
+func (s *NotificationServiceTestSuite) SetupSuite() {
+	tracing.NewProvider(tracetest.NewInMemoryExporter())
+}
+
+func TestNotificationService(t *testing.T) {
+	suite.Run(t, new(NotificationServiceTestSuite))
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotificationWithoutWebhook() {
+	service := NewNotificationService("whatsapp", "", discardLogger)
+	s.NoError(service.SendNotification(context.Background(), &order.Order{ID: "123456"}))
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotificationPropagatesTraceContext() {
+	var received http.Header
+	var body order.Order
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		s.NoError(json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "OrderUsecase.UpdateOrder")
+	defer span.End()
+
+	service := NewNotificationService("whatsapp", server.URL, discardLogger)
+	err := service.SendNotification(ctx, &order.Order{ID: "123456", Status: order.Finished})
+	s.Require().NoError(err)
+
+	traceID := span.SpanContext().TraceID().String()
+	spanID := span.SpanContext().SpanID().String()
+	s.Equal("00-"+traceID+"-"+spanID+"-01", received.Get("traceparent"))
+	s.Equal("whatsapp", received.Get("X-Notification-Client"))
+	s.Equal("123456", body.ID)
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotificationWebhookError() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	service := NewNotificationService("whatsapp", server.URL, discardLogger)
+	err := service.SendNotification(context.Background(), &order.Order{ID: "123456"})
+	s.Require().Error(err)
+	s.Equal("notification webhook answered with status 502", err.Error())
+}